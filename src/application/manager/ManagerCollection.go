@@ -1,8 +1,8 @@
 package manager
 
 import (
-	"errors"
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/Rafael24595/go-api-core/src/commons/utils"
@@ -10,6 +10,13 @@ import (
 	"github.com/Rafael24595/go-api-core/src/domain/action"
 	"github.com/Rafael24595/go-api-core/src/domain/collection"
 	"github.com/Rafael24595/go-api-core/src/domain/context"
+	"github.com/Rafael24595/go-api-core/src/domain/formatter/curl"
+	"github.com/Rafael24595/go-api-core/src/domain/formatter/docs"
+	"github.com/Rafael24595/go-api-core/src/domain/formatter/har"
+	"github.com/Rafael24595/go-api-core/src/domain/formatter/httpfile"
+	"github.com/Rafael24595/go-api-core/src/domain/formatter/insomnia"
+	"github.com/Rafael24595/go-api-core/src/domain/formatter/layout"
+	"github.com/Rafael24595/go-api-core/src/domain/formatter/postman"
 	"github.com/Rafael24595/go-api-core/src/domain/openapi"
 	"github.com/Rafael24595/go-api-core/src/infrastructure/dto"
 	go_collection "github.com/Rafael24595/go-collections/collection"
@@ -18,13 +25,15 @@ import (
 type ManagerCollection struct {
 	mu             sync.Mutex
 	collection     collection.Repository
+	contract       openapi.RepositoryContract
 	managerContext *ManagerContext
 	managerRequest *ManagerRequest
 }
 
-func NewManagerCollection(collection collection.Repository, managerContext *ManagerContext, managerRequest *ManagerRequest) *ManagerCollection {
+func NewManagerCollection(collection collection.Repository, contract openapi.RepositoryContract, managerContext *ManagerContext, managerRequest *ManagerRequest) *ManagerCollection {
 	return &ManagerCollection{
 		collection:     collection,
+		contract:       contract,
 		managerContext: managerContext,
 		managerRequest: managerRequest,
 	}
@@ -58,6 +67,36 @@ func (m *ManagerCollection) Find(owner string, id string) (*collection.Collectio
 	return collection, exists
 }
 
+// FindByTags returns the collections of the owner with any of the tags.
+func (m *ManagerCollection) FindByTags(owner string, tags ...string) []collection.Collection {
+	return go_collection.VectorFromList(m.collection.FindAll()).
+		Filter(func(c collection.Collection) bool {
+			return c.Owner == owner && domain.MatchTags(c.Tags, tags...)
+		}).
+		Collect()
+}
+
+// FindRunNodes returns the requests of the collection in run order, only the
+// requests with any of the tags are run when tags are given.
+func (m *ManagerCollection) FindRunNodes(owner string, id string, tags ...string) ([]action.NodeRequest, error) {
+	coll, exists := m.Find(owner, id)
+	if !exists {
+		return nil, fmt.Errorf("collection '%s' not found", id)
+	}
+
+	nodes := go_collection.VectorFromList(m.managerRequest.FindNodes(owner, coll.Nodes)).
+		Filter(func(n action.NodeRequest) bool {
+			return domain.MatchTags(n.Request.Tags, tags...)
+		}).
+		Collect()
+
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i].Order < nodes[j].Order
+	})
+
+	return nodes, nil
+}
+
 func (m *ManagerCollection) FindDto(owner string, id string) (*dto.DtoCollection, bool) {
 	collection, exists := m.Find(owner, id)
 	if !exists {
@@ -167,6 +206,35 @@ func (m *ManagerCollection) Insert(owner string, collection *collection.Collecti
 	return m.collection.Insert(owner, collection)
 }
 
+// Retag changes the tags of the collections, every collection of the owner is
+// changed when no id is given. It returns the changed collections.
+func (m *ManagerCollection) Retag(owner string, retag domain.Retag, ids ...string) []collection.Collection {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	colls := m.FindByTags(owner)
+	if len(ids) > 0 {
+		colls = make([]collection.Collection, 0, len(ids))
+		for _, v := range ids {
+			if coll, exists := m.Find(owner, v); exists {
+				colls = append(colls, *coll)
+			}
+		}
+	}
+
+	changed := make([]collection.Collection, 0)
+	for _, v := range colls {
+		tags, ok := retag(v.Tags)
+		if !ok {
+			continue
+		}
+		v.Tags = tags
+		changed = append(changed, *m.collection.Insert(owner, &v))
+	}
+
+	return changed
+}
+
 func (m *ManagerCollection) CollectRequest(owner string, payload PayloadCollectRequest) (*collection.Collection, *action.Request) {
 	request := &payload.Request
 	if request.Owner != owner {
@@ -220,15 +288,58 @@ func (m *ManagerCollection) moveRequest(owner string, payload PayloadCollectRequ
 }
 
 func (m *ManagerCollection) ImportOpenApi(owner string, file []byte) (*collection.Collection, error) {
-	oapi, raw, err := openapi.MakeFromJson(file)
+	oapi, raw, err := openapi.MakeFromFile(file)
 	if err != nil {
-		oapi, raw, err = openapi.MakeFromYaml(file)
-		if err != nil {
-			err = errors.New("the provided file has an invalid format, it must be an JSON or YAML")
-			return nil, err
-		}
+		return nil, err
+	}
+
+	coll, err := m.importOpenApi(owner, oapi, raw, nil)
+	if err != nil {
+		return nil, err
 	}
 
+	m.contract.Insert(owner, openapi.NewFileContract(owner, coll.Id, file))
+
+	return coll, nil
+}
+
+func (m *ManagerCollection) ImportOpenApiZip(owner string, file []byte) (*collection.Collection, error) {
+	bundle, err := openapi.BundleFromZip(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.ImportOpenApiBundle(owner, bundle)
+}
+
+func (m *ManagerCollection) ImportOpenApiBundle(owner string, bundle *openapi.Bundle) (*collection.Collection, error) {
+	oapi, raw, err := openapi.MakeFromBundle(bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	coll, err := m.importOpenApi(owner, oapi, raw, bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	m.contract.Insert(owner, openapi.NewBundleContract(owner, coll.Id, bundle))
+
+	return coll, nil
+}
+
+// FindContract returns the validator of the OpenAPI contract the collection
+// was imported from, the HTTP client checks the requests with it before they
+// are sent.
+func (m *ManagerCollection) FindContract(owner string, id string) (*openapi.ValidatorContract, error) {
+	contract, exists := m.contract.Find(id)
+	if !exists || contract.Owner != owner {
+		return nil, fmt.Errorf("contract '%s' not found", id)
+	}
+	return contract.Validator()
+}
+
+func (m *ManagerCollection) importOpenApi(owner string, oapi *openapi.OpenAPI, raw *map[string]any, bundle *openapi.Bundle) (*collection.Collection, error) {
 	version, err := utils.ParseVersion(oapi.OpenAPI)
 	if err != nil {
 		return nil, err
@@ -240,7 +351,10 @@ func (m *ManagerCollection) ImportOpenApi(owner string, file []byte) (*collectio
 		return nil, err
 	}
 
-	collection, ctx, requests, err := openapi.NewFactoryCollection(owner, oapi).SetRaw(*raw).Make()
+	collection, ctx, requests, err := openapi.NewFactoryCollection(owner, oapi).
+		SetRaw(*raw).
+		SetBundle(bundle).
+		Make()
 	if err != nil {
 		return nil, err
 	}
@@ -248,6 +362,458 @@ func (m *ManagerCollection) ImportOpenApi(owner string, file []byte) (*collectio
 	return m.insertResources(owner, collection, ctx, requests)
 }
 
+// SyncOpenApi updates a collection imported from an OpenAPI specification with
+// a newer version of it, preserving the values edited by the user. The
+// contract recorded on the import tells the edited values apart from the ones
+// generated by the previous version.
+func (m *ManagerCollection) SyncOpenApi(owner string, id string, file []byte) (*collection.Collection, *openapi.SyncReport, error) {
+	coll, exists := m.Find(owner, id)
+	if !exists {
+		return nil, nil, fmt.Errorf("collection '%s' not found", id)
+	}
+
+	oapi, raw, err := openapi.MakeFromFile(file)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nodes := m.managerRequest.FindNodes(owner, coll.Nodes)
+	current := make([]action.Request, len(nodes))
+	for i, v := range nodes {
+		current[i] = v.Request
+	}
+
+	factory := openapi.NewFactorySync(owner, oapi).
+		SetRaw(*raw)
+
+	if contract, exists := m.contract.Find(coll.Id); exists && contract.Owner == owner {
+		if previous, raw, bundle, err := contract.Load(); err == nil {
+			factory.SetPrevious(previous, *raw, bundle)
+		}
+	}
+
+	result := factory.Sync(current)
+
+	for i := range result.Updated {
+		m.managerRequest.Update(owner, &result.Updated[i])
+	}
+
+	result.Report.Context = m.syncContext(owner, coll, result.Context)
+
+	if len(result.Added) > 0 {
+		requestStatus := collection.StatusCollectionToStatusRequest(&coll.Status)
+		for i := range result.Added {
+			result.Added[i].Status = *requestStatus
+		}
+
+		added := m.managerRequest.InsertManyRequests(owner, result.Added)
+		for i, v := range added {
+			result.Report.Added[i].Request = v.Id
+			coll.Nodes = append(coll.Nodes, domain.NodeReference{
+				Order: len(coll.Nodes),
+				Item:  v.Id,
+			})
+		}
+
+		coll = m.Insert(owner, coll)
+	}
+
+	m.contract.Insert(owner, openapi.NewFileContract(owner, coll.Id, file))
+
+	return coll, &result.Report, nil
+}
+
+// syncContext adds the variables of the source context missing in the
+// collection context, the existing values are never overwritten.
+func (m *ManagerCollection) syncContext(owner string, coll *collection.Collection, source *context.Context) []string {
+	added := make([]string, 0)
+
+	ctx, exists := m.managerContext.Find(owner, coll.Context)
+	if !exists {
+		return added
+	}
+
+	target := dto.FromContext(ctx)
+	for c, cs := range dto.FromContext(source).Dictionary {
+		category, ok := target.Dictionary[c]
+		if !ok {
+			category = map[string]dto.DtoItemContext{}
+		}
+		for k, i := range cs {
+			if _, ok := category[k]; !ok {
+				category[k] = i
+				added = append(added, fmt.Sprintf("%s.%s", c, k))
+			}
+		}
+		target.Dictionary[c] = category
+	}
+
+	if len(added) > 0 {
+		m.managerContext.Update(owner, dto.ToContext(target))
+	}
+
+	sort.Strings(added)
+
+	return added
+}
+
+func (m *ManagerCollection) ImportHar(owner string, file []byte) (*collection.Collection, error) {
+	source, exchanges, err := har.Unmarshal(file)
+	if err != nil {
+		return nil, err
+	}
+
+	requests := make([]action.Request, len(exchanges))
+	for i, v := range exchanges {
+		v.Request.Owner = owner
+		requests[i] = v.Request
+	}
+
+	coll := collection.NewFreeCollection(owner)
+	coll.Name = fmt.Sprintf("[HAR] %s", source.Log.Creator.Name)
+	if len(source.Log.Pages) > 0 && source.Log.Pages[0].Title != "" {
+		coll.Name = fmt.Sprintf("[HAR] %s", source.Log.Pages[0].Title)
+	}
+
+	coll, err = m.insertResources(owner, coll, context.NewContext(owner), requests)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range coll.Nodes {
+		if v.Order >= len(exchanges) || exchanges[v.Order].Response == nil {
+			continue
+		}
+
+		response := exchanges[v.Order].Response
+		response.Id = ""
+		response.Request = v.Item
+		response.Owner = owner
+		m.managerRequest.InsertResponse(owner, response)
+	}
+
+	return coll, nil
+}
+
+func (m *ManagerCollection) ImportCurl(owner, name string, file []byte) (*collection.Collection, error) {
+	commands, err := curl.UnmarshalMany(file)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(commands) == 0 {
+		return nil, fmt.Errorf("no curl commands found")
+	}
+
+	requests := make([]action.Request, len(commands))
+	for i, v := range commands {
+		v.Request.Owner = owner
+		requests[i] = *v.Request
+	}
+
+	coll := collection.NewFreeCollection(owner)
+	coll.Name = "[cURL] Import"
+	if name != "" {
+		coll.Name = name
+	}
+
+	return m.insertResources(owner, coll, context.NewContext(owner), requests)
+}
+
+func (m *ManagerCollection) ImportHttpFile(owner, name string, file []byte, resolver httpfile.FileResolver) (*collection.Collection, error) {
+	source, err := httpfile.Unmarshal(owner, file, resolver)
+	if err != nil {
+		return nil, err
+	}
+
+	coll := collection.NewFreeCollection(owner)
+	coll.Name = "[HTTP] Import"
+	if name != "" {
+		coll.Name = name
+	}
+
+	return m.insertResources(owner, coll, source.Context, source.Requests)
+}
+
+func (m *ManagerCollection) ExportHttpFile(owner string, id string) ([]byte, error) {
+	coll, exists := m.Find(owner, id)
+	if !exists {
+		return nil, fmt.Errorf("collection '%s' not found", id)
+	}
+
+	ctx, _ := m.managerContext.Find(owner, coll.Context)
+
+	requests := make([]action.Request, 0)
+	for _, v := range m.managerRequest.FindNodes(owner, coll.Nodes) {
+		requests = append(requests, v.Request)
+	}
+
+	return httpfile.Marshal(ctx, requests...), nil
+}
+
+func (m *ManagerCollection) ExportLayout(owner string, id string, format layout.Format) (layout.Tree, error) {
+	coll, exists := m.Find(owner, id)
+	if !exists {
+		return nil, fmt.Errorf("collection '%s' not found", id)
+	}
+
+	return layout.MarshalCollection(format, m.layoutSource(owner, coll))
+}
+
+func (m *ManagerCollection) layoutSource(owner string, coll *collection.Collection) layout.Source {
+	ctx, _ := m.managerContext.Find(owner, coll.Context)
+
+	requests := make([]action.Request, 0)
+	for _, v := range m.managerRequest.FindNodes(owner, coll.Nodes) {
+		requests = append(requests, v.Request)
+	}
+
+	return layout.Source{
+		Collection: *coll,
+		Context:    ctx,
+		Requests:   requests,
+	}
+}
+
+func (m *ManagerCollection) ImportLayout(owner string, tree layout.Tree) (*collection.Collection, error) {
+	source, err := layout.UnmarshalCollection(tree)
+	if err != nil {
+		return nil, err
+	}
+	return m.importLayoutSource(owner, *source)
+}
+
+// importLayoutSource keeps the ids of the layout, so importing the same tree
+// again updates the collection instead of duplicating it. Ids that belong to
+// another owner are discarded and the requests removed from the layout are
+// deleted.
+func (m *ManagerCollection) importLayoutSource(owner string, source layout.Source) (*collection.Collection, error) {
+	coll := source.Collection
+	coll.Owner = owner
+	coll.Nodes = make([]domain.NodeReference, 0)
+
+	previous := make([]domain.NodeReference, 0)
+	if existing, exists := m.collection.Find(coll.Id); exists {
+		if existing.Owner != owner {
+			coll.Id = ""
+		} else {
+			previous = existing.Nodes
+		}
+	}
+
+	ctx := source.Context
+	if existing, exists := m.managerContext.context.Find(ctx.Id); exists && existing.Owner != owner {
+		ctx.Id = ""
+	}
+	ctx.Owner = owner
+
+	requests := make([]action.Request, len(source.Requests))
+	imported := make(map[string]bool)
+	for i, v := range source.Requests {
+		if existing, exists := m.managerRequest.request.Find(v.Id); exists && existing.Owner != owner {
+			v.Id = ""
+		}
+		v.Owner = owner
+		imported[v.Id] = true
+		requests[i] = v
+	}
+
+	result, err := m.insertResources(owner, &coll, ctx, requests)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range previous {
+		if !imported[v.Item] {
+			m.managerRequest.deleteById(owner, v.Item)
+		}
+	}
+
+	return result, nil
+}
+
+func (m *ManagerCollection) ExportDocs(owner string, id string, format docs.Format) ([]byte, error) {
+	coll, exists := m.Find(owner, id)
+	if !exists {
+		return nil, fmt.Errorf("collection '%s' not found", id)
+	}
+
+	return docs.Marshal(format, coll.Name, m.docsSource(owner, coll))
+}
+
+func (m *ManagerCollection) docsSource(owner string, coll *collection.Collection) docs.Source {
+	requests := make([]action.Request, 0)
+	responses := make(map[string]action.Response)
+	for _, v := range m.managerRequest.FindNodes(owner, coll.Nodes) {
+		requests = append(requests, v.Request)
+		if response, ok := m.managerRequest.FindReference(owner, v.Request.Id); ok {
+			responses[v.Request.Id] = *response
+		}
+	}
+
+	return docs.Source{
+		Collection: *coll,
+		Requests:   requests,
+		Responses:  responses,
+	}
+}
+
+func (m *ManagerCollection) ExportHar(owner string, id string) ([]byte, error) {
+	coll, exists := m.Find(owner, id)
+	if !exists {
+		return nil, fmt.Errorf("collection '%s' not found", id)
+	}
+
+	exchanges := make([]har.Exchange, 0)
+	for _, v := range m.managerRequest.FindNodes(owner, coll.Nodes) {
+		response, _ := m.managerRequest.FindResponse(owner, v.Request.Id)
+		exchanges = append(exchanges, har.Exchange{
+			Request:  v.Request,
+			Response: response,
+		})
+	}
+
+	return har.Marshal(exchanges...)
+}
+
+func (m *ManagerCollection) ExportOpenApi(owner string, id string, format string) ([]byte, error) {
+	coll, exists := m.Find(owner, id)
+	if !exists {
+		return nil, fmt.Errorf("collection '%s' not found", id)
+	}
+
+	ctx, _ := m.managerContext.Find(owner, coll.Context)
+
+	factory := openapi.NewFactoryOpenApi(coll, ctx)
+	for _, v := range m.managerRequest.FindNodes(owner, coll.Nodes) {
+		response, _ := m.managerRequest.FindResponse(owner, v.Request.Id)
+		factory.AddRequest(v.Request, response)
+	}
+
+	oapi := factory.Make()
+
+	switch format {
+	case "json":
+		return openapi.SerializeToJson(oapi)
+	case "yaml", "yml", "":
+		return openapi.SerializeToYaml(oapi)
+	default:
+		return nil, fmt.Errorf("unsupported OpenAPI format '%s'", format)
+	}
+}
+
+func (m *ManagerCollection) ImportPostman(owner string, file []byte, environments ...[]byte) (*collection.Collection, error) {
+	source, err := postman.Unmarshal(owner, file, environments...)
+	if err != nil {
+		return nil, err
+	}
+
+	coll := collection.NewFreeCollection(owner)
+	coll.Name = source.Name
+
+	return m.insertResources(owner, coll, source.Context, source.Requests())
+}
+
+func (m *ManagerCollection) ImportPostmanFolders(owner string, file []byte, environments ...[]byte) ([]collection.Collection, error) {
+	source, err := postman.Unmarshal(owner, file, environments...)
+	if err != nil {
+		return nil, err
+	}
+
+	collections := make([]collection.Collection, 0, len(source.Folders))
+	for _, v := range source.Folders {
+		coll := collection.NewFreeCollection(owner)
+		coll.Name = source.Name
+		if v.Name != "" {
+			coll.Name = fmt.Sprintf("%s - %s", source.Name, v.Name)
+		}
+
+		ctx := dto.ToContext(dto.FromContext(source.Context))
+		ctx.Id = ""
+
+		coll, err := m.insertResources(owner, coll, ctx, v.Requests)
+		if err != nil {
+			return collections, err
+		}
+
+		collections = append(collections, *coll)
+	}
+
+	return collections, nil
+}
+
+func (m *ManagerCollection) ExportPostman(owner string, id string) ([]byte, error) {
+	coll, exists := m.Find(owner, id)
+	if !exists {
+		return nil, fmt.Errorf("collection '%s' not found", id)
+	}
+
+	ctx, _ := m.managerContext.Find(owner, coll.Context)
+
+	return postman.Marshal(coll.Name, ctx, m.postmanFolder("", coll))
+}
+
+func (m *ManagerCollection) ExportPostmanFolders(owner, name string, ids ...string) ([]byte, error) {
+	folders := make([]postman.Folder, 0)
+	merged := dto.FromContext(context.NewContext(owner))
+
+	for _, v := range ids {
+		coll, exists := m.Find(owner, v)
+		if !exists {
+			continue
+		}
+
+		if ctx, exists := m.managerContext.Find(owner, coll.Context); exists {
+			for c, cs := range dto.FromContext(ctx).Dictionary {
+				category, ok := merged.Dictionary[c]
+				if !ok {
+					category = map[string]dto.DtoItemContext{}
+				}
+				for k, i := range cs {
+					if _, ok := category[k]; !ok {
+						category[k] = i
+					}
+				}
+				merged.Dictionary[c] = category
+			}
+		}
+
+		folders = append(folders, m.postmanFolder(coll.Name, coll))
+	}
+
+	return postman.Marshal(name, dto.ToContext(merged), folders...)
+}
+
+func (m *ManagerCollection) postmanFolder(name string, coll *collection.Collection) postman.Folder {
+	nodes := m.managerRequest.FindNodes(coll.Owner, coll.Nodes)
+	requests := make([]action.Request, len(nodes))
+	for i, v := range nodes {
+		requests[i] = v.Request
+	}
+
+	return postman.Folder{
+		Name:     name,
+		Requests: requests,
+	}
+}
+
+func (m *ManagerCollection) ImportInsomniaCollections(owner string, sources ...insomnia.Collection) ([]collection.Collection, error) {
+	collections := make([]collection.Collection, 0, len(sources))
+	for _, v := range sources {
+		coll := collection.NewFreeCollection(owner)
+		coll.Name = v.Name
+
+		coll, err := m.insertResources(owner, coll, v.Context, v.Requests)
+		if err != nil {
+			return collections, err
+		}
+
+		collections = append(collections, *coll)
+	}
+
+	return collections, nil
+}
+
 func (m *ManagerCollection) ImportDtoCollections(owner string, dtos ...dto.DtoCollection) ([]collection.Collection, error) {
 	collections := make([]collection.Collection, len(dtos))
 
@@ -509,6 +1075,10 @@ func (m *ManagerCollection) Delete(owner, id string) *collection.Collection {
 
 	m.managerRequest.DeleteMany(owner, requests...)
 
+	if contract, exists := m.contract.Find(collection.Id); exists {
+		m.contract.Delete(*contract)
+	}
+
 	return m.collection.Delete(collection)
 }
 
@@ -5,6 +5,7 @@ import (
 
 	"github.com/Rafael24595/go-api-core/src/domain"
 	"github.com/Rafael24595/go-api-core/src/domain/mock"
+	"github.com/Rafael24595/go-api-core/src/domain/openapi"
 	"github.com/Rafael24595/go-collections/collection"
 )
 
@@ -46,6 +47,33 @@ func (m *ManagerEndPoint) Import(owner string, endPoints []mock.EndPoint) []stri
 	}).Collect()
 }
 
+func (m *ManagerEndPoint) ImportOpenApi(owner string, file []byte) ([]string, error) {
+	oapi, raw, err := openapi.MakeFromFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	endPoints := openapi.NewFactoryMock(owner, oapi).
+		SetRaw(*raw).
+		Make()
+
+	return m.Import(owner, endPoints), nil
+}
+
+func (m *ManagerEndPoint) ImportOpenApiBundle(owner string, bundle *openapi.Bundle) ([]string, error) {
+	oapi, raw, err := openapi.MakeFromBundle(bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	endPoints := openapi.NewFactoryMock(owner, oapi).
+		SetRaw(*raw).
+		SetBundle(bundle).
+		Make()
+
+	return m.Import(owner, endPoints), nil
+}
+
 func (m *ManagerEndPoint) FindAll(owner string) []mock.EndPointLite {
 	endPoints := m.endPoint.FindAll(owner)
 	return collection.MapToVector(endPoints, func(e mock.EndPoint) mock.EndPointLite {
@@ -53,6 +81,15 @@ func (m *ManagerEndPoint) FindAll(owner string) []mock.EndPointLite {
 	}).Collect()
 }
 
+// FindByTags returns the end points of the owner with any of the tags.
+func (m *ManagerEndPoint) FindByTags(owner string, tags ...string) []mock.EndPointLite {
+	return collection.VectorFromList(m.FindAll(owner)).
+		Filter(func(e mock.EndPointLite) bool {
+			return domain.MatchTags(e.Tags, tags...)
+		}).
+		Collect()
+}
+
 func (m *ManagerEndPoint) Find(owner, id string) (*mock.EndPoint, bool) {
 	endPoint, ok := m.endPoint.Find(id)
 	if !ok || endPoint.Owner != owner {
@@ -111,6 +148,27 @@ func (m *ManagerEndPoint) Insert(owner string, endPoint *mock.EndPointFull) (*mo
 	return m.endPoint.Insert(result), make([]error, 0)
 }
 
+// Retag changes the tags of the end points, every end point of the owner is
+// changed when no id is given. It returns the changed end points.
+func (m *ManagerEndPoint) Retag(owner string, retag domain.Retag, ids ...string) []mock.EndPoint {
+	endPoints := m.ExportList(owner, ids...)
+	if len(ids) == 0 {
+		endPoints = m.Export(owner)
+	}
+
+	changed := make([]mock.EndPoint, 0)
+	for _, v := range endPoints {
+		tags, ok := retag(v.Tags)
+		if !ok {
+			continue
+		}
+		v.Tags = tags
+		changed = append(changed, *m.endPoint.Insert(mock.FixEndPoint(owner, &v)))
+	}
+
+	return changed
+}
+
 func (m *ManagerEndPoint) Delete(owner string, id string) *mock.EndPoint {
 	endPoint, ok := m.endPoint.Find(id)
 	if !ok || endPoint.Owner != owner {
@@ -1,12 +1,17 @@
 package manager
 
 import (
+	"fmt"
 	"sync"
 
 	"github.com/Rafael24595/go-api-core/src/domain"
 	"github.com/Rafael24595/go-api-core/src/domain/action"
 	"github.com/Rafael24595/go-api-core/src/domain/collection"
+	"github.com/Rafael24595/go-api-core/src/domain/formatter/docs"
+	"github.com/Rafael24595/go-api-core/src/domain/formatter/insomnia"
+	"github.com/Rafael24595/go-api-core/src/domain/formatter/layout"
 	"github.com/Rafael24595/go-api-core/src/domain/group"
+	"github.com/Rafael24595/go-api-core/src/domain/openapi"
 	"github.com/Rafael24595/go-api-core/src/infrastructure/dto"
 )
 
@@ -78,6 +83,115 @@ func (m *ManagerGroup) ImportOpenApi(owner string, group *group.Group, file []by
 	return m.resolveCollectionReferences(owner, group, *collection), collection, nil
 }
 
+func (m *ManagerGroup) ImportOpenApiBundle(owner string, group *group.Group, bundle *openapi.Bundle) (*group.Group, *collection.Collection, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	collection, err := m.managerCollection.ImportOpenApiBundle(owner, bundle)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return m.resolveCollectionReferences(owner, group, *collection), collection, nil
+}
+
+func (m *ManagerGroup) ImportPostman(owner string, group *group.Group, file []byte, environments ...[]byte) (*group.Group, []collection.Collection, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	collections, err := m.managerCollection.ImportPostmanFolders(owner, file, environments...)
+	if err != nil {
+		return nil, collections, err
+	}
+
+	return m.resolveCollectionReferences(owner, group, collections...), collections, nil
+}
+
+func (m *ManagerGroup) ExportPostman(owner, name string, group *group.Group) ([]byte, error) {
+	if group.Owner != owner {
+		return nil, fmt.Errorf("group '%s' not found", group.Id)
+	}
+
+	ids := make([]string, len(group.SortNodes().Nodes))
+	for i, v := range group.Nodes {
+		ids[i] = v.Item
+	}
+
+	return m.managerCollection.ExportPostmanFolders(owner, name, ids...)
+}
+
+func (m *ManagerGroup) ExportDocs(owner, title string, group *group.Group, format docs.Format) ([]byte, error) {
+	if group.Owner != owner {
+		return nil, fmt.Errorf("group '%s' not found", group.Id)
+	}
+
+	sources := make([]docs.Source, 0, len(group.Nodes))
+	for _, v := range group.SortNodes().Nodes {
+		coll, exists := m.managerCollection.Find(owner, v.Item)
+		if !exists {
+			continue
+		}
+		sources = append(sources, m.managerCollection.docsSource(owner, coll))
+	}
+
+	return docs.Marshal(format, title, sources...)
+}
+
+func (m *ManagerGroup) ImportInsomniaInto(owner string, group *group.Group, file []byte) (*group.Group, []collection.Collection, *insomnia.Report, error) {
+	source, err := insomnia.Unmarshal(owner, file)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	collections, err := m.managerCollection.ImportInsomniaCollections(owner, source.Collections()...)
+	if err != nil {
+		return nil, collections, &source.Report, err
+	}
+
+	return m.resolveCollectionReferences(owner, group, collections...), collections, &source.Report, nil
+}
+
+func (m *ManagerGroup) ExportLayout(owner string, group *group.Group, format layout.Format) (layout.Tree, error) {
+	if group.Owner != owner {
+		return nil, fmt.Errorf("group '%s' not found", group.Id)
+	}
+
+	sources := make([]layout.Source, 0, len(group.Nodes))
+	for _, v := range group.Nodes {
+		coll, exists := m.managerCollection.Find(owner, v.Item)
+		if !exists {
+			continue
+		}
+		sources = append(sources, m.managerCollection.layoutSource(owner, coll))
+	}
+
+	return layout.MarshalGroup(format, group, sources...)
+}
+
+func (m *ManagerGroup) ImportLayout(owner string, group *group.Group, tree layout.Tree) (*group.Group, []collection.Collection, error) {
+	_, sources, err := layout.UnmarshalGroup(tree)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	collections := make([]collection.Collection, 0, len(sources))
+	for _, v := range sources {
+		coll, err := m.managerCollection.importLayoutSource(owner, v)
+		if err != nil {
+			return nil, collections, err
+		}
+		collections = append(collections, *coll)
+	}
+
+	return m.resolveCollectionReferences(owner, group, collections...), collections, nil
+}
+
 func (m *ManagerGroup) ImportDtoCollections(owner string, group *group.Group, dtos ...dto.DtoCollection) (*group.Group, []collection.Collection, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
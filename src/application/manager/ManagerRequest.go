@@ -1,24 +1,31 @@
 package manager
 
 import (
+	"fmt"
 	"sync"
 	"time"
 
 	"github.com/Rafael24595/go-api-core/src/domain"
 	"github.com/Rafael24595/go-api-core/src/domain/action"
+	"github.com/Rafael24595/go-api-core/src/domain/diff"
+	"github.com/Rafael24595/go-api-core/src/domain/openapi"
 	"github.com/Rafael24595/go-collections/collection"
 )
 
 type ManagerRequest struct {
-	mu       sync.Mutex
-	request  action.RepositoryRequest
-	response action.RepositoryResponse
+	mu              sync.Mutex
+	request         action.RepositoryRequest
+	response        action.RepositoryResponse
+	managerRevision *ManagerRevision
+	retention       int
 }
 
-func NewManagerRequest(request action.RepositoryRequest, response action.RepositoryResponse) *ManagerRequest {
+func NewManagerRequest(request action.RepositoryRequest, response action.RepositoryResponse, managerRevision *ManagerRevision, retention int) *ManagerRequest {
 	return &ManagerRequest{
-		request:  request,
-		response: response,
+		request:         request,
+		response:        response,
+		managerRevision: managerRevision,
+		retention:       retention,
 	}
 }
 
@@ -43,11 +50,20 @@ func (m *ManagerRequest) Find(owner string, key string) (*action.Request, *actio
 	if !exits || request.Owner != owner {
 		return nil, nil, exits
 	}
-	response, _ := m.response.Find(key)
+	response, _ := action.LastResponse(m.response.FindByRequest(key))
 
 	return request, response, exits
 }
 
+// FindByTags returns the requests of the owner with any of the tags.
+func (m *ManagerRequest) FindByTags(owner string, tags ...string) []action.Request {
+	return collection.VectorFromList(m.request.FindAll()).
+		Filter(func(r action.Request) bool {
+			return r.Owner == owner && domain.MatchTags(r.Tags, tags...)
+		}).
+		Collect()
+}
+
 func (m *ManagerRequest) FindRequest(owner string, key string) (*action.Request, bool) {
 	request, exits := m.request.Find(key)
 	if !exits || request.Owner != owner {
@@ -56,12 +72,114 @@ func (m *ManagerRequest) FindRequest(owner string, key string) (*action.Request,
 	return request, exits
 }
 
+// FindResponse returns the last response of the request.
 func (m *ManagerRequest) FindResponse(owner string, key string) (*action.Response, bool) {
-	response, exits := m.response.Find(key)
-	if !exits || response.Owner != owner {
-		return nil, exits
+	return action.LastResponse(m.FindHistory(owner, key))
+}
+
+// FindReference returns the pinned response of the request or the last one
+// if nothing is pinned.
+func (m *ManagerRequest) FindReference(owner string, key string) (*action.Response, bool) {
+	return action.ReferenceResponse(m.FindHistory(owner, key))
+}
+
+// FindHistory returns the responses of the request from the newest to the
+// oldest.
+func (m *ManagerRequest) FindHistory(owner string, key string) []action.Response {
+	return collection.VectorFromList(m.response.FindByRequest(key)).
+		Filter(func(r action.Response) bool {
+			return r.Owner == owner
+		}).
+		Collect()
+}
+
+func (m *ManagerRequest) FindHistoryResponse(owner string, id string) (*action.Response, bool) {
+	response, exists := m.response.Find(id)
+	if !exists || response.Owner != owner {
+		return nil, false
+	}
+	return response, true
+}
+
+// CompareResponses compares two responses of the history, they may belong to
+// different requests to compare the same call across environments.
+func (m *ManagerRequest) CompareResponses(owner string, before, after string, engine *diff.DiffEngine) (*diff.Report, error) {
+	source, exists := m.FindHistoryResponse(owner, before)
+	if !exists {
+		return nil, fmt.Errorf("response '%s' not found", before)
+	}
+
+	target, exists := m.FindHistoryResponse(owner, after)
+	if !exists {
+		return nil, fmt.Errorf("response '%s' not found", after)
+	}
+
+	if engine == nil {
+		engine = diff.NewDiffEngine()
+	}
+
+	report := engine.Compare(*source, *target)
+	return &report, nil
+}
+
+// PinResponse marks the response as the reference example of its request,
+// the previous pinned response of the request is released.
+func (m *ManagerRequest) PinResponse(owner string, id string) (*action.Response, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	response, exists := m.response.Find(id)
+	if !exists || response.Owner != owner {
+		return nil, fmt.Errorf("response '%s' not found", id)
+	}
+
+	for _, v := range m.response.FindByRequest(response.RequestId()) {
+		if v.Pinned && v.Id != response.Id {
+			v.Pinned = false
+			m.response.Insert(owner, &v)
+		}
+	}
+
+	response.Pinned = true
+	return m.response.Insert(owner, response), nil
+}
+
+// UnpinResponse releases the response, so it expires again with the rest of
+// the history.
+func (m *ManagerRequest) UnpinResponse(owner string, id string) (*action.Response, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	response, exists := m.response.Find(id)
+	if !exists || response.Owner != owner {
+		return nil, fmt.Errorf("response '%s' not found", id)
+	}
+
+	response.Pinned = false
+	response = m.response.Insert(owner, response)
+
+	m.expireResponses(owner, response.RequestId())
+
+	return response, nil
+}
+
+func (m *ManagerRequest) ValidateOpenApi(owner string, key string, file []byte) ([]openapi.Violation, error) {
+	request, response, exists := m.Find(owner, key)
+	if !exists || request == nil {
+		return nil, fmt.Errorf("request '%s' not found", key)
 	}
-	return response, exits
+	if response == nil {
+		return nil, fmt.Errorf("request '%s' has no response to validate", key)
+	}
+
+	oapi, raw, err := openapi.MakeFromFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return openapi.NewValidatorContract(oapi).
+		SetRaw(*raw).
+		Validate(*request, *response)
 }
 
 func (m *ManagerRequest) FindLiteNodes(owner string, references []domain.NodeReference) []action.NodeRequestLite {
@@ -105,11 +223,10 @@ func (m *ManagerRequest) Insert(owner string, request *action.Request, response
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	requestResult := m.request.Insert(owner, request)
+	requestResult := m.insertRequest(owner, request, "")
 
-	response.Id = requestResult.Id
 	response.Request = requestResult.Id
-	resultResponse := m.response.Insert(owner, response)
+	resultResponse := m.insertResponse(owner, response)
 
 	return requestResult, resultResponse
 }
@@ -118,14 +235,44 @@ func (m *ManagerRequest) InsertRequest(owner string, request *action.Request) *a
 	if m.isNotOwner(owner, request, nil) {
 		return nil
 	}
-	return m.request.Insert(owner, request)
+	return m.insertRequest(owner, request, "")
 }
 
 func (m *ManagerRequest) InsertResponse(owner string, response *action.Response) *action.Response {
 	if m.isNotOwner(owner, nil, response) {
 		return nil
 	}
-	return m.response.Insert(owner, response)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.insertResponse(owner, response)
+}
+
+// insertResponse appends the response to the history of its request, a known
+// response of the same request is updated instead. The oldest responses out of
+// the retention are removed.
+func (m *ManagerRequest) insertResponse(owner string, response *action.Response) *action.Response {
+	if response.Id != "" {
+		old, exists := m.response.Find(response.Id)
+		if !exists || old.Owner != owner || old.RequestId() != response.Request {
+			response.Id = ""
+		} else {
+			response.Pinned = old.Pinned
+		}
+	}
+
+	response = m.response.Insert(owner, response)
+	m.expireResponses(owner, response.RequestId())
+
+	return response
+}
+
+func (m *ManagerRequest) expireResponses(owner string, request string) {
+	history := m.FindHistory(owner, request)
+	if expired := action.ExpiredResponses(history, m.retention); len(expired) > 0 {
+		m.response.DeleteMany(expired...)
+	}
 }
 
 func (m *ManagerRequest) InsertManyRequests(owner string, requests []action.Request) []action.Request {
@@ -134,7 +281,22 @@ func (m *ManagerRequest) InsertManyRequests(owner string, requests []action.Requ
 			return m.isOwner(owner, &r, nil)
 		}).
 		Collect()
-	return m.request.InsertMany(owner, requests)
+
+	previous := make(map[string]*action.Request)
+	for _, v := range requests {
+		if old, exists := m.findOwned(owner, v.Id); exists {
+			previous[v.Id] = old
+		}
+	}
+
+	requests = m.request.InsertMany(owner, requests)
+	for _, v := range requests {
+		if old, exists := previous[v.Id]; exists {
+			m.managerRevision.record(owner, old, v, "")
+		}
+	}
+
+	return requests
 }
 
 func (m *ManagerRequest) Update(owner string, request *action.Request) *action.Request {
@@ -147,7 +309,73 @@ func (m *ManagerRequest) Update(owner string, request *action.Request) *action.R
 		request.Name = oldRequest.Name
 	}
 
-	return m.request.Insert(owner, request)
+	return m.insertRequest(owner, request, "")
+}
+
+// RestoreRevision overwrites the request with the state of the revision, the
+// restore is recorded as a new revision so it can be undone as well.
+func (m *ManagerRequest) RestoreRevision(owner string, id string) (*action.Request, error) {
+	revision, exists := m.managerRevision.FindRevision(owner, id)
+	if !exists {
+		return nil, fmt.Errorf("revision '%s' not found", id)
+	}
+
+	oldRequest, exists := m.request.Find(revision.Request)
+	if !exists || oldRequest.Owner != owner {
+		return nil, fmt.Errorf("request '%s' not found", revision.Request)
+	}
+
+	request := revision.Snapshot
+	request.Id = oldRequest.Id
+	request.Owner = oldRequest.Owner
+	request.Timestamp = oldRequest.Timestamp
+
+	return m.insertRequest(owner, &request, revision.Id), nil
+}
+
+// insertRequest stores the request, overwriting a stored request of the owner
+// records a revision so every change can be restored.
+func (m *ManagerRequest) insertRequest(owner string, request *action.Request, restored string) *action.Request {
+	oldRequest, exists := m.findOwned(owner, request.Id)
+
+	result := m.request.Insert(owner, request)
+	if exists {
+		m.managerRevision.record(owner, oldRequest, *result, restored)
+	}
+
+	return result
+}
+
+func (m *ManagerRequest) findOwned(owner string, id string) (*action.Request, bool) {
+	if id == "" {
+		return nil, false
+	}
+	request, exists := m.request.Find(id)
+	if !exists || request.Owner != owner {
+		return nil, false
+	}
+	return request, true
+}
+
+// Retag changes the tags of the requests, every request of the owner is
+// changed when no id is given. It returns the changed requests.
+func (m *ManagerRequest) Retag(owner string, retag domain.Retag, ids ...string) []action.Request {
+	requests := m.ExportList(owner, ids...)
+	if len(ids) == 0 {
+		requests = m.FindByTags(owner)
+	}
+
+	changed := make([]action.Request, 0)
+	for _, v := range requests {
+		tags, ok := retag(v.Tags)
+		if !ok {
+			continue
+		}
+		v.Tags = tags
+		changed = append(changed, *m.Update(owner, &v))
+	}
+
+	return changed
 }
 
 func (m *ManagerRequest) Delete(owner string, request *action.Request) (*action.Request, *action.Response) {
@@ -157,16 +385,19 @@ func (m *ManagerRequest) Delete(owner string, request *action.Request) (*action.
 	return m.deleteById(owner, request.Id)
 }
 
+// deleteById removes the request with its response history and returns the
+// last response removed.
 func (m *ManagerRequest) deleteById(owner, id string) (*action.Request, *action.Response) {
 	request, exists := m.request.Find(id)
 	if exists && request.Owner == owner {
 		request = m.request.Delete(request)
 	}
 
-	response, exists := m.response.Find(id)
-	if exists && response.Owner != owner {
-		response = m.response.Delete(response)
-	}
+	history := m.FindHistory(owner, id)
+	response, _ := action.LastResponse(history)
+	m.response.DeleteMany(history...)
+
+	m.managerRevision.deleteByRequest(owner, id)
 
 	return request, response
 }
@@ -174,6 +405,7 @@ func (m *ManagerRequest) deleteById(owner, id string) (*action.Request, *action.
 func (m *ManagerRequest) DeleteMany(owner string, ids ...string) ([]action.Request, []action.Response) {
 	requests := m.deleteManyRequests(owner, ids...)
 	responses := m.deleteManyResponses(owner, ids...)
+	m.managerRevision.deleteByRequest(owner, ids...)
 	return requests, responses
 }
 
@@ -188,7 +420,7 @@ func (m *ManagerRequest) deleteManyRequests(owner string, ids ...string) []actio
 }
 
 func (m *ManagerRequest) deleteManyResponses(owner string, ids ...string) []action.Response {
-	responses := m.response.FindMany(ids)
+	responses := m.response.FindByRequest(ids...)
 	responses = collection.VectorFromList(responses).
 		Filter(func(r action.Response) bool {
 			return r.Owner == owner
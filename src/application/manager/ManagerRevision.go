@@ -0,0 +1,100 @@
+package manager
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	"github.com/Rafael24595/go-api-core/src/domain/diff"
+	"github.com/Rafael24595/go-collections/collection"
+)
+
+type ManagerRevision struct {
+	mu        sync.Mutex
+	revision  action.RepositoryRevision
+	retention int
+}
+
+func NewManagerRevision(revision action.RepositoryRevision, retention int) *ManagerRevision {
+	return &ManagerRevision{
+		revision:  revision,
+		retention: retention,
+	}
+}
+
+// FindRevisions returns the revisions of the request from the newest to the
+// oldest.
+func (m *ManagerRevision) FindRevisions(owner string, request string) []action.Revision {
+	return collection.VectorFromList(m.revision.FindByRequest(request)).
+		Filter(func(r action.Revision) bool {
+			return r.Owner == owner
+		}).
+		Collect()
+}
+
+func (m *ManagerRevision) FindRevision(owner string, id string) (*action.Revision, bool) {
+	revision, exists := m.revision.Find(id)
+	if !exists || revision.Owner != owner {
+		return nil, false
+	}
+	return revision, true
+}
+
+// CompareRevisions compares the request state of two revisions.
+func (m *ManagerRevision) CompareRevisions(owner string, before, after string) (*diff.Report, error) {
+	source, exists := m.FindRevision(owner, before)
+	if !exists {
+		return nil, fmt.Errorf("revision '%s' not found", before)
+	}
+
+	target, exists := m.FindRevision(owner, after)
+	if !exists {
+		return nil, fmt.Errorf("revision '%s' not found", after)
+	}
+
+	report := diff.NewDiffEngineEmpty().CompareRequests(source.Snapshot, target.Snapshot)
+	return &report, nil
+}
+
+// record stores the new state of the request. The previous state is stored
+// first when the request has no revisions yet, so the first update can be
+// restored too. Updates without changes are not recorded.
+func (m *ManagerRevision) record(owner string, before *action.Request, after action.Request, restored string) *action.Revision {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	revision := action.NewRevision(owner, before, after)
+	if before != nil && len(revision.Changes) == 0 {
+		return nil
+	}
+
+	history := m.FindRevisions(owner, after.Id)
+	if before != nil && len(history) == 0 {
+		baseline := action.NewRevision(owner, nil, *before)
+		baseline.Sequence = action.NextSequence(history)
+		history = append(history, *m.revision.Insert(owner, baseline))
+	}
+
+	revision.Sequence = action.NextSequence(history)
+	revision.Restored = restored
+	revision = m.revision.Insert(owner, revision)
+
+	history = m.FindRevisions(owner, after.Id)
+	if expired := action.ExpiredRevisions(history, m.retention); len(expired) > 0 {
+		m.revision.DeleteMany(expired...)
+	}
+
+	return revision
+}
+
+func (m *ManagerRevision) deleteByRequest(owner string, requests ...string) []action.Revision {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	revisions := collection.VectorFromList(m.revision.FindByRequest(requests...)).
+		Filter(func(r action.Revision) bool {
+			return r.Owner == owner
+		}).
+		Collect()
+	return m.revision.DeleteMany(revisions...)
+}
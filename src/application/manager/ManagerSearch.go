@@ -0,0 +1,21 @@
+package manager
+
+import (
+	"github.com/Rafael24595/go-api-core/src/domain/search"
+)
+
+type ManagerSearch struct {
+	index *search.Index
+}
+
+func NewManagerSearch(index *search.Index) *ManagerSearch {
+	return &ManagerSearch{
+		index: index,
+	}
+}
+
+// Search looks for the requests, responses and collections of the owner
+// that match the query.
+func (m *ManagerSearch) Search(owner string, query search.Query) []search.Hit {
+	return m.index.Search(owner, query)
+}
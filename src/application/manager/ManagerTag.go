@@ -0,0 +1,190 @@
+package manager
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	"github.com/Rafael24595/go-api-core/src/domain/collection"
+	"github.com/Rafael24595/go-api-core/src/domain/mock"
+	go_collection "github.com/Rafael24595/go-collections/collection"
+)
+
+// TagSummary is a tag of the owner with the number of items that use it.
+type TagSummary struct {
+	Tag         string `json:"tag"`
+	Requests    int    `json:"requests"`
+	Collections int    `json:"collections"`
+	EndPoints   int    `json:"end_points"`
+}
+
+type TagItems struct {
+	Requests    []action.RequestLite    `json:"requests"`
+	Collections []collection.Collection `json:"collections"`
+	EndPoints   []mock.EndPointLite     `json:"end_points"`
+}
+
+type ManagerTag struct {
+	mu                sync.Mutex
+	managerRequest    *ManagerRequest
+	managerCollection *ManagerCollection
+	managerEndPoint   *ManagerEndPoint
+}
+
+func NewManagerTag(managerRequest *ManagerRequest, managerCollection *ManagerCollection, managerEndPoint *ManagerEndPoint) *ManagerTag {
+	return &ManagerTag{
+		managerRequest:    managerRequest,
+		managerCollection: managerCollection,
+		managerEndPoint:   managerEndPoint,
+	}
+}
+
+// FindTags returns every tag used by the owner sorted by name.
+func (m *ManagerTag) FindTags(owner string) []TagSummary {
+	summaries := make(map[string]*TagSummary)
+	summary := func(tag string) *TagSummary {
+		if _, ok := summaries[tag]; !ok {
+			summaries[tag] = &TagSummary{Tag: tag}
+		}
+		return summaries[tag]
+	}
+
+	for _, v := range m.managerRequest.FindByTags(owner) {
+		for _, t := range domain.NormalizeTags(v.Tags...) {
+			summary(t).Requests++
+		}
+	}
+
+	for _, v := range m.managerCollection.FindByTags(owner) {
+		for _, t := range domain.NormalizeTags(v.Tags...) {
+			summary(t).Collections++
+		}
+	}
+
+	for _, v := range m.managerEndPoint.FindAll(owner) {
+		for _, t := range domain.NormalizeTags(v.Tags...) {
+			summary(t).EndPoints++
+		}
+	}
+
+	result := make([]TagSummary, 0, len(summaries))
+	for _, v := range summaries {
+		result = append(result, *v)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Tag < result[j].Tag
+	})
+
+	return result
+}
+
+// Find returns the items of the owner with any of the tags.
+func (m *ManagerTag) Find(owner string, tags ...string) *TagItems {
+	if len(domain.NormalizeTags(tags...)) == 0 {
+		return makeTagItems(nil, nil, nil)
+	}
+
+	return makeTagItems(
+		m.managerRequest.FindByTags(owner, tags...),
+		m.managerCollection.FindByTags(owner, tags...),
+		m.managerEndPoint.FindByTags(owner, tags...),
+	)
+}
+
+// Tag adds the tags to the items of the payload, it returns the items that
+// changed.
+func (m *ManagerTag) Tag(owner string, payload PayloadTag) *TagItems {
+	return m.retagItems(owner, payload, func(tags []string) ([]string, bool) {
+		return domain.AddTags(tags, payload.Tags...)
+	})
+}
+
+// Untag removes the tags from the items of the payload, it returns the items
+// that changed.
+func (m *ManagerTag) Untag(owner string, payload PayloadTag) *TagItems {
+	return m.retagItems(owner, payload, func(tags []string) ([]string, bool) {
+		return domain.RemoveTags(tags, payload.Tags...)
+	})
+}
+
+// Rename renames the tag in every item of the owner.
+func (m *ManagerTag) Rename(owner, old, new string) (*TagItems, error) {
+	if domain.NormalizeTag(old) == "" || domain.NormalizeTag(new) == "" {
+		return nil, fmt.Errorf("invalid tag name")
+	}
+
+	return m.retagAll(owner, func(tags []string) ([]string, bool) {
+		return domain.RenameTag(tags, old, new)
+	}), nil
+}
+
+// Delete removes the tag from every item of the owner.
+func (m *ManagerTag) Delete(owner, tag string) *TagItems {
+	return m.retagAll(owner, func(tags []string) ([]string, bool) {
+		return domain.RemoveTags(tags, tag)
+	})
+}
+
+func (m *ManagerTag) retagItems(owner string, payload PayloadTag, retag domain.Retag) *TagItems {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	items := makeTagItems(nil, nil, nil)
+	if len(domain.NormalizeTags(payload.Tags...)) == 0 {
+		return items
+	}
+
+	if len(payload.Requests) > 0 {
+		items.Requests = toLiteRequests(m.managerRequest.Retag(owner, retag, payload.Requests...))
+	}
+
+	if len(payload.Collections) > 0 {
+		items.Collections = m.managerCollection.Retag(owner, retag, payload.Collections...)
+	}
+
+	if len(payload.EndPoints) > 0 {
+		items.EndPoints = toLiteEndPoints(m.managerEndPoint.Retag(owner, retag, payload.EndPoints...))
+	}
+
+	return items
+}
+
+func (m *ManagerTag) retagAll(owner string, retag domain.Retag) *TagItems {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return &TagItems{
+		Requests:    toLiteRequests(m.managerRequest.Retag(owner, retag)),
+		Collections: m.managerCollection.Retag(owner, retag),
+		EndPoints:   toLiteEndPoints(m.managerEndPoint.Retag(owner, retag)),
+	}
+}
+
+func makeTagItems(requests []action.Request, collections []collection.Collection, endPoints []mock.EndPointLite) *TagItems {
+	if collections == nil {
+		collections = make([]collection.Collection, 0)
+	}
+	if endPoints == nil {
+		endPoints = make([]mock.EndPointLite, 0)
+	}
+	return &TagItems{
+		Requests:    toLiteRequests(requests),
+		Collections: collections,
+		EndPoints:   endPoints,
+	}
+}
+
+func toLiteRequests(requests []action.Request) []action.RequestLite {
+	return go_collection.MapToVector(requests, func(r action.Request) action.RequestLite {
+		return *action.ToLiteRequest(&r)
+	}).Collect()
+}
+
+func toLiteEndPoints(endPoints []mock.EndPoint) []mock.EndPointLite {
+	return go_collection.MapToVector(endPoints, func(e mock.EndPoint) mock.EndPointLite {
+		return *mock.LiteFromEndPoint(&e)
+	}).Collect()
+}
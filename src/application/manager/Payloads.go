@@ -41,3 +41,11 @@ type PayloadCollectionNode struct {
 	Order int    `json:"order"`
 	Item  string `json:"item"`
 }
+
+// PayloadTag lists the items to tag or untag by kind.
+type PayloadTag struct {
+	Tags        []string `json:"tags"`
+	Requests    []string `json:"requests"`
+	Collections []string `json:"collections"`
+	EndPoints   []string `json:"end_points"`
+}
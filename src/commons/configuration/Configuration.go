@@ -14,6 +14,11 @@ var (
 	once     sync.Once
 )
 
+const (
+	DEFAULT_RESPONSE_RETENTION = 10
+	DEFAULT_REVISION_RETENTION = 20
+)
+
 type Snapshot struct {
 	Enable bool
 	Time   int64
@@ -32,6 +37,9 @@ type Configuration struct {
 	secret    []byte
 	format    format.DataFormat
 	snapshot  Snapshot
+	retention int
+	revisions int
+	search    bool
 	kargs     map[string]utils.Argument
 }
 
@@ -49,6 +57,18 @@ func Initialize(session string, timestamp int64, kargs map[string]utils.Argument
 
 		dev := kargs["GAC_DEV"].Boold(false)
 
+		retention := kargs["GAC_RESPONSE_RETENTION"].Intd(DEFAULT_RESPONSE_RETENTION)
+		if retention < 1 {
+			retention = DEFAULT_RESPONSE_RETENTION
+		}
+
+		search := kargs["GAC_SEARCH_RESPONSES"].Boold(false)
+
+		revisions := kargs["GAC_REVISION_RETENTION"].Intd(DEFAULT_REVISION_RETENTION)
+		if revisions < 1 {
+			revisions = DEFAULT_REVISION_RETENTION
+		}
+
 		instance = &Configuration{
 			Signal:    newSignalHandler(),
 			EventHub:  system.InitializeSystemEventHub(),
@@ -61,6 +81,9 @@ func Initialize(session string, timestamp int64, kargs map[string]utils.Argument
 			admin:     admin,
 			secret:    []byte(secret),
 			snapshot:  *snapshot,
+			retention: retention,
+			revisions: revisions,
+			search:    search,
 			kargs:     kargs,
 		}
 	})
@@ -106,3 +129,20 @@ func (c Configuration) Format() format.DataFormat {
 func (c Configuration) Snapshot() Snapshot {
 	return c.snapshot
 }
+
+// ResponseRetention is the number of responses kept in the history of every
+// request, pinned responses are not counted.
+func (c Configuration) ResponseRetention() int {
+	return c.retention
+}
+
+// RevisionRetention is the number of revisions kept for every request.
+func (c Configuration) RevisionRetention() int {
+	return c.revisions
+}
+
+// SearchResponses tells whether the search index includes the response
+// headers and bodies.
+func (c Configuration) SearchResponses() bool {
+	return c.search
+}
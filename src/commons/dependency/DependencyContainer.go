@@ -6,13 +6,17 @@ import (
 	topic_snapshot "github.com/Rafael24595/go-api-core/src/commons/system/topic/snapshot"
 	collection_domain "github.com/Rafael24595/go-api-core/src/domain/collection"
 	domain_mock "github.com/Rafael24595/go-api-core/src/domain/mock"
+	domain_openapi "github.com/Rafael24595/go-api-core/src/domain/openapi"
+	domain_search "github.com/Rafael24595/go-api-core/src/domain/search"
 	domain_session "github.com/Rafael24595/go-api-core/src/domain/session"
 	domain_token "github.com/Rafael24595/go-api-core/src/domain/token"
 	repository_client "github.com/Rafael24595/go-api-core/src/infrastructure/repository/client"
 	repository_collection "github.com/Rafael24595/go-api-core/src/infrastructure/repository/collection"
 	repository_context "github.com/Rafael24595/go-api-core/src/infrastructure/repository/context"
+	repository_contract "github.com/Rafael24595/go-api-core/src/infrastructure/repository/contract"
 	repository_group "github.com/Rafael24595/go-api-core/src/infrastructure/repository/group"
 	repository_mock "github.com/Rafael24595/go-api-core/src/infrastructure/repository/mock"
+	repository_search "github.com/Rafael24595/go-api-core/src/infrastructure/repository/search"
 	repository_token "github.com/Rafael24595/go-api-core/src/infrastructure/repository/token"
 
 	"github.com/Rafael24595/go-api-core/src/application/manager"
@@ -27,6 +31,7 @@ import (
 	"github.com/Rafael24595/go-api-core/src/infrastructure/repository"
 	"github.com/Rafael24595/go-api-core/src/infrastructure/repository/request"
 	"github.com/Rafael24595/go-api-core/src/infrastructure/repository/response"
+	"github.com/Rafael24595/go-api-core/src/infrastructure/repository/revision"
 	"github.com/Rafael24595/go-collections/collection"
 	"github.com/Rafael24595/go-log/log"
 	"github.com/Rafael24595/go-log/log/record"
@@ -41,6 +46,7 @@ type DependencyContainer struct {
 	RecordStore        *record.Memory
 	RepositoryContext  context.Repository
 	ManagerRequest     *manager.ManagerRequest
+	ManagerRevision    *manager.ManagerRevision
 	ManagerContext     *manager.ManagerContext
 	ManagerCollection  *manager.ManagerCollection
 	ManagerHistoric    *manager.ManagerHistoric
@@ -48,6 +54,8 @@ type DependencyContainer struct {
 	ManagerEndPoint    *manager.ManagerEndPoint
 	ManagerMetrics     *manager.ManagerMetrics
 	ManagerToken       *manager.ManagerToken
+	ManagerSearch      *manager.ManagerSearch
+	ManagerTag         *manager.ManagerTag
 	ManagerSessionData *session.ManagerSessionData
 }
 
@@ -58,31 +66,39 @@ func Initialize(config configuration.Configuration, recordStore *record.Memory)
 			log.Error(err)
 		}
 
-		repositoryRequest := loadRepositoryRequest(config)
-		repositoryResponse := loadRepositoryResponse(config)
+		searchIndex := domain_search.NewIndex(config.SearchResponses())
+
+		repositoryRequest := loadRepositoryRequest(config, searchIndex)
+		repositoryResponse := loadRepositoryResponse(config, searchIndex)
+		repositoryRevision := loadRepositoryRevision(config)
 
 		repositoryContext := loadRepositoryContext(config)
-		repositoryCollection := loadRepositoryCollection(config)
+		repositoryCollection := loadRepositoryCollection(config, searchIndex)
+		repositoryContract := loadRepositoryContract(config)
 		repositoryGroup := loadRepositoryGroup(config)
 		repositoryEndPoint := loadRepositoryEndPoint(config)
 		repositoryMetrics := loadRepositoryMetrics(config)
 		repositoryToken := loadRepositoryToken(config)
 		repositoryClient := loadRepositoryClientData(config)
 
-		managerRequest := loadManagerRequest(repositoryRequest, repositoryResponse)
+		managerRevision := loadManagerRevision(repositoryRevision, config.RevisionRetention())
+		managerRequest := loadManagerRequest(repositoryRequest, repositoryResponse, managerRevision, config.ResponseRetention())
 		managerContext := loadManagerContext(repositoryContext)
-		managerCollection := loadManagerCollection(repositoryCollection, managerContext, managerRequest)
+		managerCollection := loadManagerCollection(repositoryCollection, repositoryContract, managerContext, managerRequest)
 		managerHistoric := loadManagerHistoric(managerRequest, managerCollection)
 		managerGroup := loadManagerGroup(repositoryGroup, managerCollection)
 		managerMetrics := loadManagerMetrics(repositoryMetrics)
 		managerEndPoint := loadManagerEndPoint(repositoryEndPoint, managerMetrics)
 		managerToken := loadManagerToken(repositoryToken)
+		managerSearch := loadManagerSearch(searchIndex)
+		managerTag := loadManagerTag(managerRequest, managerCollection, managerEndPoint)
 		managerSessionData := loadManagerSessionData(repositoryClient, managerCollection, managerGroup)
 
 		container := &DependencyContainer{
 			RecordStore:        recordStore,
 			RepositoryContext:  repositoryContext,
 			ManagerRequest:     managerRequest,
+			ManagerRevision:    managerRevision,
 			ManagerContext:     managerContext,
 			ManagerCollection:  managerCollection,
 			ManagerHistoric:    managerHistoric,
@@ -90,6 +106,8 @@ func Initialize(config configuration.Configuration, recordStore *record.Memory)
 			ManagerEndPoint:    managerEndPoint,
 			ManagerMetrics:     managerMetrics,
 			ManagerToken:       managerToken,
+			ManagerSearch:      managerSearch,
+			ManagerTag:         managerTag,
 			ManagerSessionData: managerSessionData,
 		}
 
@@ -107,7 +125,7 @@ func Instance() *DependencyContainer {
 	return instance
 }
 
-func loadRepositoryRequest(config configuration.Configuration) action.RepositoryRequest {
+func loadRepositoryRequest(config configuration.Configuration, index *domain_search.Index) action.RepositoryRequest {
 	var file repository.IFileManager[action.Request]
 	file = repository.NewManagerCsvtFile[action.Request](repository.CSVT_FILE_PATH_REQUEST)
 
@@ -123,10 +141,10 @@ func loadRepositoryRequest(config configuration.Configuration) action.Repository
 		local.Panic(err)
 	}
 
-	return repository
+	return repository_search.NewIndexedRepositoryRequest(repository, index)
 }
 
-func loadRepositoryResponse(config configuration.Configuration) action.RepositoryResponse {
+func loadRepositoryResponse(config configuration.Configuration, index *domain_search.Index) action.RepositoryResponse {
 	var file repository.IFileManager[action.Response]
 	file = repository.NewManagerCsvtFile[action.Response](repository.CSVT_FILE_PATH_RESPONSE)
 
@@ -142,6 +160,25 @@ func loadRepositoryResponse(config configuration.Configuration) action.Repositor
 		local.Panic(err)
 	}
 
+	return repository_search.NewIndexedRepositoryResponse(repository, index)
+}
+
+func loadRepositoryRevision(config configuration.Configuration) action.RepositoryRevision {
+	var file repository.IFileManager[action.Revision]
+	file = repository.NewManagerCsvtFile[action.Revision](repository.CSVT_FILE_PATH_REVISION)
+
+	snapshot := config.Snapshot()
+	if snapshot.Enable {
+		topic := topic_snapshot.TOPIC_REVISION
+		file = loadManagerSnapshotFile(topic, snapshot, file)
+	}
+
+	impl := collection.DictionarySyncEmpty[string, action.Revision]()
+	repository, err := revision.InitializeRepositoryMemory(impl, file)
+	if err != nil {
+		local.Panic(err)
+	}
+
 	return repository
 }
 
@@ -164,7 +201,7 @@ func loadRepositoryContext(config configuration.Configuration) context.Repositor
 	return repository
 }
 
-func loadRepositoryCollection(config configuration.Configuration) collection_domain.Repository {
+func loadRepositoryCollection(config configuration.Configuration, index *domain_search.Index) collection_domain.Repository {
 	var file repository.IFileManager[collection_domain.Collection]
 	file = repository.NewManagerCsvtFile[collection_domain.Collection](repository.CSVT_FILE_PATH_COLLECTION)
 
@@ -180,6 +217,25 @@ func loadRepositoryCollection(config configuration.Configuration) collection_dom
 		local.Panic(err)
 	}
 
+	return repository_search.NewIndexedRepositoryCollection(repository, index)
+}
+
+func loadRepositoryContract(config configuration.Configuration) domain_openapi.RepositoryContract {
+	var file repository.IFileManager[domain_openapi.Contract]
+	file = repository.NewManagerCsvtFile[domain_openapi.Contract](repository.CSVT_FILE_PATH_CONTRACT)
+
+	snapshot := config.Snapshot()
+	if snapshot.Enable {
+		topic := topic_snapshot.TOPIC_CONTRACT
+		file = loadManagerSnapshotFile(topic, snapshot, file)
+	}
+
+	impl := collection.DictionarySyncEmpty[string, domain_openapi.Contract]()
+	repository, err := repository_contract.InitializeRepositoryMemory(impl, file)
+	if err != nil {
+		local.Panic(err)
+	}
+
 	return repository
 }
 
@@ -290,8 +346,14 @@ func loadManagerSnapshotFile[T repository.IStructure](
 
 func loadManagerRequest(
 	request action.RepositoryRequest,
-	response action.RepositoryResponse) *manager.ManagerRequest {
-	return manager.NewManagerRequest(request, response)
+	response action.RepositoryResponse,
+	managerRevision *manager.ManagerRevision,
+	retention int) *manager.ManagerRequest {
+	return manager.NewManagerRequest(request, response, managerRevision, retention)
+}
+
+func loadManagerRevision(revision action.RepositoryRevision, retention int) *manager.ManagerRevision {
+	return manager.NewManagerRevision(revision, retention)
 }
 
 func loadManagerContext(context context.Repository) *manager.ManagerContext {
@@ -300,9 +362,10 @@ func loadManagerContext(context context.Repository) *manager.ManagerContext {
 
 func loadManagerCollection(
 	collection collection_domain.Repository,
+	contract domain_openapi.RepositoryContract,
 	managerContext *manager.ManagerContext,
 	managerRequest *manager.ManagerRequest) *manager.ManagerCollection {
-	return manager.NewManagerCollection(collection, managerContext, managerRequest)
+	return manager.NewManagerCollection(collection, contract, managerContext, managerRequest)
 }
 
 func loadManagerHistoric(
@@ -329,6 +392,17 @@ func loadManagerToken(token domain_token.Repository) *manager.ManagerToken {
 	return manager.NewManagerToken(token)
 }
 
+func loadManagerSearch(index *domain_search.Index) *manager.ManagerSearch {
+	return manager.NewManagerSearch(index)
+}
+
+func loadManagerTag(
+	managerRequest *manager.ManagerRequest,
+	managerCollection *manager.ManagerCollection,
+	managerEndPoint *manager.ManagerEndPoint) *manager.ManagerTag {
+	return manager.NewManagerTag(managerRequest, managerCollection, managerEndPoint)
+}
+
 func loadManagerSessionData(
 	client domain_session.RepositorySessionData,
 	managerCollection *manager.ManagerCollection,
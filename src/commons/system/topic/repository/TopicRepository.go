@@ -31,6 +31,8 @@ const (
 	TOPIC_TOKEN       TopicRepository = "rep_tkn"
 	TOPIC_SESSION     TopicRepository = "rep_ses"
 	TOPIC_CLIENT_DATA TopicRepository = "rep_cld"
+	TOPIC_REVISION    TopicRepository = "rep_rev"
+	TOPIC_CONTRACT    TopicRepository = "rep_oac"
 )
 
 var snapshotMeta = map[TopicRepository]TopicMeta{
@@ -74,6 +76,14 @@ var snapshotMeta = map[TopicRepository]TopicMeta{
 		isCore:      true,
 		Description: "Represents the repository of user client data.",
 	},
+	TOPIC_REVISION: {
+		isCore:      true,
+		Description: "Represents the repository of request revisions.",
+	},
+	TOPIC_CONTRACT: {
+		isCore:      true,
+		Description: "Represents the repository of imported OpenAPI contracts.",
+	},
 }
 
 func allTopicRepositorys() []TopicRepository {
@@ -160,3 +170,13 @@ func (t TopicRepository) ActionReload() topic.TopicAction {
 		Description: "Reloads the repository",
 	}
 }
+
+// ActionReloaded is published by the repository once its data has been
+// reloaded, so the listeners read the new data.
+func (t TopicRepository) ActionReloaded() topic.TopicAction {
+	return topic.TopicAction{
+		Parent:      string(t),
+		Code:        fmt.Sprintf("%s_rld", string(t)),
+		Description: "Notifies the repository reload",
+	}
+}
@@ -37,6 +37,8 @@ const (
 	TOPIC_TOKEN       TopicSnapshot = "snpsh_tkn"
 	TOPIC_SESSION     TopicSnapshot = "snpsh_ses"
 	TOPIC_CLIENT_DATA TopicSnapshot = "snpsh_cld"
+	TOPIC_REVISION    TopicSnapshot = "snpsh_rev"
+	TOPIC_CONTRACT    TopicSnapshot = "snpsh_oac"
 )
 
 var meta = map[TopicSnapshot]TopicMeta{
@@ -54,7 +56,7 @@ var meta = map[TopicSnapshot]TopicMeta{
 	},
 	TOPIC_RESPONSE: {
 		isCore:      true,
-		Description: "Represents a snapshot of response data.",
+		Description: "Represents a snapshot of the response history of every request.",
 		CsvPath:     "./db/snapshot/response",
 		Repository:  topic_repository.TOPIC_RESPONSE,
 	},
@@ -100,6 +102,18 @@ var meta = map[TopicSnapshot]TopicMeta{
 		CsvPath:     "./db/snapshot/client_data",
 		Repository:  topic_repository.TOPIC_CLIENT_DATA,
 	},
+	TOPIC_REVISION: {
+		isCore:      true,
+		Description: "Represents a snapshot of request revisions.",
+		CsvPath:     "./db/snapshot/revision",
+		Repository:  topic_repository.TOPIC_REVISION,
+	},
+	TOPIC_CONTRACT: {
+		isCore:      true,
+		Description: "Represents a snapshot of imported OpenAPI contracts.",
+		CsvPath:     "./db/snapshot/contract",
+		Repository:  topic_repository.TOPIC_CONTRACT,
+	},
 }
 
 const CSVT_PATH_MISC string = "./db/snapshot/misc"
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+)
+
+// Retag changes a tag list and reports whether it changed.
+type Retag func(tags []string) ([]string, bool)
+
+// NormalizeTag lower cases the tag and joins its words with dashes, so
+// "Smoke Test" and "smoke-test" are the same tag.
+func NormalizeTag(tag string) string {
+	return strings.Join(strings.Fields(strings.ToLower(tag)), "-")
+}
+
+// NormalizeTags returns the normalized tags sorted, without duplicates and
+// without empty tags.
+func NormalizeTags(tags ...string) []string {
+	unique := make(map[string]bool)
+	for _, v := range tags {
+		if tag := NormalizeTag(v); tag != "" {
+			unique[tag] = true
+		}
+	}
+
+	result := make([]string, 0, len(unique))
+	for k := range unique {
+		result = append(result, k)
+	}
+
+	sort.Strings(result)
+	return result
+}
+
+// MatchTags tells whether the tags contain any of the filter tags, an empty
+// filter matches everything.
+func MatchTags(tags []string, filter ...string) bool {
+	filter = NormalizeTags(filter...)
+	if len(filter) == 0 {
+		return true
+	}
+
+	for _, v := range NormalizeTags(tags...) {
+		for _, f := range filter {
+			if v == f {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// AddTags returns the union of both lists, it reports whether any tag was
+// missing.
+func AddTags(tags []string, add ...string) ([]string, bool) {
+	current := NormalizeTags(tags...)
+	result := NormalizeTags(append(current, add...)...)
+	return result, len(result) != len(current)
+}
+
+// RemoveTags returns the tags without the removed ones, it reports whether
+// any tag was present.
+func RemoveTags(tags []string, remove ...string) ([]string, bool) {
+	removed := make(map[string]bool)
+	for _, v := range remove {
+		removed[NormalizeTag(v)] = true
+	}
+
+	current := NormalizeTags(tags...)
+	result := make([]string, 0, len(current))
+	for _, v := range current {
+		if !removed[v] {
+			result = append(result, v)
+		}
+	}
+
+	return result, len(result) != len(current)
+}
+
+// RenameTag replaces the tag, the new name is merged if it is already in the
+// list. It reports whether the tag was present.
+func RenameTag(tags []string, old, new string) ([]string, bool) {
+	result, ok := RemoveTags(tags, old)
+	if !ok {
+		return NormalizeTags(tags...), false
+	}
+	result, _ = AddTags(result, new)
+	return result, true
+}
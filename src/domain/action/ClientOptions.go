@@ -0,0 +1,15 @@
+package action
+
+// ClientOptions configure the HTTP client that sends the request, they come
+// from imports such as curl commands and only apply when their status is
+// enabled. The timeouts are in milliseconds and zero keeps the default of the
+// client.
+type ClientOptions struct {
+	Status          bool   `json:"status"`
+	Insecure        bool   `json:"insecure"`
+	FollowRedirects bool   `json:"follow_redirects"`
+	Compressed      bool   `json:"compressed"`
+	Proxy           string `json:"proxy"`
+	Timeout         int64  `json:"timeout"`
+	ConnectTimeout  int64  `json:"connect_timeout"`
+}
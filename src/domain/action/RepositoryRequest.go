@@ -3,6 +3,7 @@ package action
 import "github.com/Rafael24595/go-api-core/src/domain"
 
 type RepositoryRequest interface {
+	FindAll() []Request
 	Find(id string) (*Request, bool)
 	FindMany(ids ...string) []Request
 	FindNodes(references []domain.NodeReference) []NodeRequest
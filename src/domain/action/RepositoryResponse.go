@@ -1,8 +1,10 @@
 package action
 
 type RepositoryResponse interface {
+	FindAll() []Response
 	Find(key string) (*Response, bool)
 	FindMany(ids []string) []Response
+	FindByRequest(requests ...string) []Response
 	Insert(owner string, response *Response) *Response
 	Delete(response *Response) *Response
 	DeleteMany(responses ...Response) []Response
@@ -0,0 +1,8 @@
+package action
+
+type RepositoryRevision interface {
+	Find(id string) (*Revision, bool)
+	FindByRequest(requests ...string) []Revision
+	Insert(owner string, revision *Revision) *Revision
+	DeleteMany(revisions ...Revision) []Revision
+}
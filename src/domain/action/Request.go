@@ -8,6 +8,7 @@ import (
 	"github.com/Rafael24595/go-api-core/src/domain/action/body"
 	"github.com/Rafael24595/go-api-core/src/domain/action/cookie"
 	"github.com/Rafael24595/go-api-core/src/domain/action/header"
+	"github.com/Rafael24595/go-api-core/src/domain/action/param"
 	"github.com/Rafael24595/go-api-core/src/domain/action/query"
 )
 
@@ -19,6 +20,7 @@ type Request struct {
 	Name      string               `json:"name"`
 	Method    domain.HttpMethod    `json:"method"`
 	Uri       string               `json:"uri"`
+	Param     param.Params         `json:"param"`
 	Query     query.Queries        `json:"query"`
 	Header    header.Headers       `json:"header"`
 	Cookie    cookie.CookiesClient `json:"cookie"`
@@ -27,6 +29,8 @@ type Request struct {
 	Owner     string               `json:"owner"`
 	Modified  int64                `json:"modified"`
 	Status    StatusRequest        `json:"status"`
+	Tags      []string             `json:"tags"`
+	Options   ClientOptions        `json:"options"`
 }
 
 func NewRequestEmpty() *Request {
@@ -40,6 +44,9 @@ func NewRequest(name string, method domain.HttpMethod, uri string) *Request {
 		Name:      name,
 		Method:    method,
 		Uri:       uri,
+		Param: param.Params{
+			Params: make([]param.Param, 0),
+		},
 		Query: query.Queries{
 			Queries: make(map[string][]query.Query),
 		},
@@ -59,6 +66,7 @@ func NewRequest(name string, method domain.HttpMethod, uri string) *Request {
 		Owner:    ANONYMOUS_OWNER,
 		Modified: time.Now().UnixMilli(),
 		Status:   DRAFT,
+		Tags:     make([]string, 0),
 	}
 }
 
@@ -74,6 +82,7 @@ type RequestLite struct {
 	Uri       string            `json:"uri"`
 	Owner     string            `json:"owner"`
 	Modified  int64             `json:"modified"`
+	Tags      []string          `json:"tags"`
 }
 
 func ToLiteRequest(request *Request) *RequestLite {
@@ -85,5 +94,6 @@ func ToLiteRequest(request *Request) *RequestLite {
 		Uri:       request.Uri,
 		Owner:     request.Owner,
 		Modified:  request.Modified,
+		Tags:      request.Tags,
 	}
 }
@@ -17,6 +17,7 @@ type Response struct {
 	Cookies   cookie.CookiesServer `json:"cookies"`
 	Body      body.BodyResponse    `json:"body"`
 	Size      int                  `json:"size"`
+	Pinned    bool                 `json:"pinned"`
 	Owner     string               `json:"owner"`
 }
 
@@ -27,3 +28,12 @@ func NewResponseDefault() *Response {
 func (r Response) PersistenceId() string {
 	return r.Id
 }
+
+// RequestId returns the request of the response, the responses stored before
+// the history was introduced only share the id with their request.
+func (r Response) RequestId() string {
+	if r.Request != "" {
+		return r.Request
+	}
+	return r.Id
+}
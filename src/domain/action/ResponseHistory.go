@@ -0,0 +1,60 @@
+package action
+
+import "sort"
+
+// SortResponses orders the responses from the newest to the oldest.
+func SortResponses(responses []Response) []Response {
+	sort.SliceStable(responses, func(i, j int) bool {
+		if responses[i].Timestamp != responses[j].Timestamp {
+			return responses[i].Timestamp > responses[j].Timestamp
+		}
+		if responses[i].Date != responses[j].Date {
+			return responses[i].Date > responses[j].Date
+		}
+		return responses[i].Id > responses[j].Id
+	})
+	return responses
+}
+
+func LastResponse(responses []Response) (*Response, bool) {
+	if len(responses) == 0 {
+		return nil, false
+	}
+	last := SortResponses(responses)[0]
+	return &last, true
+}
+
+// ReferenceResponse returns the pinned response, or the last one if the
+// request has nothing pinned.
+func ReferenceResponse(responses []Response) (*Response, bool) {
+	for _, v := range responses {
+		if v.Pinned {
+			return &v, true
+		}
+	}
+	return LastResponse(responses)
+}
+
+// ExpiredResponses returns the responses out of the retention, the pinned
+// ones are never expired and do not count towards the limit. A retention
+// lower than one keeps the full history.
+func ExpiredResponses(responses []Response, retention int) []Response {
+	expired := make([]Response, 0)
+	if retention < 1 {
+		return expired
+	}
+
+	count := 0
+	for _, v := range SortResponses(responses) {
+		if v.Pinned {
+			continue
+		}
+		if count < retention {
+			count++
+			continue
+		}
+		expired = append(expired, v)
+	}
+
+	return expired
+}
@@ -0,0 +1,111 @@
+package action
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+)
+
+// Revision is the state of a request after one of its updates, with the
+// fields that changed from the previous state. Sequence numbers the revisions
+// of the request in the order they were recorded.
+type Revision struct {
+	Id        string   `json:"_id"`
+	Request   string   `json:"request"`
+	Sequence  int64    `json:"sequence"`
+	Timestamp int64    `json:"timestamp"`
+	Author    string   `json:"author"`
+	Changes   []string `json:"changes"`
+	Restored  string   `json:"restored"`
+	Snapshot  Request  `json:"snapshot"`
+	Owner     string   `json:"owner"`
+}
+
+func NewRevision(author string, before *Request, after Request) *Revision {
+	changes := make([]string, 0)
+	if before != nil {
+		changes = RequestChanges(*before, after)
+	}
+
+	return &Revision{
+		Id:        "",
+		Request:   after.Id,
+		Timestamp: after.Modified,
+		Author:    author,
+		Changes:   changes,
+		Snapshot:  after,
+		Owner:     after.Owner,
+	}
+}
+
+func (r Revision) PersistenceId() string {
+	return r.Id
+}
+
+// RequestChanges returns the name of the request fields that differ, the
+// identity and the dates of the request are not compared.
+func RequestChanges(before, after Request) []string {
+	fields := map[string][2]any{
+		"name":    {before.Name, after.Name},
+		"method":  {before.Method, after.Method},
+		"uri":     {before.Uri, after.Uri},
+		"param":   {before.Param, after.Param},
+		"query":   {before.Query, after.Query},
+		"header":  {before.Header, after.Header},
+		"cookie":  {before.Cookie, after.Cookie},
+		"body":    {before.Body, after.Body},
+		"auth":    {before.Auth, after.Auth},
+		"status":  {before.Status, after.Status},
+		"options": {before.Options, after.Options},
+		"tags":    {domain.NormalizeTags(before.Tags...), domain.NormalizeTags(after.Tags...)},
+	}
+
+	changes := make([]string, 0)
+	for k, v := range fields {
+		if !reflect.DeepEqual(v[0], v[1]) {
+			changes = append(changes, k)
+		}
+	}
+
+	sort.Strings(changes)
+	return changes
+}
+
+// SortRevisions orders the revisions from the newest to the oldest. The
+// revisions of the same request are ordered by sequence, so updates recorded
+// in the same millisecond keep their order.
+func SortRevisions(revisions []Revision) []Revision {
+	sort.SliceStable(revisions, func(i, j int) bool {
+		a, b := revisions[i], revisions[j]
+		if a.Request == b.Request && a.Sequence != b.Sequence {
+			return a.Sequence > b.Sequence
+		}
+		if a.Timestamp != b.Timestamp {
+			return a.Timestamp > b.Timestamp
+		}
+		return a.Id > b.Id
+	})
+	return revisions
+}
+
+// NextSequence returns the sequence that follows the revisions.
+func NextSequence(revisions []Revision) int64 {
+	next := int64(1)
+	for _, v := range revisions {
+		if v.Sequence >= next {
+			next = v.Sequence + 1
+		}
+	}
+	return next
+}
+
+// ExpiredRevisions returns the revisions out of the retention, a retention
+// lower than one keeps every revision.
+func ExpiredRevisions(revisions []Revision, retention int) []Revision {
+	expired := make([]Revision, 0)
+	if retention < 1 || len(revisions) <= retention {
+		return expired
+	}
+	return append(expired, SortRevisions(revisions)[retention:]...)
+}
@@ -0,0 +1,19 @@
+package param
+
+type Param struct {
+	Order       int64  `json:"order"`
+	Status      bool   `json:"status"`
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Description string `json:"description"`
+}
+
+func NewParam(order int64, status bool, key, value, description string) Param {
+	return Param{
+		Order:       order,
+		Status:      status,
+		Key:         key,
+		Value:       value,
+		Description: description,
+	}
+}
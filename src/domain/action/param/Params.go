@@ -0,0 +1,209 @@
+package param
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+type Params struct {
+	Params []Param `json:"params"`
+}
+
+func NewParams() *Params {
+	return &Params{
+		Params: make([]Param, 0),
+	}
+}
+
+func (p *Params) Find(key string) (*Param, bool) {
+	for i := range p.Params {
+		if p.Params[i].Key == key {
+			return &p.Params[i], true
+		}
+	}
+	return nil, false
+}
+
+func (p *Params) Exists(key string) bool {
+	_, ok := p.Find(key)
+	return ok
+}
+
+func (p *Params) Add(key, value string) *Params {
+	return p.AddStatus(key, value, true)
+}
+
+func (p *Params) AddStatus(key, value string, status bool) *Params {
+	return p.AddParam(Param{
+		Order:  int64(len(p.Params)),
+		Status: status,
+		Key:    key,
+		Value:  value,
+	})
+}
+
+func (p *Params) AddParam(param Param) *Params {
+	if cursor, ok := p.Find(param.Key); ok {
+		*cursor = param
+		return p
+	}
+
+	p.Params = append(p.Params, param)
+
+	return p
+}
+
+func (p *Params) Sort() *Params {
+	sort.SliceStable(p.Params, func(i, j int) bool {
+		return p.Params[i].Order < p.Params[j].Order
+	})
+	return p
+}
+
+// Apply replaces the "{name}" and ":name" segments of the uri with the
+// values of the active parameters. Unknown or disabled parameters keep their
+// placeholder, and context variables like "${name}" are never touched.
+func (p Params) Apply(uri string) string {
+	values := make(map[string]string)
+	for _, v := range p.Params {
+		if !v.Status {
+			continue
+		}
+		values[v.Key] = url.PathEscape(v.Value)
+	}
+
+	if len(values) == 0 {
+		return uri
+	}
+
+	var buffer strings.Builder
+	for _, s := range FindSegments(uri) {
+		value, ok := values[s.Key]
+		if s.Key == "" || !ok {
+			buffer.WriteString(s.Raw)
+			continue
+		}
+		buffer.WriteString(value)
+	}
+
+	return buffer.String()
+}
+
+// Segment is a fragment of an uri, Key is only defined when the fragment is a
+// path parameter placeholder.
+type Segment struct {
+	Raw string
+	Key string
+}
+
+// FindSegments splits the uri into literal fragments and path parameter
+// placeholders. Braced placeholders are recognized anywhere outside a
+// context variable, colon placeholders only at the start of a path segment.
+func FindSegments(uri string) []Segment {
+	segments := make([]Segment, 0)
+
+	start := pathStart(uri)
+	cursor := 0
+	i := 0
+	for i < len(uri) {
+		key, size := placeholderAt(uri, i, start)
+		if size == 0 {
+			i++
+			continue
+		}
+
+		if cursor < i {
+			segments = append(segments, Segment{Raw: uri[cursor:i]})
+		}
+
+		segments = append(segments, Segment{
+			Raw: uri[i : i+size],
+			Key: key,
+		})
+
+		i += size
+		cursor = i
+	}
+
+	if cursor < len(uri) {
+		segments = append(segments, Segment{Raw: uri[cursor:]})
+	}
+
+	return segments
+}
+
+// FindKeys returns the placeholder names of the uri in order of appearance.
+func FindKeys(uri string) []string {
+	keys := make([]string, 0)
+	cache := make(map[string]bool)
+	for _, s := range FindSegments(uri) {
+		if s.Key == "" || cache[s.Key] {
+			continue
+		}
+		cache[s.Key] = true
+		keys = append(keys, s.Key)
+	}
+	return keys
+}
+
+func placeholderAt(uri string, i, start int) (string, int) {
+	switch uri[i] {
+	case '{':
+		if i > 0 && uri[i-1] == '$' {
+			return "", 0
+		}
+		end := strings.IndexByte(uri[i:], '}')
+		if end <= 1 {
+			return "", 0
+		}
+		key := uri[i+1 : i+end]
+		if !isKey(key) {
+			return "", 0
+		}
+		return key, end + 1
+	case ':':
+		if i < start || i == 0 || uri[i-1] != '/' {
+			return "", 0
+		}
+		end := i + 1
+		for end < len(uri) && isKeyRune(uri[end]) {
+			end++
+		}
+		if end == i+1 {
+			return "", 0
+		}
+		return uri[i+1 : end], end - i
+	}
+	return "", 0
+}
+
+func pathStart(uri string) int {
+	index := strings.Index(uri, "://")
+	if index == -1 {
+		return 0
+	}
+
+	slash := strings.IndexByte(uri[index+3:], '/')
+	if slash == -1 {
+		return len(uri)
+	}
+
+	return index + 3 + slash
+}
+
+func isKey(key string) bool {
+	for i := 0; i < len(key); i++ {
+		if !isKeyRune(key[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isKeyRune(r byte) bool {
+	return r == '_' || r == '-' ||
+		(r >= 'a' && r <= 'z') ||
+		(r >= 'A' && r <= 'Z') ||
+		(r >= '0' && r <= '9')
+}
@@ -1,12 +1,14 @@
 package query
 
 type Queries struct {
-	Queries map[string][]Query `json:"queries"`
+	Queries map[string][]Query       `json:"queries"`
+	Styles  map[string]Serialization `json:"styles"`
 }
 
 func NewQueries() *Queries {
 	return &Queries{
 		Queries: make(map[string][]Query),
+		Styles:  make(map[string]Serialization),
 	}
 }
 
@@ -50,6 +52,32 @@ func (q *Queries) AddQuery(key string, query Query) *Queries {
 	return q
 }
 
+func (q *Queries) PutSerialization(key string, serialization Serialization) *Queries {
+	if q.Styles == nil {
+		q.Styles = make(map[string]Serialization)
+	}
+
+	if serialization.IsDefault() {
+		delete(q.Styles, key)
+		return q
+	}
+
+	q.Styles[key] = serialization
+
+	return q
+}
+
+func (q *Queries) PutStyle(key string, style Style, explode bool) *Queries {
+	return q.PutSerialization(key, NewSerialization(style, explode))
+}
+
+func (q Queries) FindSerialization(key string) Serialization {
+	if serialization, ok := q.Styles[key]; ok && serialization.Style != "" {
+		return serialization
+	}
+	return DefaultSerialization()
+}
+
 func (q *Queries) SizeOf(key string) int {
 	if queries, ok := q.Queries[key]; ok {
 		return len(queries)
@@ -1,9 +1,10 @@
 package query
 
 type Query struct {
-	Order  int64  `json:"order"`
-	Status bool   `json:"status"`
-	Value  string `json:"value"`
+	Order    int64  `json:"order"`
+	Status   bool   `json:"status"`
+	Value    string `json:"value"`
+	Property string `json:"property"`
 }
 
 func NewQuery(order int64, status bool, value string) Query {
@@ -13,3 +14,12 @@ func NewQuery(order int64, status bool, value string) Query {
 		Value:  value,
 	}
 }
+
+func NewPropertyQuery(order int64, status bool, property, value string) Query {
+	return Query{
+		Order:    order,
+		Status:   status,
+		Value:    value,
+		Property: property,
+	}
+}
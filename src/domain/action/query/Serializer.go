@@ -0,0 +1,153 @@
+package query
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Encode merges the active queries into the given values following the
+// serialization of every key. Keys with the default form style are encoded
+// with the values, the rest are appended keeping their literal delimiters.
+func (q Queries) Encode(values url.Values) string {
+	if values == nil {
+		values = url.Values{}
+	}
+
+	keys := make([]string, 0, len(q.Queries))
+	for k := range q.Queries {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	fragments := make([]string, 0)
+	for _, k := range keys {
+		items := activeQueries(q.Queries[k])
+		if len(items) == 0 {
+			continue
+		}
+
+		serialization := q.FindSerialization(k)
+		if serialization.IsDefault() && !hasProperties(items) {
+			for _, v := range items {
+				values.Add(k, v.Value)
+			}
+			continue
+		}
+
+		fragments = append(fragments, Serialize(k, serialization, items)...)
+	}
+
+	encoded := values.Encode()
+	if len(fragments) == 0 {
+		return encoded
+	}
+
+	if encoded != "" {
+		fragments = append([]string{encoded}, fragments...)
+	}
+
+	return strings.Join(fragments, "&")
+}
+
+// Serialize renders the values of a single key as raw query fragments
+// following the OpenAPI style and explode semantics. Values with a property
+// are treated as object members and the rest as array items.
+func Serialize(key string, serialization Serialization, items []Query) []string {
+	escKey := url.QueryEscape(key)
+
+	if hasProperties(items) {
+		return serializeObject(escKey, serialization, items)
+	}
+
+	switch serialization.Style {
+	case BRACKETS:
+		return explode(escKey+"[]", items)
+	case SPACE_DELIMITED:
+		if serialization.Explode {
+			return explode(escKey, items)
+		}
+		return []string{escKey + "=" + join(items, "%20")}
+	case PIPE_DELIMITED:
+		if serialization.Explode {
+			return explode(escKey, items)
+		}
+		return []string{escKey + "=" + join(items, "|")}
+	default:
+		if serialization.Explode {
+			return explode(escKey, items)
+		}
+		return []string{escKey + "=" + join(items, ",")}
+	}
+}
+
+func serializeObject(key string, serialization Serialization, items []Query) []string {
+	fragments := make([]string, 0)
+
+	switch {
+	case serialization.Style == DEEP_OBJECT || serialization.Style == BRACKETS:
+		for _, v := range items {
+			name := key
+			if v.Property != "" {
+				name = key + "[" + url.QueryEscape(v.Property) + "]"
+			}
+			fragments = append(fragments, name+"="+url.QueryEscape(v.Value))
+		}
+	case serialization.Explode:
+		for _, v := range items {
+			name := key
+			if v.Property != "" {
+				name = url.QueryEscape(v.Property)
+			}
+			fragments = append(fragments, name+"="+url.QueryEscape(v.Value))
+		}
+	default:
+		pairs := make([]string, 0)
+		for _, v := range items {
+			pairs = append(pairs, url.QueryEscape(v.Property), url.QueryEscape(v.Value))
+		}
+		fragments = append(fragments, key+"="+strings.Join(pairs, ","))
+	}
+
+	return fragments
+}
+
+func explode(key string, items []Query) []string {
+	fragments := make([]string, len(items))
+	for i, v := range items {
+		fragments[i] = key + "=" + url.QueryEscape(v.Value)
+	}
+	return fragments
+}
+
+func join(items []Query, delimiter string) string {
+	values := make([]string, len(items))
+	for i, v := range items {
+		values[i] = url.QueryEscape(v.Value)
+	}
+	return strings.Join(values, delimiter)
+}
+
+func activeQueries(queries []Query) []Query {
+	active := make([]Query, 0)
+	for _, v := range queries {
+		if v.Status {
+			active = append(active, v)
+		}
+	}
+
+	sort.SliceStable(active, func(i, j int) bool {
+		return active[i].Order < active[j].Order
+	})
+
+	return active
+}
+
+func hasProperties(queries []Query) bool {
+	for _, v := range queries {
+		if v.Property != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Style string
+
+const (
+	FORM            Style = "form"
+	SPACE_DELIMITED Style = "spaceDelimited"
+	PIPE_DELIMITED  Style = "pipeDelimited"
+	DEEP_OBJECT     Style = "deepObject"
+	BRACKETS        Style = "brackets"
+)
+
+func (s Style) String() string {
+	return string(s)
+}
+
+func StyleFromString(value string) (*Style, error) {
+	switch strings.ToLower(value) {
+	case strings.ToLower(string(FORM)):
+		style := FORM
+		return &style, nil
+	case strings.ToLower(string(SPACE_DELIMITED)):
+		style := SPACE_DELIMITED
+		return &style, nil
+	case strings.ToLower(string(PIPE_DELIMITED)):
+		style := PIPE_DELIMITED
+		return &style, nil
+	case strings.ToLower(string(DEEP_OBJECT)):
+		style := DEEP_OBJECT
+		return &style, nil
+	case strings.ToLower(string(BRACKETS)):
+		style := BRACKETS
+		return &style, nil
+	default:
+		return nil, fmt.Errorf("unknown query style value: '%s'", value)
+	}
+}
+
+type Serialization struct {
+	Style   Style `json:"style"`
+	Explode bool  `json:"explode"`
+}
+
+func NewSerialization(style Style, explode bool) Serialization {
+	return Serialization{
+		Style:   style,
+		Explode: explode,
+	}
+}
+
+func DefaultSerialization() Serialization {
+	return NewSerialization(FORM, true)
+}
+
+func (s Serialization) IsDefault() bool {
+	return s.Style == "" || s == DefaultSerialization()
+}
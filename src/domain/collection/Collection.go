@@ -17,6 +17,7 @@ type Collection struct {
 	Owner     string                 `json:"owner"`
 	Modified  int64                  `json:"modified"`
 	Status    StatusCollection       `json:"status"`
+	Tags      []string               `json:"tags"`
 }
 
 func NewUserCollection(owner string) *Collection {
@@ -41,6 +42,7 @@ func newCollection(owner string, status StatusCollection) *Collection {
 		Owner:     owner,
 		Modified:  0,
 		Status:    status,
+		Tags:      make([]string, 0),
 	}
 }
 
@@ -107,6 +109,7 @@ type CollectionLite struct {
 	Owner     string                   `json:"owner"`
 	Modified  int64                    `json:"modified"`
 	Status    StatusCollection         `json:"status"`
+	Tags      []string                 `json:"tags"`
 }
 
 func ToLiteCollection(collection *Collection, ctx string, nodes []action.NodeRequestLite) *CollectionLite {
@@ -118,5 +121,6 @@ func ToLiteCollection(collection *Collection, ctx string, nodes []action.NodeReq
 		Nodes:     nodes,
 		Owner:     collection.Owner,
 		Modified:  collection.Modified,
+		Tags:      collection.Tags,
 	}
 }
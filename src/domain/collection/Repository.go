@@ -5,6 +5,7 @@ import (
 )
 
 type Repository interface {
+	FindAll() []Collection
 	Find(id string) (*Collection, bool)
 	FindNodes(steps []domain.NodeReference) []NodeCollection
 	Insert(owner string, collection *Collection) *Collection
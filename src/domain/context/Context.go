@@ -12,6 +12,7 @@ import (
 	"github.com/Rafael24595/go-api-core/src/domain/action/body"
 	"github.com/Rafael24595/go-api-core/src/domain/action/cookie"
 	"github.com/Rafael24595/go-api-core/src/domain/action/header"
+	"github.com/Rafael24595/go-api-core/src/domain/action/param"
 	"github.com/Rafael24595/go-api-core/src/domain/action/query"
 	"github.com/Rafael24595/go-collections/collection"
 )
@@ -134,6 +135,7 @@ func ProcessRequest(request *action.Request, context *Context) *action.Request {
 		Name:      request.Name,
 		Method:    request.Method,
 		Uri:       context.Apply("uri", request.Uri),
+		Param:     *processParam(request.Param, context),
 		Query:     *processQuery(request.Query, context),
 		Header:    *processHeader(request.Header, context),
 		Cookie:    *processCookie(request.Cookie, context),
@@ -145,6 +147,23 @@ func ProcessRequest(request *action.Request, context *Context) *action.Request {
 	}
 }
 
+func processParam(params param.Params, context *Context) *param.Params {
+	paramCollection := make([]param.Param, len(params.Params))
+	for i, p := range params.Params {
+		paramCollection[i] = param.Param{
+			Order:       p.Order,
+			Status:      p.Status,
+			Key:         p.Key,
+			Value:       context.Apply("uri", p.Value),
+			Description: p.Description,
+		}
+	}
+
+	return &param.Params{
+		Params: paramCollection,
+	}
+}
+
 func processQuery(queries query.Queries, context *Context) *query.Queries {
 	queryCategory := map[string][]query.Query{}
 	for k, qs := range queries.Queries {
@@ -153,15 +172,23 @@ func processQuery(queries query.Queries, context *Context) *query.Queries {
 		for _, q := range qs {
 			value := context.Apply("query", q.Value)
 			queryCollection = append(queryCollection, query.Query{
-				Status: q.Status,
-				Value:  value,
+				Order:    q.Order,
+				Status:   q.Status,
+				Value:    value,
+				Property: q.Property,
 			})
 		}
 		queryCategory[key] = queryCollection
 	}
 
+	queryStyles := map[string]query.Serialization{}
+	for k, s := range queries.Styles {
+		queryStyles[context.Apply("query", k)] = s
+	}
+
 	return &query.Queries{
 		Queries: queryCategory,
+		Styles:  queryStyles,
 	}
 }
 
@@ -0,0 +1,266 @@
+package diff
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	"github.com/Rafael24595/go-api-core/src/domain/action/body"
+)
+
+const (
+	XML_ATTRIBUTE_PREFIX = "@"
+	XML_TEXT_KEY         = "#text"
+)
+
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_@#][A-Za-z0-9_@#-]*$`)
+
+// compareBody compares the JSON and XML documents field by field, any other
+// payload is compared as a whole.
+func (d *DiffEngine) compareBody(changes []Change, before, after action.Response) []Change {
+	if before.Body.ContentType != after.Body.ContentType {
+		changes = d.push(changes, Change{
+			Section:   BODY,
+			Path:      "content_type",
+			Operation: CHANGED,
+			Before:    before.Body.ContentType,
+			After:     after.Body.ContentType,
+		})
+	}
+
+	source, okSource := parseDocument(before.Body)
+	target, okTarget := parseDocument(after.Body)
+	if okSource && okTarget {
+		return d.compareValue(changes, BODY, "$", source, target)
+	}
+
+	if before.Body.Payload != after.Body.Payload {
+		changes = d.push(changes, Change{
+			Section:   BODY,
+			Path:      "$",
+			Operation: CHANGED,
+			Before:    before.Body.Payload,
+			After:     after.Body.Payload,
+		})
+	}
+
+	return changes
+}
+
+func (d *DiffEngine) compareValue(changes []Change, section Section, path string, source, target any) []Change {
+	switch value := source.(type) {
+	case map[string]any:
+		other, ok := target.(map[string]any)
+		if !ok {
+			break
+		}
+
+		for _, k := range unionKeys(value, other) {
+			if d.fields[k] {
+				continue
+			}
+
+			child := childPath(path, k)
+			before, okBefore := value[k]
+			after, okAfter := other[k]
+
+			switch {
+			case !okBefore:
+				changes = d.push(changes, Change{Section: section, Path: child, Operation: ADDED, After: after})
+			case !okAfter:
+				changes = d.push(changes, Change{Section: section, Path: child, Operation: REMOVED, Before: before})
+			default:
+				changes = d.compareValue(changes, section, child, before, after)
+			}
+		}
+
+		return changes
+	case []any:
+		other, ok := target.([]any)
+		if !ok {
+			break
+		}
+
+		for i := 0; i < max(len(value), len(other)); i++ {
+			child := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(value):
+				changes = d.push(changes, Change{Section: section, Path: child, Operation: ADDED, After: other[i]})
+			case i >= len(other):
+				changes = d.push(changes, Change{Section: section, Path: child, Operation: REMOVED, Before: value[i]})
+			default:
+				changes = d.compareValue(changes, section, child, value[i], other[i])
+			}
+		}
+
+		return changes
+	}
+
+	if equalValue(source, target) {
+		return changes
+	}
+
+	return d.push(changes, Change{
+		Section:   section,
+		Path:      path,
+		Operation: CHANGED,
+		Before:    source,
+		After:     target,
+	})
+}
+
+func equalValue(source, target any) bool {
+	number, ok := source.(json.Number)
+	other, okOther := target.(json.Number)
+	if ok && okOther {
+		if number == other {
+			return true
+		}
+		a, errA := strconv.ParseFloat(string(number), 64)
+		b, errB := strconv.ParseFloat(string(other), 64)
+		return errA == nil && errB == nil && a == b
+	}
+	return reflect.DeepEqual(source, target)
+}
+
+func childPath(path, key string) string {
+	if identifierPattern.MatchString(key) {
+		return path + "." + key
+	}
+	return fmt.Sprintf("%s[%s]", path, strconv.Quote(key))
+}
+
+func parseDocument(payload body.BodyResponse) (any, bool) {
+	content := strings.TrimSpace(payload.Payload)
+	if content == "" {
+		return nil, false
+	}
+
+	switch payload.ContentType {
+	case domain.Json:
+		return parseJson(content)
+	case domain.Xml:
+		return parseXml(content)
+	case domain.None, domain.Text:
+		if strings.HasPrefix(content, "{") || strings.HasPrefix(content, "[") {
+			return parseJson(content)
+		}
+	}
+
+	return nil, false
+}
+
+func parseJson(content string) (any, bool) {
+	decoder := json.NewDecoder(strings.NewReader(content))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, false
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, false
+	}
+
+	return value, true
+}
+
+type xmlNode struct {
+	name       string
+	attributes map[string]any
+	children   []*xmlNode
+	text       strings.Builder
+}
+
+// parseXml reads the document as the JSON tree, the attributes are prefixed
+// with "@", the text of mixed elements is stored as "#text" and the repeated
+// elements become arrays. Namespaces are not compared.
+func parseXml(content string) (any, bool) {
+	decoder := xml.NewDecoder(strings.NewReader(content))
+
+	var root *xmlNode
+	stack := make([]*xmlNode, 0)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, false
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			node := &xmlNode{
+				name:       element.Name.Local,
+				attributes: make(map[string]any),
+			}
+			for _, a := range element.Attr {
+				node.attributes[XML_ATTRIBUTE_PREFIX+a.Name.Local] = a.Value
+			}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, node)
+			} else if root == nil {
+				root = node
+			}
+			stack = append(stack, node)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(element)
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, false
+	}
+
+	return map[string]any{root.name: root.value()}, true
+}
+
+func (n *xmlNode) value() any {
+	text := strings.TrimSpace(n.text.String())
+	if len(n.attributes) == 0 && len(n.children) == 0 {
+		return text
+	}
+
+	result := make(map[string]any, len(n.attributes))
+	for k, v := range n.attributes {
+		result[k] = v
+	}
+
+	for _, v := range n.children {
+		value := v.value()
+		current, ok := result[v.name]
+		switch {
+		case !ok:
+			result[v.name] = value
+		case isList(current):
+			result[v.name] = append(current.([]any), value)
+		default:
+			result[v.name] = []any{current, value}
+		}
+	}
+
+	if text != "" {
+		result[XML_TEXT_KEY] = text
+	}
+
+	return result
+}
+
+func isList(value any) bool {
+	_, ok := value.([]any)
+	return ok
+}
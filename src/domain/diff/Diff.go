@@ -0,0 +1,286 @@
+package diff
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	"github.com/Rafael24595/go-api-core/src/domain/action/cookie"
+)
+
+type Section string
+
+const (
+	STATUS  Section = "status"
+	HEADER  Section = "header"
+	COOKIE  Section = "cookie"
+	BODY    Section = "body"
+	REQUEST Section = "request"
+)
+
+type Operation string
+
+const (
+	ADDED   Operation = "added"
+	REMOVED Operation = "removed"
+	CHANGED Operation = "changed"
+)
+
+// DEFAULT_VOLATILE_HEADERS change on every call and are ignored unless the
+// engine is cleared.
+var DEFAULT_VOLATILE_HEADERS = []string{
+	"Age", "Date", "Expires", "Last-Modified", "ETag", "X-Request-Id", "X-Correlation-Id", "X-Trace-Id",
+}
+
+// DEFAULT_VOLATILE_COOKIES are the cookie attributes ignored by default.
+var DEFAULT_VOLATILE_COOKIES = []string{
+	"*.expiration", "*.maxage",
+}
+
+type Change struct {
+	Section   Section   `json:"section"`
+	Path      string    `json:"path"`
+	Operation Operation `json:"operation"`
+	Before    any       `json:"before,omitempty"`
+	After     any       `json:"after,omitempty"`
+}
+
+type Report struct {
+	Equal   bool     `json:"equal"`
+	Added   int      `json:"added"`
+	Removed int      `json:"removed"`
+	Changed int      `json:"changed"`
+	Changes []Change `json:"changes"`
+}
+
+type DiffEngine struct {
+	headers map[string]bool
+	fields  map[string]bool
+	paths   map[Section][]*regexp.Regexp
+}
+
+func NewDiffEngine() *DiffEngine {
+	return NewDiffEngineEmpty().
+		IgnoreHeaders(DEFAULT_VOLATILE_HEADERS...).
+		IgnorePaths(COOKIE, DEFAULT_VOLATILE_COOKIES...)
+}
+
+// NewDiffEngineEmpty returns an engine that compares every field.
+func NewDiffEngineEmpty() *DiffEngine {
+	return &DiffEngine{
+		headers: make(map[string]bool),
+		fields:  make(map[string]bool),
+		paths:   make(map[Section][]*regexp.Regexp),
+	}
+}
+
+func (d *DiffEngine) IgnoreHeaders(names ...string) *DiffEngine {
+	for _, v := range names {
+		d.headers[http.CanonicalHeaderKey(v)] = true
+	}
+	return d
+}
+
+// IgnoreFields ignores the body fields with any of the names, wherever they
+// are in the document.
+func (d *DiffEngine) IgnoreFields(names ...string) *DiffEngine {
+	for _, v := range names {
+		d.fields[v] = true
+	}
+	return d
+}
+
+// IgnorePaths ignores the paths of the section and everything below them. A
+// "*" matches a single field or index and "**" any number of them, e.g.
+// "$.items[*].updated" or "$.meta.**".
+func (d *DiffEngine) IgnorePaths(section Section, patterns ...string) *DiffEngine {
+	for _, v := range patterns {
+		d.paths[section] = append(d.paths[section], compilePattern(v))
+	}
+	return d
+}
+
+func (d *DiffEngine) Compare(before, after action.Response) Report {
+	changes := make([]Change, 0)
+
+	if before.Status != after.Status {
+		changes = d.push(changes, Change{
+			Section:   STATUS,
+			Path:      "status",
+			Operation: CHANGED,
+			Before:    before.Status,
+			After:     after.Status,
+		})
+	}
+
+	changes = d.compareHeaders(changes, before, after)
+	changes = d.compareCookies(changes, before.Cookies, after.Cookies)
+	changes = d.compareBody(changes, before, after)
+
+	return makeReport(changes)
+}
+
+func (d *DiffEngine) compareHeaders(changes []Change, before, after action.Response) []Change {
+	source := headerValues(before)
+	target := headerValues(after)
+
+	for _, k := range unionKeys(source, target) {
+		if d.headers[k] {
+			continue
+		}
+
+		value := source[k]
+		other := target[k]
+		change := Change{
+			Section: HEADER,
+			Path:    k,
+		}
+
+		switch {
+		case value == nil:
+			change.Operation = ADDED
+			change.After = strings.Join(other, ", ")
+		case other == nil:
+			change.Operation = REMOVED
+			change.Before = strings.Join(value, ", ")
+		case strings.Join(value, "\n") != strings.Join(other, "\n"):
+			change.Operation = CHANGED
+			change.Before = strings.Join(value, ", ")
+			change.After = strings.Join(other, ", ")
+		default:
+			continue
+		}
+
+		changes = d.push(changes, change)
+	}
+
+	return changes
+}
+
+// headerValues indexes the headers by their canonical name, the cookies are
+// compared on their own so Set-Cookie is left out.
+func headerValues(response action.Response) map[string][]string {
+	values := make(map[string][]string)
+	for k, vs := range response.Headers.Headers {
+		key := http.CanonicalHeaderKey(k)
+		if key == "Set-Cookie" {
+			continue
+		}
+		for _, v := range vs {
+			values[key] = append(values[key], v.Value)
+		}
+	}
+	return values
+}
+
+func (d *DiffEngine) compareCookies(changes []Change, before, after cookie.CookiesServer) []Change {
+	for _, k := range unionKeys(before.Cookies, after.Cookies) {
+		value, okBefore := before.Cookies[k]
+		other, okAfter := after.Cookies[k]
+
+		switch {
+		case !okBefore:
+			changes = d.push(changes, Change{Section: COOKIE, Path: k, Operation: ADDED, After: other.Value})
+			continue
+		case !okAfter:
+			changes = d.push(changes, Change{Section: COOKIE, Path: k, Operation: REMOVED, Before: value.Value})
+			continue
+		}
+
+		source := cookieAttributes(value)
+		target := cookieAttributes(other)
+		for _, a := range sortedKeys(source) {
+			if source[a] != target[a] {
+				changes = d.push(changes, Change{
+					Section:   COOKIE,
+					Path:      fmt.Sprintf("%s.%s", k, a),
+					Operation: CHANGED,
+					Before:    source[a],
+					After:     target[a],
+				})
+			}
+		}
+	}
+
+	return changes
+}
+
+func cookieAttributes(value cookie.CookieServer) map[string]any {
+	return map[string]any{
+		"value":      value.Value,
+		"domain":     value.Domain,
+		"path":       value.Path,
+		"expiration": value.Expiration,
+		"maxage":     value.MaxAge,
+		"secure":     value.Secure,
+		"httponly":   value.HttpOnly,
+		"samesite":   value.SameSite.String(),
+	}
+}
+
+// push appends the change unless its path is ignored.
+func (d *DiffEngine) push(changes []Change, change Change) []Change {
+	for _, v := range d.paths[change.Section] {
+		if v.MatchString(change.Path) {
+			return changes
+		}
+	}
+	return append(changes, change)
+}
+
+func makeReport(changes []Change) Report {
+	order := map[Section]int{STATUS: 0, HEADER: 1, COOKIE: 2, BODY: 3, REQUEST: 4}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return order[changes[i].Section] < order[changes[j].Section]
+	})
+
+	report := Report{
+		Equal:   len(changes) == 0,
+		Changes: changes,
+	}
+
+	for _, v := range changes {
+		switch v.Operation {
+		case ADDED:
+			report.Added++
+		case REMOVED:
+			report.Removed++
+		case CHANGED:
+			report.Changed++
+		}
+	}
+
+	return report
+}
+
+// compilePattern turns the path pattern into an expression that also matches
+// the paths below it.
+func compilePattern(pattern string) *regexp.Regexp {
+	expression := regexp.QuoteMeta(pattern)
+	expression = strings.ReplaceAll(expression, `\*\*`, `.*`)
+	expression = strings.ReplaceAll(expression, `\*`, `[^.\[\]]*`)
+	return regexp.MustCompile(`^` + expression + `($|[.\[])`)
+}
+
+func unionKeys[T any](source, target map[string]T) []string {
+	keys := make(map[string]bool)
+	for k := range source {
+		keys[k] = true
+	}
+	for k := range target {
+		keys[k] = true
+	}
+	return sortedKeys(keys)
+}
+
+func sortedKeys[T any](items map[string]T) []string {
+	keys := make([]string, 0, len(items))
+	for k := range items {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
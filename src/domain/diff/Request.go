@@ -0,0 +1,51 @@
+package diff
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+)
+
+// REQUEST_IDENTITY_FIELDS are the request fields left out of the comparison,
+// they change on every revision without being edited.
+var REQUEST_IDENTITY_FIELDS = []string{
+	"_id", "timestamp", "modified", "owner",
+}
+
+// CompareRequests compares two states of a request field by field, the paths
+// are rooted at "$" like the JSON of the request.
+func (d *DiffEngine) CompareRequests(before, after action.Request) Report {
+	changes := make([]Change, 0)
+
+	source, okSource := requestDocument(before)
+	target, okTarget := requestDocument(after)
+	if okSource && okTarget {
+		changes = d.compareValue(changes, REQUEST, "$", source, target)
+	}
+
+	return makeReport(changes)
+}
+
+func requestDocument(request action.Request) (map[string]any, bool) {
+	content, err := json.Marshal(request)
+	if err != nil {
+		return nil, false
+	}
+
+	value, ok := parseJson(strings.TrimSpace(string(content)))
+	if !ok {
+		return nil, false
+	}
+
+	document, ok := value.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+
+	for _, v := range REQUEST_IDENTITY_FIELDS {
+		delete(document, v)
+	}
+
+	return document, true
+}
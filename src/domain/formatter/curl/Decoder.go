@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"net/url"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/Rafael24595/go-api-core/src/commons/utils"
@@ -13,6 +16,7 @@ import (
 	auth_strategy "github.com/Rafael24595/go-api-core/src/domain/action/auth/strategy"
 	"github.com/Rafael24595/go-api-core/src/domain/action/body"
 	body_strategy "github.com/Rafael24595/go-api-core/src/domain/action/body/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/action/param"
 	"github.com/Rafael24595/go-api-core/src/domain/action/query"
 	"github.com/Rafael24595/go-collections/collection"
 )
@@ -24,43 +28,467 @@ const (
 	CMD_CONTINUATION  = "^\n"
 )
 
-type curlData struct {
-	uri    string
-	tuples []utils.CmdTuple
+type Command struct {
+	Request *action.Request
+}
+
+// option describes a curl flag, argument defines if the flag consumes the
+// next token and apply maps it into the decoder state, nil apply means the
+// flag is accepted but has no effect on the request.
+type option struct {
+	names    []string
+	argument bool
+	apply    func(state *decoder, data string) error
+}
+
+var optionTable = []option{
+	{names: []string{"-X", "--request"}, argument: true, apply: applyMethod},
+	{names: []string{"-H", "--header"}, argument: true, apply: applyHeader},
+	{names: []string{"-A", "--user-agent"}, argument: true, apply: namedHeader("User-Agent")},
+	{names: []string{"-e", "--referer"}, argument: true, apply: namedHeader("Referer")},
+	{names: []string{"-r", "--range"}, argument: true, apply: rangeHeader},
+	{names: []string{"-d", "--data", "--data-ascii", "--data-raw"}, argument: true, apply: applyData},
+	{names: []string{"--data-binary"}, argument: true, apply: applyBinary},
+	{names: []string{"--data-urlencode"}, argument: true, apply: applyDataUrlencode},
+	{names: []string{"--json"}, argument: true, apply: applyJson},
+	{names: []string{"-F", "--form"}, argument: true, apply: applyForm},
+	{names: []string{"--form-string"}, argument: true, apply: applyFormString},
+	{names: []string{"-T", "--upload-file"}, argument: true, apply: applyUpload},
+	{names: []string{"-G", "--get"}, apply: applyGet},
+	{names: []string{"-I", "--head"}, apply: applyHead},
+	{names: []string{"-u", "--user"}, argument: true, apply: applyBasicAuth},
+	{names: []string{"--oauth2-bearer"}, argument: true, apply: applyBearerAuth},
+	{names: []string{"-b", "--cookie"}, argument: true, apply: applyCookie},
+	{names: []string{"--url", "--uri"}, argument: true, apply: applyUri},
+	{names: []string{"--expand-url"}, argument: true, apply: applyExpandUri},
+	{names: []string{"--variable"}, argument: true, apply: applyVariable},
+	{names: []string{"-k", "--insecure"}, apply: func(s *decoder, _ string) error { s.options.Insecure = true; return nil }},
+	{names: []string{"-L", "--location"}, apply: func(s *decoder, _ string) error { s.options.FollowRedirects = true; return nil }},
+	{names: []string{"--compressed"}, apply: func(s *decoder, _ string) error { s.options.Compressed = true; return nil }},
+	{names: []string{"-x", "--proxy"}, argument: true, apply: func(s *decoder, d string) error { s.options.Proxy = d; return nil }},
+	{names: []string{"-m", "--max-time"}, argument: true, apply: applyMaxTime},
+	{names: []string{"--connect-timeout"}, argument: true, apply: applyConnectTimeout},
+	{names: []string{
+		"-s", "--silent", "-S", "--show-error", "-v", "--verbose", "-i", "--include",
+		"-f", "--fail", "--fail-with-body", "-#", "--progress-bar", "--no-progress-meter",
+		"-N", "--no-buffer", "-g", "--globoff", "-0", "--http1.0", "--http1.1", "--http2",
+		"--http2-prior-knowledge", "--http3", "-4", "--ipv4", "-6", "--ipv6", "-O", "--remote-name",
+		"-J", "--remote-header-name", "-q", "--disable", "--path-as-is", "--tr-encoding",
+	}},
+	{names: []string{
+		"-o", "--output", "-w", "--write-out", "-c", "--cookie-jar", "-D", "--dump-header",
+		"--retry", "--retry-delay", "--retry-max-time", "--max-redirs", "--limit-rate",
+		"--cacert", "--capath", "-E", "--cert", "--cert-type", "--key", "--key-type",
+		"--resolve", "--connect-to", "--interface", "--trace", "--trace-ascii", "-K", "--config",
+		"-U", "--proxy-user", "-z", "--time-cond", "-Y", "--speed-limit", "-y", "--speed-time",
+	}, argument: true},
+}
+
+var options = indexOptions(optionTable)
+
+// expandPattern matches the variables of an expanded uri, with their
+// optional functions.
+var expandPattern = regexp.MustCompile(`\{\{([A-Za-z0-9_-]+)(?::[a-z]+)*\}\}`)
+
+func indexOptions(table []option) map[string]option {
+	index := make(map[string]option)
+	for _, v := range table {
+		for _, name := range v.names {
+			index[name] = v
+		}
+	}
+	return index
+}
+
+type decoder struct {
+	uri       string
+	method    string
+	request   *action.Request
+	options   action.ClientOptions
+	variables map[string]string
+	data      []string
+	json      bool
+	get       bool
+	head      bool
+	upload    bool
+	expand    bool
 }
 
 func Unmarshal(curl []byte) (*action.Request, error) {
-	data, err := decode(curl)
+	command, err := UnmarshalCommand(curl)
 	if err != nil {
 		return nil, err
 	}
+	return command.Request, nil
+}
+
+func UnmarshalCommand(curl []byte) (*Command, error) {
+	args, err := utils.SplitCommand(clean(curl))
+	if err != nil {
+		return nil, err
+	}
+	return decode(args)
+}
+
+// UnmarshalMany decodes a file with one curl command per line, continuation
+// lines are joined and lines starting with '#' are ignored.
+func UnmarshalMany(file []byte) ([]Command, error) {
+	commands := make([]Command, 0)
+	for i, v := range splitCommands(clean(file)) {
+		command, err := UnmarshalCommand([]byte(v))
+		if err != nil {
+			return nil, fmt.Errorf("command %d: %w", i+1, err)
+		}
+		commands = append(commands, *command)
+	}
+	return commands, nil
+}
+
+func decode(args []string) (*Command, error) {
+	fragments := collection.VectorFromList(args)
+	head, ok := fragments.Shift()
+	if !ok || head != "curl" {
+		return nil, errors.New("the command is not a valid curl sentence")
+	}
 
-	uri, query := processUri(data.uri)
+	state := &decoder{
+		request:   action.NewRequest("", domain.GET, ""),
+		variables: make(map[string]string),
+		data:      make([]string, 0),
+	}
+
+	literal := false
+	for fragments.Size() > 0 {
+		token, _ := fragments.Shift()
+
+		if literal || !strings.HasPrefix(token, "-") || token == "-" {
+			if err := applyUri(state, token); err != nil {
+				return nil, err
+			}
+			continue
+		}
 
-	name := fmt.Sprintf("[cURL] %s", uri)
-	request := action.NewRequest(name, domain.GET, uri)
-	request.Query = *query
+		if token == "--" {
+			literal = true
+			continue
+		}
 
-	for _, v := range data.tuples {
-		switch v.Flag {
-		case "-X":
-			request.Method = domain.HttpMethod(v.Data)
-		case "-H", "--header":
-			request = processHeader(v.Data, request)
-		case "-d", "--data", "--data-raw":
-			request = processDocument(v.Data, request)
-		case "--data-binary":
-			request = processBinary(v.Data, request)
-		case "-F", "--form":
-			request = processFormData(v.Data, request)
-		case "-u", "--user":
-			request = processBasicAuth(v.Data, request)
-		case "-b", "--cookie":
-			request = processCookie(v.Data, request)
+		if err := decodeOption(state, token, fragments); err != nil {
+			return nil, err
+		}
+	}
+
+	return state.make()
+}
+
+func decodeOption(state *decoder, token string, fragments *collection.Vector[string]) error {
+	if strings.HasPrefix(token, "--") {
+		opt, ok := options[token]
+		if !ok {
+			return fmt.Errorf("unsupported curl option '%s'", token)
+		}
+		return applyOption(state, token, opt, "", fragments)
+	}
+
+	flags := []rune(strings.TrimPrefix(token, "-"))
+	for i, r := range flags {
+		name := "-" + string(r)
+		opt, ok := options[name]
+		if !ok {
+			return fmt.Errorf("unsupported curl option '%s'", name)
+		}
+
+		if opt.argument {
+			return applyOption(state, name, opt, string(flags[i+1:]), fragments)
+		}
+
+		if err := applyOption(state, name, opt, "", fragments); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func applyOption(state *decoder, name string, opt option, data string, fragments *collection.Vector[string]) error {
+	if opt.argument && data == "" {
+		value, ok := fragments.Shift()
+		if !ok {
+			return fmt.Errorf("the option '%s' requires a value", name)
 		}
+		data = value
+	}
+
+	if opt.apply == nil {
+		return nil
+	}
+
+	return opt.apply(state, data)
+}
+
+func (s *decoder) make() (*Command, error) {
+	if s.uri == "" {
+		return nil, errors.New("the uri is not defined")
+	}
+
+	uri := s.uri
+	if s.expand {
+		uri = expandUri(uri)
+	}
+
+	uri, queries := processUri(uri)
+
+	request := s.request
+	request.Name = fmt.Sprintf("[cURL] %s", uri)
+	request.Uri = uri
+	request.Query = *queries
+	request.Param = *processParams(uri, s.variables)
+
+	if len(s.data) > 0 && s.get {
+		if values, err := url.ParseQuery(strings.Join(s.data, "&")); err == nil {
+			for _, key := range sortedKeys(values) {
+				for _, v := range values[key] {
+					request.Query.Add(key, v)
+				}
+			}
+		}
+	} else if len(s.data) > 0 {
+		request.Body = *body_strategy.DocumentBody(true, s.documentType(), strings.Join(s.data, s.separator()))
+	}
+
+	request.Method = domain.HttpMethod(s.resolveMethod())
+
+	if s.options != (action.ClientOptions{}) {
+		request.Options = s.options
+		request.Options.Status = true
+	}
+
+	return &Command{
+		Request: request,
+	}, nil
+}
+
+// resolveMethod follows curl, an upload is sent with PUT even when the
+// command also has data.
+func (s *decoder) resolveMethod() string {
+	switch {
+	case s.method != "":
+		return s.method
+	case s.upload:
+		return "PUT"
+	case s.head:
+		return "HEAD"
+	case s.get:
+		return "GET"
+	case len(s.data) > 0 || !s.request.Body.Empty():
+		return "POST"
+	default:
+		return "GET"
+	}
+}
+
+func (s *decoder) documentType() domain.ContentType {
+	if s.json {
+		return domain.Json
+	}
+
+	for key, headers := range s.request.Header.Headers {
+		if !strings.EqualFold(key, "Content-Type") || len(headers) == 0 {
+			continue
+		}
+		if contentType, ok := domain.ContentTypeFromHeader(headers[0].Value); ok && contentType != domain.Form {
+			return contentType
+		}
+	}
+
+	return domain.Text
+}
+
+func (s *decoder) separator() string {
+	if s.json {
+		return ""
+	}
+	return "&"
+}
+
+func applyMethod(state *decoder, data string) error {
+	state.method = strings.ToUpper(strings.TrimSpace(data))
+	return nil
+}
+
+func applyHeader(state *decoder, data string) error {
+	state.request = processHeader(data, state.request)
+	return nil
+}
+
+func namedHeader(key string) func(state *decoder, data string) error {
+	return func(state *decoder, data string) error {
+		state.request.Header.Add(key, data)
+		return nil
+	}
+}
+
+func rangeHeader(state *decoder, data string) error {
+	state.request.Header.Add("Range", fmt.Sprintf("bytes=%s", data))
+	return nil
+}
+
+func applyData(state *decoder, data string) error {
+	state.data = append(state.data, data)
+	return nil
+}
+
+func applyBinary(state *decoder, data string) error {
+	value := strings.TrimSpace(data)
+	if path, ok := strings.CutPrefix(value, "@"); ok {
+		value = path
+	}
+	state.data = append(state.data, value)
+	return nil
+}
+
+func applyDataUrlencode(state *decoder, data string) error {
+	name, content, hasName := strings.Cut(data, "=")
+	if !hasName {
+		state.data = append(state.data, url.QueryEscape(data))
+		return nil
+	}
+
+	if name == "" {
+		state.data = append(state.data, url.QueryEscape(content))
+		return nil
+	}
+
+	state.data = append(state.data, fmt.Sprintf("%s=%s", name, url.QueryEscape(content)))
+	return nil
+}
+
+func applyJson(state *decoder, data string) error {
+	state.json = true
+	state.data = append(state.data, data)
+	if !hasHeader(state.request, "Content-Type") {
+		state.request.Header.Add("Content-Type", "application/json")
+	}
+	if !hasHeader(state.request, "Accept") {
+		state.request.Header.Add("Accept", "application/json")
+	}
+	return nil
+}
+
+func applyForm(state *decoder, data string) error {
+	state.request = processFormData(data, state.request)
+	return nil
+}
+
+func applyFormString(state *decoder, data string) error {
+	key, value, _ := strings.Cut(data, "=")
+	body := body_strategy.AddFormData(&state.request.Body, strings.TrimSpace(key), body.NewParameterActive(value))
+	state.request.Body = *body
+	return nil
+}
+
+// applyUpload only sets the method, the uploaded file is local to the
+// command and is not imported.
+func applyUpload(state *decoder, _ string) error {
+	state.upload = true
+	return nil
+}
+
+func applyGet(state *decoder, _ string) error {
+	state.get = true
+	return nil
+}
+
+func applyHead(state *decoder, _ string) error {
+	state.head = true
+	return nil
+}
+
+func applyBasicAuth(state *decoder, data string) error {
+	state.request = processBasicAuth(data, state.request)
+	return nil
+}
+
+func applyBearerAuth(state *decoder, data string) error {
+	auth := auth_strategy.BearerAuth(true, auth_strategy.DEFAULT_BEARER_PREFIX, strings.TrimSpace(data))
+	state.request.Auth.PutAuth(*auth)
+	state.request.Auth.Status = true
+	return nil
+}
+
+// applyCookie imports the inline cookies, a cookie file is local to the
+// command and is ignored.
+func applyCookie(state *decoder, data string) error {
+	if strings.HasPrefix(data, "@") || !strings.Contains(data, "=") {
+		return nil
+	}
+
+	state.request = processCookie(data, state.request)
+	return nil
+}
+
+func applyMaxTime(state *decoder, data string) error {
+	timeout, err := parseSeconds(data)
+	if err != nil {
+		return fmt.Errorf("invalid max time '%s'", data)
+	}
+	state.options.Timeout = timeout
+	return nil
+}
+
+func applyConnectTimeout(state *decoder, data string) error {
+	timeout, err := parseSeconds(data)
+	if err != nil {
+		return fmt.Errorf("invalid connect timeout '%s'", data)
+	}
+	state.options.ConnectTimeout = timeout
+	return nil
+}
+
+// parseSeconds reads the curl fractional seconds as milliseconds.
+func parseSeconds(data string) (int64, error) {
+	seconds, err := strconv.ParseFloat(strings.TrimSpace(data), 64)
+	if err != nil || seconds < 0 {
+		return 0, errors.New("invalid seconds")
+	}
+	return int64(seconds * 1000), nil
+}
+
+func applyUri(state *decoder, data string) error {
+	if state.uri != "" {
+		return nil
+	}
+
+	uri := strings.TrimSpace(data)
+	if _, err := url.Parse(uri); err != nil {
+		return err
+	}
+
+	if !strings.Contains(uri, "://") && !strings.HasPrefix(uri, "/") && !strings.HasPrefix(uri, "${") {
+		uri = fmt.Sprintf("http://%s", uri)
 	}
 
-	return request, nil
+	state.uri = uri
+	return nil
+}
+
+func applyExpandUri(state *decoder, data string) error {
+	if state.uri != "" {
+		return nil
+	}
+	state.expand = true
+	return applyUri(state, data)
+}
+
+// applyVariable stores the "name=content" variables, the variables read from
+// files or the environment are ignored.
+func applyVariable(state *decoder, data string) error {
+	name, value, ok := strings.Cut(data, "=")
+	if !ok || strings.ContainsAny(name, "@%") {
+		return nil
+	}
+	state.variables[strings.TrimSpace(name)] = value
+	return nil
 }
 
 func processUri(uri string) (string, *query.Queries) {
@@ -71,27 +499,67 @@ func processUri(uri string) (string, *query.Queries) {
 		return uri, queries
 	}
 
-	values, err := url.ParseQuery(fragments[1])
-	if err != nil {
-		return uri, queries
-	}
+	// The pairs are read in source order, so the same command always
+	// decodes to the same query order.
+	order := int64(0)
+	for _, pair := range strings.Split(fragments[1], "&") {
+		if pair == "" {
+			continue
+		}
 
-	for key, vals := range values {
-		for _, v := range vals {
-			queries.Add(key, v)
+		key, value, _ := strings.Cut(pair, "=")
+		key, errKey := url.QueryUnescape(key)
+		value, errValue := url.QueryUnescape(value)
+		if errKey != nil || errValue != nil {
+			return uri, query.NewQueries()
 		}
+
+		name, property, ok := splitQueryKey(key)
+		switch {
+		case !ok:
+			queries.AddQuery(key, query.NewQuery(order, true, value))
+		case property == "":
+			queries.AddQuery(name, query.NewQuery(order, true, value))
+			queries.PutStyle(name, query.BRACKETS, true)
+		default:
+			queries.AddQuery(name, query.NewPropertyQuery(order, true, property, value))
+			queries.PutStyle(name, query.DEEP_OBJECT, true)
+		}
+		order++
 	}
 
 	return fragments[0], queries
 }
 
-func processDocument(data string, request *action.Request) *action.Request {
-	request.Body = *body_strategy.DocumentBody(true, domain.Text, data)
-	if request.Method == domain.GET {
-		request.Method = domain.POST
+func splitQueryKey(key string) (string, string, bool) {
+	start := strings.Index(key, "[")
+	if start <= 0 || !strings.HasSuffix(key, "]") {
+		return key, "", false
 	}
 
-	return request
+	property := key[start+1 : len(key)-1]
+	if strings.ContainsAny(property, "[]") {
+		return key, "", false
+	}
+
+	return key[:start], property, true
+}
+
+// processParams defines the placeholders of the uri as path parameters, the
+// parameters with a curl variable are enabled with its value.
+func processParams(uri string, variables map[string]string) *param.Params {
+	params := param.NewParams()
+	for _, v := range param.FindKeys(uri) {
+		value, ok := variables[v]
+		params.AddStatus(v, value, ok)
+	}
+	return params
+}
+
+// expandUri turns the curl variables of an expanded uri, such as "{{id}}" or
+// "{{id:url}}", into path parameter placeholders.
+func expandUri(uri string) string {
+	return expandPattern.ReplaceAllString(uri, "{$1}")
 }
 
 func processFormData(data string, request *action.Request) *action.Request {
@@ -115,32 +583,30 @@ func processFormData(data string, request *action.Request) *action.Request {
 
 	request.Body = *body
 
-	if request.Method == domain.GET {
-		request.Method = domain.POST
-	}
-
 	return request
 }
 
-func processBinary(data string, request *action.Request) *action.Request {
-	value := strings.TrimSpace(data)
-	if path, ok := strings.CutPrefix(value, "@"); ok {
-		value = path
+func processHeader(data string, request *action.Request) *action.Request {
+	key, value, ok := strings.Cut(data, ":")
+	if !ok {
+		// "Name;" sends the header without value.
+		key, ok = strings.CutSuffix(strings.TrimSpace(data), ";")
+	}
+
+	if ok && strings.TrimSpace(key) != "" {
+		request.Header.Add(strings.TrimSpace(key), strings.TrimSpace(value))
 	}
 
-	return processDocument(value, request)
+	return request
 }
 
-func processHeader(data string, request *action.Request) *action.Request {
-	for hs := range strings.SplitSeq(data, ";") {
-		parts := strings.SplitN(hs, ":", 2)
-		if len(parts) == 2 {
-			key := strings.TrimSpace(parts[0])
-			value := strings.TrimSpace(parts[1])
-			request.Header.Add(key, value)
+func hasHeader(request *action.Request, key string) bool {
+	for k := range request.Header.Headers {
+		if strings.EqualFold(k, key) {
+			return true
 		}
 	}
-	return request
+	return false
 }
 
 func processBasicAuth(data string, request *action.Request) *action.Request {
@@ -170,56 +636,6 @@ func processCookie(data string, request *action.Request) *action.Request {
 	return request
 }
 
-func decode(curl []byte) (*curlData, error) {
-	clean := clean(curl)
-
-	args, err := utils.SplitCommand(clean)
-	if err != nil {
-		return nil, err
-	}
-
-	uri := ""
-	tuples := make([]utils.CmdTuple, 0)
-
-	fragments := collection.VectorFromList(args)
-	head, ok := fragments.Shift()
-	if !ok || head != "curl" {
-		return nil, errors.New("the command is not a valid curl sentence")
-	}
-
-	for fragments.Size() > 0 {
-		flag, ok := fragments.Shift()
-		if !ok {
-			return nil, errors.New("the command flag could not be empty")
-		}
-
-		url, ok, err := cutUri(flag, fragments)
-		if err != nil {
-			return nil, err
-		}
-
-		if ok {
-			uri = url
-			continue
-		}
-
-		data, ok := fragments.Shift()
-		if !ok {
-			return nil, errors.New("the command flag data could not be empty")
-		}
-
-		tuples = append(tuples, utils.CmdTuple{
-			Flag: flag,
-			Data: data,
-		})
-	}
-
-	return &curlData{
-		uri:    uri,
-		tuples: tuples,
-	}, nil
-}
-
 func clean(curl []byte) string {
 	clean := strings.ReplaceAll(string(curl), WINDOWS_NEW_LINE, "\n")
 	clean = strings.ReplaceAll(clean, UNIX_CONTINUATION, " ")
@@ -228,27 +644,51 @@ func clean(curl []byte) string {
 	return strings.TrimSpace(clean)
 }
 
-func cutUri(flag string, fragmensts *collection.Vector[string]) (string, bool, error) {
-	if flag == "--uri" || flag == "--url" {
-		uri, ok := fragmensts.Shift()
-		if !ok {
-			return "", false, errors.New("the uri is not defined")
-		}
+// splitCommands breaks the text in commands by the unquoted line breaks.
+func splitCommands(text string) []string {
+	commands := make([]string, 0)
+	buffer := make([]rune, 0)
 
-		flag = uri
-	} else if strings.HasPrefix(flag, "-") &&
-		(!strings.Contains(flag, "://") && !strings.HasPrefix(flag, "/")) {
-		return "", false, nil
-	}
+	quote := rune(0)
+	escaped := false
 
-	u, err := url.Parse(flag)
-	if err != nil {
-		return "", false, err
+	flush := func() {
+		command := strings.TrimSpace(string(buffer))
+		if command != "" && !strings.HasPrefix(command, "#") {
+			commands = append(commands, command)
+		}
+		buffer = make([]rune, 0)
 	}
 
-	if u.Scheme == "" && !strings.HasPrefix(flag, "/") {
-		return "", false, fmt.Errorf("invalid URI: %s", flag)
+	for _, r := range text {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '\n':
+			flush()
+			continue
+		}
+		buffer = append(buffer, r)
 	}
 
-	return flag, true, nil
+	flush()
+
+	return commands
+}
+
+func sortedKeys[T any](items map[string]T) []string {
+	keys := make([]string, 0, len(items))
+	for k := range items {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
 }
@@ -3,6 +3,7 @@ package curl
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/Rafael24595/go-api-core/src/domain"
@@ -10,6 +11,8 @@ import (
 	auth_strategy "github.com/Rafael24595/go-api-core/src/domain/action/auth/strategy"
 	"github.com/Rafael24595/go-api-core/src/domain/action/body"
 	body_strategy "github.com/Rafael24595/go-api-core/src/domain/action/body/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/action/param"
+	"github.com/Rafael24595/go-api-core/src/domain/action/query"
 
 	"github.com/Rafael24595/go-api-core/src/domain/context"
 )
@@ -23,7 +26,7 @@ func Marshal(req *action.Request, inline bool) (string, error) {
 	buffer := make([]string, 0)
 
 	method := strings.ToUpper(req.Method.String())
-	url := strings.TrimSpace(req.Uri)
+	url, variables := paramsToCurl(req)
 
 	if method == "" || url == "" {
 		return "", errors.New("the method or the URI are empty")
@@ -33,7 +36,13 @@ func Marshal(req *action.Request, inline bool) (string, error) {
 
 	query := queryToCurl(req)
 
-	buffer = append(buffer, fmt.Sprintf("curl -X %s %s%s", method, url, query))
+	if len(variables) == 0 {
+		buffer = append(buffer, fmt.Sprintf("curl -X %s %s%s", method, url, query))
+	} else {
+		buffer = append(buffer, fmt.Sprintf("curl -X %s", method))
+		buffer = append(buffer, variables...)
+		buffer = append(buffer, fmt.Sprintf("--expand-url %s%s", url, query))
+	}
 
 	cookies := cookiesToCurl(req)
 	buffer = append(buffer, cookies...)
@@ -44,6 +53,9 @@ func Marshal(req *action.Request, inline bool) (string, error) {
 	payload := bodyToCurl(req)
 	buffer = append(buffer, payload...)
 
+	options := optionsToCurl(req.Options)
+	buffer = append(buffer, options...)
+
 	for i := 0; i < len(buffer); i++ {
 		buffer[i] = strings.ReplaceAll(buffer[i], "\n", " ")
 	}
@@ -56,9 +68,70 @@ func Marshal(req *action.Request, inline bool) (string, error) {
 	return strings.Join(buffer, delimiter), nil
 }
 
+// paramsToCurl keeps the active path parameters as curl variables, the URI is
+// expanded by curl so the parameters survive an import of the command.
+func paramsToCurl(req *action.Request) (string, []string) {
+	variables := make([]string, 0)
+	defined := make(map[string]bool)
+
+	var buffer strings.Builder
+	for _, s := range param.FindSegments(strings.TrimSpace(req.Uri)) {
+		p, ok := req.Param.Find(s.Key)
+		if s.Key == "" || !ok || !p.Status {
+			buffer.WriteString(s.Raw)
+			continue
+		}
+
+		buffer.WriteString(fmt.Sprintf("{{%s:url}}", s.Key))
+		if !defined[s.Key] {
+			defined[s.Key] = true
+			variables = append(variables, fmt.Sprintf(`--variable "%s=%s"`, s.Key, p.Value))
+		}
+	}
+
+	return buffer.String(), variables
+}
+
+func optionsToCurl(options action.ClientOptions) []string {
+	buffer := make([]string, 0)
+	if !options.Status {
+		return buffer
+	}
+
+	if options.Insecure {
+		buffer = append(buffer, "-k")
+	}
+	if options.FollowRedirects {
+		buffer = append(buffer, "-L")
+	}
+	if options.Compressed {
+		buffer = append(buffer, "--compressed")
+	}
+	if options.Proxy != "" {
+		buffer = append(buffer, fmt.Sprintf("-x '%s'", options.Proxy))
+	}
+	if options.Timeout > 0 {
+		buffer = append(buffer, fmt.Sprintf("-m %s", formatSeconds(options.Timeout)))
+	}
+	if options.ConnectTimeout > 0 {
+		buffer = append(buffer, fmt.Sprintf("--connect-timeout %s", formatSeconds(options.ConnectTimeout)))
+	}
+
+	return buffer
+}
+
+func formatSeconds(milliseconds int64) string {
+	return strconv.FormatFloat(float64(milliseconds)/1000, 'f', -1, 64)
+}
+
 func queryToCurl(req *action.Request) string {
 	buffer := make([]string, 0)
 	for k, q := range req.Query.Queries {
+		if serialization := req.Query.FindSerialization(k); !serialization.IsDefault() || hasProperties(q) {
+			buffer = append(buffer, serializeQuery(k, serialization, q)...)
+			continue
+		}
+
 		values := make([]string, 0)
 		for _, v := range q {
 			if !v.Status {
@@ -82,6 +155,32 @@ func queryToCurl(req *action.Request) string {
 	return fmt.Sprintf("?%s", strings.Join(buffer, "&"))
 }
 
+func serializeQuery(key string, serialization query.Serialization, queries []query.Query) []string {
+	active := make([]query.Query, 0)
+	for _, v := range queries {
+		if !v.Status {
+			continue
+		}
+		v.Value = strings.TrimSpace(v.Value)
+		active = append(active, v)
+	}
+
+	if len(active) == 0 {
+		return []string{}
+	}
+
+	return query.Serialize(key, serialization, active)
+}
+
+func hasProperties(queries []query.Query) bool {
+	for _, v := range queries {
+		if v.Status && v.Property != "" {
+			return true
+		}
+	}
+	return false
+}
+
 func cookiesToCurl(req *action.Request) []string {
 	buffer := make([]string, 0)
 
@@ -0,0 +1,399 @@
+package docs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	"github.com/Rafael24595/go-api-core/src/domain/action/auth"
+	auth_strategy "github.com/Rafael24595/go-api-core/src/domain/action/auth/strategy"
+	body_strategy "github.com/Rafael24595/go-api-core/src/domain/action/body/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/action/param"
+	"github.com/Rafael24595/go-api-core/src/domain/collection"
+)
+
+type Format string
+
+const (
+	MARKDOWN Format = "markdown"
+	HTML     Format = "html"
+)
+
+const (
+	SECRET_MASK = "********"
+	MAX_EXAMPLE = 4096
+)
+
+var variablePattern = regexp.MustCompile(`^\$\{[^}]+\}$`)
+
+// sensitiveKeys are the fragments of the header, query and cookie names whose
+// values are masked.
+var sensitiveKeys = []string{
+	"authorization", "cookie", "token", "secret", "password", "passwd", "apikey", "api-key", "session", "signature",
+}
+
+// Source is a collection with its requests in node order and the reference
+// response of each request indexed by the request id.
+type Source struct {
+	Collection collection.Collection
+	Requests   []action.Request
+	Responses  map[string]action.Response
+}
+
+func FormatFromString(value string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "markdown", "md", "":
+		return MARKDOWN, nil
+	case "html", "htm":
+		return HTML, nil
+	default:
+		return "", fmt.Errorf("unsupported documentation format '%s'", value)
+	}
+}
+
+func Marshal(format Format, title string, sources ...Source) ([]byte, error) {
+	switch format {
+	case MARKDOWN:
+		return MarshalMarkdown(title, sources...), nil
+	case HTML:
+		return MarshalHtml(title, sources...)
+	default:
+		return nil, fmt.Errorf("unsupported documentation format '%s'", format)
+	}
+}
+
+type page struct {
+	Title       string
+	Collections []collectionDoc
+}
+
+type collectionDoc struct {
+	Name     string
+	Anchor   string
+	Requests []requestDoc
+}
+
+type requestDoc struct {
+	Name     string
+	Anchor   string
+	Method   string
+	Uri      string
+	Params   []field
+	Queries  []field
+	Headers  []field
+	Cookies  []field
+	Auth     *authDoc
+	Body     *exampleDoc
+	Response *responseDoc
+}
+
+type field struct {
+	Key         string
+	Value       string
+	Description string
+}
+
+type authDoc struct {
+	Type   string
+	Fields []field
+}
+
+type exampleDoc struct {
+	ContentType string
+	Language    string
+	Example     string
+	Fields      []field
+}
+
+type responseDoc struct {
+	Status  int16
+	Text    string
+	Time    int64
+	Headers []field
+	Body    *exampleDoc
+}
+
+func makePage(title string, sources []Source) page {
+	result := page{
+		Title:       title,
+		Collections: make([]collectionDoc, 0, len(sources)),
+	}
+
+	anchors := make(map[string]int)
+	for _, s := range sources {
+		coll := collectionDoc{
+			Name:     s.Collection.Name,
+			Anchor:   anchor(anchors, s.Collection.Name),
+			Requests: make([]requestDoc, 0, len(s.Requests)),
+		}
+
+		for _, r := range s.Requests {
+			request := makeRequest(r)
+			request.Anchor = anchor(anchors, fmt.Sprintf("%s %s", s.Collection.Name, r.Name))
+			if response, ok := s.Responses[r.Id]; ok {
+				request.Response = makeResponse(response)
+			}
+			coll.Requests = append(coll.Requests, request)
+		}
+
+		result.Collections = append(result.Collections, coll)
+	}
+
+	return result
+}
+
+func makeRequest(request action.Request) requestDoc {
+	result := requestDoc{
+		Name:    request.Name,
+		Method:  string(request.Method),
+		Uri:     request.Uri,
+		Params:  make([]field, 0),
+		Queries: make([]field, 0),
+		Headers: make([]field, 0),
+		Cookies: make([]field, 0),
+		Auth:    makeAuth(request.Auth),
+		Body:    makeBody(request),
+	}
+
+	params := param.Params{Params: slices.Clone(request.Param.Params)}
+	for _, v := range params.Sort().Params {
+		if v.Status {
+			result.Params = append(result.Params, field{v.Key, v.Value, v.Description})
+		}
+	}
+
+	for _, k := range sortedKeys(request.Query.Queries) {
+		for _, v := range request.Query.Queries[k] {
+			if v.Status {
+				result.Queries = append(result.Queries, field{Key: k, Value: mask(k, v.Value)})
+			}
+		}
+	}
+
+	for _, k := range sortedKeys(request.Header.Headers) {
+		for _, v := range request.Header.Headers[k] {
+			if v.Status {
+				result.Headers = append(result.Headers, field{Key: k, Value: mask(k, v.Value)})
+			}
+		}
+	}
+
+	for _, k := range sortedKeys(request.Cookie.Cookies) {
+		if v := request.Cookie.Cookies[k]; v.Status {
+			result.Cookies = append(result.Cookies, field{Key: k, Value: maskValue(v.Value)})
+		}
+	}
+
+	return result
+}
+
+// makeAuth describes the active authentication, the user name and the bearer
+// prefix are public but passwords and tokens are always masked.
+func makeAuth(auths auth.Auths) *authDoc {
+	if !auths.Status {
+		return nil
+	}
+
+	if basic, ok := auths.Auths[auth.Basic.String()]; ok && basic.Status {
+		return &authDoc{
+			Type: auth.Basic.String(),
+			Fields: []field{
+				{Key: "User", Value: basic.Parameters[auth_strategy.BASIC_PARAM_USER]},
+				{Key: "Password", Value: maskValue(basic.Parameters[auth_strategy.BASIC_PARAM_PASSWORD])},
+			},
+		}
+	}
+
+	if bearer, ok := auths.Auths[auth.Bearer.String()]; ok && bearer.Status {
+		prefix := bearer.Parameters[auth_strategy.BEARER_PARAM_PREFIX]
+		if prefix == "" {
+			prefix = auth_strategy.DEFAULT_BEARER_PREFIX
+		}
+		return &authDoc{
+			Type: auth.Bearer.String(),
+			Fields: []field{
+				{Key: "Prefix", Value: prefix},
+				{Key: "Token", Value: maskValue(bearer.Parameters[auth_strategy.BEARER_PARAM_TOKEN])},
+			},
+		}
+	}
+
+	return nil
+}
+
+func makeBody(request action.Request) *exampleDoc {
+	payload := request.Body
+	if !payload.Status || payload.Empty() {
+		return nil
+	}
+
+	result := &exampleDoc{
+		ContentType: payload.ContentType.ToHeader(),
+		Language:    language(payload.ContentType),
+	}
+
+	if payload.ContentType == domain.Form {
+		result.Fields = make([]field, 0)
+		parameters := payload.Parameters[body_strategy.FORM_DATA_PARAM]
+		for _, k := range sortedKeys(parameters) {
+			for _, v := range parameters[k] {
+				if !v.Status {
+					continue
+				}
+				value := mask(k, v.Value)
+				if v.IsFile {
+					value = fmt.Sprintf("<file %s>", v.FileName)
+				}
+				result.Fields = append(result.Fields, field{Key: k, Value: value})
+			}
+		}
+		return result
+	}
+
+	document, ok := payload.Parameters[body_strategy.DOCUMENT_PARAM][body_strategy.PAYLOAD_PARAM]
+	if !ok || len(document) == 0 {
+		return nil
+	}
+
+	if document[0].IsFile {
+		result.Example = fmt.Sprintf("<file %s>", document[0].FileName)
+		return result
+	}
+
+	result.Example = example(payload.ContentType, document[0].Value)
+	return result
+}
+
+func makeResponse(response action.Response) *responseDoc {
+	result := &responseDoc{
+		Status:  response.Status,
+		Text:    http.StatusText(int(response.Status)),
+		Time:    response.Time,
+		Headers: make([]field, 0),
+	}
+
+	for _, k := range sortedKeys(response.Headers.Headers) {
+		for _, v := range response.Headers.Headers[k] {
+			result.Headers = append(result.Headers, field{Key: k, Value: mask(k, v.Value)})
+		}
+	}
+
+	if response.Body.Payload != "" {
+		result.Body = &exampleDoc{
+			ContentType: response.Body.ContentType.ToHeader(),
+			Language:    language(response.Body.ContentType),
+			Example:     example(response.Body.ContentType, response.Body.Payload),
+		}
+	}
+
+	return result
+}
+
+// example indents the JSON documents and cuts the long payloads, the docs
+// only need a sample of the message.
+func example(contentType domain.ContentType, payload string) string {
+	if contentType == domain.Json {
+		buffer := new(bytes.Buffer)
+		if err := json.Indent(buffer, []byte(payload), "", "  "); err == nil {
+			payload = buffer.String()
+		}
+	}
+
+	if len(payload) > MAX_EXAMPLE {
+		cut := MAX_EXAMPLE
+		for cut > 0 && !isRuneStart(payload[cut]) {
+			cut--
+		}
+		payload = payload[:cut] + "\n..."
+	}
+
+	return payload
+}
+
+func language(contentType domain.ContentType) string {
+	switch contentType {
+	case domain.Json:
+		return "json"
+	case domain.Xml:
+		return "xml"
+	case domain.Html:
+		return "html"
+	default:
+		return ""
+	}
+}
+
+func mask(key, value string) string {
+	if !isSensitive(key) {
+		return value
+	}
+	return maskValue(value)
+}
+
+// maskValue hides the value unless it is a context variable, which is
+// already a reference to the secret and documents where it comes from.
+func maskValue(value string) string {
+	if value == "" || variablePattern.MatchString(value) {
+		return value
+	}
+	return SECRET_MASK
+}
+
+func isSensitive(key string) bool {
+	key = strings.ReplaceAll(strings.ToLower(key), "_", "-")
+	for _, v := range sensitiveKeys {
+		if strings.Contains(key, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// anchor returns a unique fragment identifier for the title.
+func anchor(taken map[string]int, title string) string {
+	var buffer strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			buffer.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && buffer.Len() > 0 {
+			buffer.WriteRune('-')
+			dash = true
+		}
+	}
+
+	result := strings.TrimSuffix(buffer.String(), "-")
+	if result == "" {
+		result = "section"
+	}
+
+	taken[result]++
+	if count := taken[result]; count > 1 {
+		result = fmt.Sprintf("%s-%d", result, count-1)
+	}
+
+	return result
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+func sortedKeys[T any](items map[string]T) []string {
+	keys := make([]string, 0, len(items))
+	for k := range items {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,115 @@
+package docs
+
+import (
+	"bytes"
+	"html/template"
+)
+
+var htmlTemplate = template.Must(template.New("docs").Funcs(template.FuncMap{
+	"fields": makeTable,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 0 auto; max-width: 960px; padding: 1rem 2rem; color: #222; }
+nav ul { list-style: none; padding-left: 1rem; }
+section.request { border-top: 1px solid #ddd; padding-top: .5rem; }
+.endpoint { font-family: monospace; background: #f4f4f4; padding: .4rem .6rem; display: block; overflow-x: auto; }
+.method { font-weight: bold; margin-right: .5rem; }
+table { border-collapse: collapse; margin: .5rem 0; }
+th, td { border: 1px solid #ddd; padding: .25rem .5rem; text-align: left; font-family: monospace; }
+pre { background: #f4f4f4; padding: .5rem; overflow-x: auto; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<nav>
+<ul>
+{{- range .Collections}}
+<li><a href="#{{.Anchor}}">{{.Name}}</a>
+<ul>
+{{- range .Requests}}
+<li><a href="#{{.Anchor}}">{{.Method}} {{.Name}}</a></li>
+{{- end}}
+</ul>
+</li>
+{{- end}}
+</ul>
+</nav>
+{{- range .Collections}}
+<section class="collection" id="{{.Anchor}}">
+<h2>{{.Name}}</h2>
+{{- range .Requests}}
+<section class="request" id="{{.Anchor}}">
+<h3>{{.Name}}</h3>
+<code class="endpoint"><span class="method">{{.Method}}</span>{{.Uri}}</code>
+{{- template "fields" (fields "Path parameters" .Params true)}}
+{{- template "fields" (fields "Query parameters" .Queries false)}}
+{{- template "fields" (fields "Headers" .Headers false)}}
+{{- template "fields" (fields "Cookies" .Cookies false)}}
+{{- with .Auth}}
+<h4>Authentication</h4>
+<p>Type: <code>{{.Type}}</code></p>
+{{- template "table" (fields "" .Fields false)}}
+{{- end}}
+{{- with .Body}}
+<h4>Body</h4>
+{{- template "example" .}}
+{{- end}}
+{{- with .Response}}
+<h4>Response example</h4>
+<p>Status: <code>{{.Status}} {{.Text}}</code> ({{.Time}} ms)</p>
+{{- template "fields" (fields "Response headers" .Headers false)}}
+{{- with .Body}}{{template "example" .}}{{end}}
+{{- end}}
+</section>
+{{- end}}
+</section>
+{{- end}}
+</body>
+</html>
+{{- define "fields"}}{{if .Fields}}
+<h4>{{.Title}}</h4>
+{{- template "table" .}}{{end}}{{end}}
+{{- define "table"}}
+<table>
+<tr><th>Name</th><th>Value</th>{{if .Description}}<th>Description</th>{{end}}</tr>
+{{- $description := .Description}}
+{{- range .Fields}}
+<tr><td>{{.Key}}</td><td>{{.Value}}</td>{{if $description}}<td>{{.Description}}</td>{{end}}</tr>
+{{- end}}
+</table>
+{{- end}}
+{{- define "example"}}
+<p>Content type: <code>{{.ContentType}}</code></p>
+{{- if .Fields}}{{template "table" (fields "" .Fields false)}}{{else}}
+<pre><code{{if .Language}} class="language-{{.Language}}"{{end}}>{{.Example}}</code></pre>
+{{- end}}
+{{- end -}}
+`))
+
+type table struct {
+	Title       string
+	Fields      []field
+	Description bool
+}
+
+func makeTable(title string, fields []field, description bool) table {
+	return table{
+		Title:       title,
+		Fields:      fields,
+		Description: description,
+	}
+}
+
+// MarshalHtml renders the collections as a standalone HTML page, the styles
+// are inlined so the file can be published without other assets.
+func MarshalHtml(title string, sources ...Source) ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	if err := htmlTemplate.Execute(buffer, makePage(title, sources)); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
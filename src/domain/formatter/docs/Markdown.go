@@ -0,0 +1,140 @@
+package docs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MarshalMarkdown renders the collections as a single Markdown document with
+// a table of contents and one section per request.
+func MarshalMarkdown(title string, sources ...Source) []byte {
+	page := makePage(title, sources)
+
+	var buffer strings.Builder
+	fmt.Fprintf(&buffer, "# %s\n\n", escapeMarkdown(page.Title))
+
+	for _, c := range page.Collections {
+		fmt.Fprintf(&buffer, "- [%s](#%s)\n", escapeMarkdown(c.Name), c.Anchor)
+		for _, r := range c.Requests {
+			fmt.Fprintf(&buffer, "  - [%s %s](#%s)\n", r.Method, escapeMarkdown(r.Name), r.Anchor)
+		}
+	}
+
+	for _, c := range page.Collections {
+		fmt.Fprintf(&buffer, "\n<a id=\"%s\"></a>\n\n## %s\n", c.Anchor, escapeMarkdown(c.Name))
+		for _, r := range c.Requests {
+			writeMarkdownRequest(&buffer, r)
+		}
+	}
+
+	return []byte(buffer.String())
+}
+
+func writeMarkdownRequest(buffer *strings.Builder, request requestDoc) {
+	fmt.Fprintf(buffer, "\n<a id=\"%s\"></a>\n\n### %s\n\n", request.Anchor, escapeMarkdown(request.Name))
+	fmt.Fprintf(buffer, "%s\n", code(fmt.Sprintf("%s %s", request.Method, request.Uri)))
+
+	writeMarkdownFields(buffer, "Path parameters", request.Params, true)
+	writeMarkdownFields(buffer, "Query parameters", request.Queries, false)
+	writeMarkdownFields(buffer, "Headers", request.Headers, false)
+	writeMarkdownFields(buffer, "Cookies", request.Cookies, false)
+
+	if request.Auth != nil {
+		fmt.Fprintf(buffer, "\n#### Authentication\n\nType: %s\n", code(request.Auth.Type))
+		writeMarkdownTable(buffer, request.Auth.Fields, false)
+	}
+
+	if request.Body != nil {
+		buffer.WriteString("\n#### Body\n")
+		writeMarkdownExample(buffer, request.Body)
+	}
+
+	if response := request.Response; response != nil {
+		status := strings.TrimSpace(fmt.Sprintf("%d %s", response.Status, response.Text))
+		fmt.Fprintf(buffer, "\n#### Response example\n\nStatus: %s (%d ms)\n", code(status), response.Time)
+		writeMarkdownFields(buffer, "Response headers", response.Headers, false)
+		if response.Body != nil {
+			writeMarkdownExample(buffer, response.Body)
+		}
+	}
+}
+
+func writeMarkdownFields(buffer *strings.Builder, title string, fields []field, description bool) {
+	if len(fields) == 0 {
+		return
+	}
+	fmt.Fprintf(buffer, "\n#### %s\n", title)
+	writeMarkdownTable(buffer, fields, description)
+}
+
+func writeMarkdownTable(buffer *strings.Builder, fields []field, description bool) {
+	if description {
+		buffer.WriteString("\n| Name | Value | Description |\n| --- | --- | --- |\n")
+	} else {
+		buffer.WriteString("\n| Name | Value |\n| --- | --- |\n")
+	}
+
+	for _, v := range fields {
+		fmt.Fprintf(buffer, "| %s | %s |", codeCell(v.Key), codeCell(v.Value))
+		if description {
+			fmt.Fprintf(buffer, " %s |", escapeCell(v.Description))
+		}
+		buffer.WriteString("\n")
+	}
+}
+
+func writeMarkdownExample(buffer *strings.Builder, example *exampleDoc) {
+	fmt.Fprintf(buffer, "\nContent type: %s\n", code(example.ContentType))
+
+	if example.Fields != nil {
+		writeMarkdownTable(buffer, example.Fields, false)
+		return
+	}
+
+	fence := strings.Repeat("`", max(3, longestRun(example.Example, '`')+1))
+	fmt.Fprintf(buffer, "\n%s%s\n%s\n%s\n", fence, example.Language, example.Example, fence)
+}
+
+// code writes an inline code span, the delimiter is longer than any backtick
+// sequence of the value so it can not be closed early.
+func code(value string) string {
+	fence := strings.Repeat("`", longestRun(value, '`')+1)
+	if strings.HasPrefix(value, "`") || strings.HasSuffix(value, "`") {
+		value = " " + value + " "
+	}
+	return fence + value + fence
+}
+
+func escapeMarkdown(value string) string {
+	replacer := strings.NewReplacer(
+		"\\", "\\\\", "`", "\\`", "*", "\\*", "_", "\\_", "[", "\\[", "]", "\\]", "<", "&lt;", ">", "&gt;", "#", "\\#",
+	)
+	return replacer.Replace(strings.Join(strings.Fields(value), " "))
+}
+
+func escapeCell(value string) string {
+	return strings.ReplaceAll(escapeMarkdown(value), "|", "\\|")
+}
+
+// codeCell writes the value as a code span inside a table, the pipes must
+// be escaped even there or they would split the cell.
+func codeCell(value string) string {
+	value = strings.Join(strings.Fields(value), " ")
+	if value == "" {
+		return ""
+	}
+	return strings.ReplaceAll(code(value), "|", "\\|")
+}
+
+func longestRun(value string, char rune) int {
+	longest, current := 0, 0
+	for _, r := range value {
+		if r != char {
+			current = 0
+			continue
+		}
+		current++
+		longest = max(longest, current)
+	}
+	return longest
+}
@@ -0,0 +1,287 @@
+package har
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	"github.com/Rafael24595/go-api-core/src/domain/action/body"
+	body_strategy "github.com/Rafael24595/go-api-core/src/domain/action/body/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/action/cookie"
+	"github.com/Rafael24595/go-api-core/src/domain/action/header"
+	"github.com/Rafael24595/go-api-core/src/domain/action/query"
+)
+
+func Unmarshal(data []byte) (*Har, []Exchange, error) {
+	har := &Har{}
+	if err := json.Unmarshal(data, har); err != nil {
+		return nil, nil, fmt.Errorf("the provided file is not a valid HAR document: %s", err.Error())
+	}
+
+	if har.Log.Version == "" && len(har.Log.Entries) == 0 {
+		return nil, nil, errors.New("the provided file is not a valid HAR document: log not found")
+	}
+
+	exchanges := make([]Exchange, 0, len(har.Log.Entries))
+	for i, v := range har.Log.Entries {
+		exchange, err := UnmarshalEntry(v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("entry %d: %s", i, err.Error())
+		}
+		exchanges = append(exchanges, *exchange)
+	}
+
+	return har, exchanges, nil
+}
+
+func UnmarshalEntry(entry Entry) (*Exchange, error) {
+	method, err := domain.HttpMethodFromString(entry.Request.Method)
+	if err != nil {
+		return nil, err
+	}
+
+	uri, queries := processUrl(entry.Request.Url, entry.Request.QueryString)
+
+	request := action.NewRequest(fmt.Sprintf("[HAR] %s %s", *method, uri), *method, uri)
+
+	started := parseDate(entry.StartedDateTime)
+	if started != 0 {
+		request.Timestamp = started
+		request.Modified = started
+	}
+
+	request.Query = *queries
+	request.Header = *processRequestHeaders(entry.Request)
+	request.Cookie = *processRequestCookies(entry.Request)
+	request.Body = *processPostData(entry.Request.PostData)
+
+	return &Exchange{
+		Request:  *request,
+		Response: processResponse(started, entry),
+	}, nil
+}
+
+func processUrl(rawUrl string, queryString []NameValue) (string, *query.Queries) {
+	queries := query.NewQueries()
+
+	uri, rawQuery, _ := strings.Cut(rawUrl, "?")
+	uri, _, _ = strings.Cut(uri, "#")
+
+	if len(queryString) > 0 {
+		for _, v := range queryString {
+			queries.Add(v.Name, v.Value)
+		}
+		return uri, queries
+	}
+
+	rawQuery, _, _ = strings.Cut(rawQuery, "#")
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return rawUrl, queries
+	}
+
+	for key, vals := range values {
+		for _, v := range vals {
+			queries.Add(key, v)
+		}
+	}
+
+	return uri, queries
+}
+
+func processRequestHeaders(request Request) *header.Headers {
+	headers := header.NewHeaders()
+	for _, v := range request.Headers {
+		if strings.HasPrefix(v.Name, ":") {
+			continue
+		}
+
+		if strings.EqualFold(v.Name, "Cookie") && len(request.Cookies) > 0 {
+			continue
+		}
+
+		headers.Add(http.CanonicalHeaderKey(v.Name), v.Value)
+	}
+	return headers
+}
+
+func processRequestCookies(request Request) *cookie.CookiesClient {
+	cookies := cookie.NewCookiesClient()
+	for _, v := range request.Cookies {
+		cookies.Put(v.Name, v.Value)
+	}
+
+	if len(request.Cookies) > 0 {
+		return cookies
+	}
+
+	for _, h := range request.Headers {
+		if !strings.EqualFold(h.Name, "Cookie") {
+			continue
+		}
+
+		for c := range strings.SplitSeq(h.Value, ";") {
+			key, value, ok := strings.Cut(c, "=")
+			if ok {
+				cookies.Put(strings.TrimSpace(key), strings.TrimSpace(value))
+			}
+		}
+	}
+
+	return cookies
+}
+
+func processPostData(postData *PostData) *body.BodyRequest {
+	if postData == nil || (postData.Text == "" && len(postData.Params) == 0) {
+		return body.EmptyBody(false, domain.None)
+	}
+
+	mimeType := strings.ToLower(postData.MimeType)
+
+	isForm := strings.Contains(mimeType, "multipart/form-data") ||
+		strings.Contains(mimeType, "application/x-www-form-urlencoded")
+
+	if isForm && len(postData.Params) > 0 {
+		return processPostParams(postData.Params)
+	}
+
+	if isForm && strings.Contains(mimeType, "application/x-www-form-urlencoded") {
+		if values, err := url.ParseQuery(postData.Text); err == nil {
+			payload := body.EmptyBody(true, domain.Form)
+			for key, vals := range values {
+				for _, v := range vals {
+					payload = body_strategy.AddFormData(payload, key, body.NewParameterActive(v))
+				}
+			}
+			return payload
+		}
+	}
+
+	contentType, ok := domain.ContentTypeFromHeader(mimeType)
+	if !ok || contentType == domain.Form {
+		contentType = domain.Text
+	}
+
+	return body_strategy.DocumentBody(true, contentType, postData.Text)
+}
+
+func processPostParams(params []PostParam) *body.BodyRequest {
+	payload := body.EmptyBody(true, domain.Form)
+	for _, v := range params {
+		var parameter *body.BodyParameter
+		if v.FileName != "" {
+			value := base64.StdEncoding.EncodeToString([]byte(v.Value))
+			parameter = body.NewFileParameterActive(v.ContentType, v.FileName, value)
+		} else {
+			parameter = body.NewParameterActive(v.Value)
+		}
+		payload = body_strategy.AddFormData(payload, v.Name, parameter)
+	}
+	return payload
+}
+
+func processResponse(started int64, entry Entry) *action.Response {
+	source := entry.Response
+	if source.Status <= 0 {
+		return nil
+	}
+
+	headers := header.NewHeaders()
+	for _, v := range source.Headers {
+		headers.Add(http.CanonicalHeaderKey(v.Name), v.Value)
+	}
+
+	elapsed := int64(entry.Time)
+
+	size := source.Content.Size
+	if size <= 0 {
+		size = source.BodySize
+	}
+
+	return &action.Response{
+		Timestamp: started + elapsed,
+		Date:      started,
+		Time:      elapsed,
+		Status:    int16(source.Status),
+		Headers:   *headers,
+		Cookies:   *processResponseCookies(source, headers),
+		Body:      *processContent(source.Content),
+		Size:      max(size, 0),
+	}
+}
+
+func processResponseCookies(response Response, headers *header.Headers) *cookie.CookiesServer {
+	cookies := cookie.NewCookiesServer()
+
+	for _, v := range response.Cookies {
+		var sameSite cookie.SameSite
+		if v.SameSite != "" {
+			if result, err := cookie.SameSiteFromString(v.SameSite); err == nil {
+				sameSite = *result
+			}
+		}
+
+		cookies.Cookies[v.Name] = cookie.CookieServer{
+			Status:     true,
+			Code:       v.Name,
+			Value:      v.Value,
+			Domain:     v.Domain,
+			Path:       v.Path,
+			Expiration: v.Expires,
+			Secure:     v.Secure,
+			HttpOnly:   v.HttpOnly,
+			SameSite:   sameSite,
+		}
+	}
+
+	if len(response.Cookies) > 0 {
+		return cookies
+	}
+
+	setCookie, _ := headers.Find("Set-Cookie")
+	for _, v := range setCookie {
+		parsed, err := cookie.CookieServerFromString(v.Value)
+		if err != nil {
+			continue
+		}
+		cookies.Cookies[parsed.Code] = *parsed
+	}
+
+	return cookies
+}
+
+func processContent(content Content) *body.BodyResponse {
+	contentType := domain.Text
+	if result, ok := domain.ContentTypeFromHeader(content.MimeType); ok {
+		contentType = result
+	}
+
+	payload := content.Text
+	if strings.EqualFold(content.Encoding, "base64") {
+		if decoded, err := base64.StdEncoding.DecodeString(content.Text); err == nil {
+			payload = string(decoded)
+		}
+	}
+
+	return body.NewResponseBody(contentType, payload)
+}
+
+func parseDate(date string) int64 {
+	if date == "" {
+		return 0
+	}
+
+	parsed, err := time.Parse(time.RFC3339Nano, date)
+	if err != nil {
+		return 0
+	}
+
+	return parsed.UnixMilli()
+}
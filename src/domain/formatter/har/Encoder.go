@@ -0,0 +1,298 @@
+package har
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	auth_strategy "github.com/Rafael24595/go-api-core/src/domain/action/auth/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/action/body"
+	body_strategy "github.com/Rafael24595/go-api-core/src/domain/action/body/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/action/header"
+)
+
+const HTTP_VERSION = "HTTP/1.1"
+
+func Marshal(exchanges ...Exchange) ([]byte, error) {
+	return json.MarshalIndent(MarshalHar(exchanges...), "", "  ")
+}
+
+func MarshalHar(exchanges ...Exchange) *Har {
+	entries := make([]Entry, len(exchanges))
+	for i, v := range exchanges {
+		entries[i] = MarshalEntry(v)
+	}
+
+	return &Har{
+		Log: Log{
+			Version: VERSION,
+			Creator: Creator{
+				Name:    CREATOR,
+				Version: VERSION,
+			},
+			Entries: entries,
+		},
+	}
+}
+
+func MarshalEntry(exchange Exchange) Entry {
+	request := exchange.Request
+	response := exchange.Response
+
+	started := request.Modified
+	elapsed := int64(0)
+	if response != nil {
+		started = response.Date
+		elapsed = response.Time
+	}
+
+	return Entry{
+		StartedDateTime: formatDate(started),
+		Time:            float64(elapsed),
+		Request:         requestToHar(request),
+		Response:        responseToHar(response),
+		Cache:           Cache{},
+		Timings: Timings{
+			Send:    0,
+			Wait:    float64(elapsed),
+			Receive: 0,
+		},
+	}
+}
+
+func requestToHar(request action.Request) Request {
+	uri := request.Param.Apply(request.Uri)
+	if rawQuery := request.Query.Encode(nil); rawQuery != "" {
+		separator := "?"
+		if strings.Contains(uri, "?") {
+			separator = "&"
+		}
+		uri = uri + separator + rawQuery
+	}
+
+	postData, size := bodyToHar(request.Body)
+
+	return Request{
+		Method:      request.Method.String(),
+		Url:         uri,
+		HttpVersion: HTTP_VERSION,
+		Cookies:     requestCookiesToHar(request),
+		Headers:     requestHeadersToHar(request),
+		QueryString: queriesToHar(request),
+		PostData:    postData,
+		HeadersSize: -1,
+		BodySize:    size,
+	}
+}
+
+func queriesToHar(request action.Request) []NameValue {
+	result := make([]NameValue, 0)
+	for _, k := range sortedKeys(request.Query.Queries) {
+		for _, v := range request.Query.Queries[k] {
+			if !v.Status {
+				continue
+			}
+
+			name := k
+			if v.Property != "" {
+				name = k + "[" + v.Property + "]"
+			}
+
+			result = append(result, NameValue{
+				Name:  name,
+				Value: v.Value,
+			})
+		}
+	}
+	return result
+}
+
+func requestHeadersToHar(request action.Request) []NameValue {
+	result := headersToHar(request.Header)
+
+	authorized := auth_strategy.ApplyAuth(&action.Request{
+		Header: *header.NewHeaders(),
+		Auth:   request.Auth,
+	})
+	result = append(result, headersToHar(authorized.Header)...)
+
+	cookies := make([]string, 0)
+	for _, k := range sortedKeys(request.Cookie.Cookies) {
+		if v := request.Cookie.Cookies[k]; v.Status {
+			cookies = append(cookies, k+"="+v.Value)
+		}
+	}
+
+	if len(cookies) > 0 {
+		result = append(result, NameValue{
+			Name:  "Cookie",
+			Value: strings.Join(cookies, "; "),
+		})
+	}
+
+	return result
+}
+
+func requestCookiesToHar(request action.Request) []Cookie {
+	result := make([]Cookie, 0)
+	for _, k := range sortedKeys(request.Cookie.Cookies) {
+		v := request.Cookie.Cookies[k]
+		if !v.Status {
+			continue
+		}
+		result = append(result, Cookie{
+			Name:  k,
+			Value: v.Value,
+		})
+	}
+	return result
+}
+
+func headersToHar(headers header.Headers) []NameValue {
+	result := make([]NameValue, 0)
+	for _, k := range sortedKeys(headers.Headers) {
+		for _, v := range headers.Headers[k] {
+			if !v.Status {
+				continue
+			}
+			result = append(result, NameValue{
+				Name:  k,
+				Value: v.Value,
+			})
+		}
+	}
+	return result
+}
+
+func bodyToHar(payload body.BodyRequest) (*PostData, int) {
+	if !payload.Status || payload.Empty() {
+		return nil, 0
+	}
+
+	if payload.ContentType == domain.Form {
+		return formDataToHar(payload)
+	}
+
+	document, ok := payload.Parameters[body_strategy.DOCUMENT_PARAM][body_strategy.PAYLOAD_PARAM]
+	if !ok || len(document) == 0 || document[0].IsFile {
+		return nil, 0
+	}
+
+	return &PostData{
+		MimeType: payload.ContentType.ToHeader(),
+		Text:     document[0].Value,
+	}, len(document[0].Value)
+}
+
+func formDataToHar(payload body.BodyRequest) (*PostData, int) {
+	form := payload.Parameters[body_strategy.FORM_DATA_PARAM]
+
+	params := make([]PostParam, 0)
+	for _, k := range sortedKeys(form) {
+		for _, v := range form[k] {
+			if !v.Status {
+				continue
+			}
+
+			param := PostParam{
+				Name:  k,
+				Value: v.Value,
+			}
+
+			if v.IsFile {
+				if decoded, err := base64.StdEncoding.DecodeString(v.Value); err == nil {
+					param.Value = string(decoded)
+				}
+				param.FileName = v.FileName
+				param.ContentType = v.FileType
+			}
+
+			params = append(params, param)
+		}
+	}
+
+	return &PostData{
+		MimeType: payload.ContentType.ToHeader(),
+		Params:   params,
+	}, -1
+}
+
+func responseToHar(response *action.Response) Response {
+	if response == nil {
+		return Response{
+			HttpVersion: HTTP_VERSION,
+			Cookies:     make([]Cookie, 0),
+			Headers:     make([]NameValue, 0),
+			Content: Content{
+				MimeType: "",
+			},
+			HeadersSize: -1,
+			BodySize:    -1,
+		}
+	}
+
+	mimeType := response.Body.ContentType.ToHeader()
+	if contentType, ok := response.Headers.Find("Content-Type"); ok && len(contentType) > 0 {
+		mimeType = contentType[0].Value
+	}
+
+	redirect := ""
+	if location, ok := response.Headers.Find("Location"); ok && len(location) > 0 {
+		redirect = location[0].Value
+	}
+
+	return Response{
+		Status:      int(response.Status),
+		StatusText:  http.StatusText(int(response.Status)),
+		HttpVersion: HTTP_VERSION,
+		Cookies:     responseCookiesToHar(response),
+		Headers:     headersToHar(response.Headers),
+		Content: Content{
+			Size:     response.Size,
+			MimeType: mimeType,
+			Text:     response.Body.Payload,
+		},
+		RedirectURL: redirect,
+		HeadersSize: -1,
+		BodySize:    response.Size,
+	}
+}
+
+func responseCookiesToHar(response *action.Response) []Cookie {
+	result := make([]Cookie, 0)
+	for _, k := range sortedKeys(response.Cookies.Cookies) {
+		v := response.Cookies.Cookies[k]
+		result = append(result, Cookie{
+			Name:     v.Code,
+			Value:    v.Value,
+			Path:     v.Path,
+			Domain:   v.Domain,
+			Expires:  v.Expiration,
+			HttpOnly: v.HttpOnly,
+			Secure:   v.Secure,
+			SameSite: string(v.SameSite),
+		})
+	}
+	return result
+}
+
+func formatDate(timestamp int64) string {
+	if timestamp == 0 {
+		timestamp = time.Now().UnixMilli()
+	}
+	return time.UnixMilli(timestamp).UTC().Format(time.RFC3339Nano)
+}
+
+func sortedKeys[T any](items map[string]T) []string {
+	keys := make([]string, 0, len(items))
+	for k := range items {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,521 @@
+package httpfile
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	auth_strategy "github.com/Rafael24595/go-api-core/src/domain/action/auth/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/action/body"
+	body_strategy "github.com/Rafael24595/go-api-core/src/domain/action/body/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/action/cookie"
+	"github.com/Rafael24595/go-api-core/src/domain/action/param"
+	"github.com/Rafael24595/go-api-core/src/domain/action/query"
+	"github.com/Rafael24595/go-api-core/src/domain/context"
+)
+
+const (
+	REQUEST_SEPARATOR = "###"
+	INCLUDE_PREFIX    = "<"
+)
+
+var (
+	variablePattern    = regexp.MustCompile(`\{\{\s*([^{}\s]+)\s*\}\}`)
+	declarationPattern = regexp.MustCompile(`^@([A-Za-z0-9_\-.]+)\s*=\s*(.*)$`)
+	namePattern        = regexp.MustCompile(`^(?:#|//)\s*@name\s+(.+)$`)
+)
+
+var methods = map[string]domain.HttpMethod{
+	"GET":     domain.GET,
+	"POST":    domain.POST,
+	"PUT":     domain.PUT,
+	"DELETE":  domain.DELETE,
+	"PATCH":   domain.PATCH,
+	"HEAD":    domain.HEAD,
+	"OPTIONS": domain.OPTIONS,
+	"TRACE":   domain.TRACE,
+	"CONNECT": domain.CONNECT,
+}
+
+// FileResolver loads the files included with '< ./path' in the request
+// bodies, the path is relative to the .http file.
+type FileResolver func(path string) ([]byte, error)
+
+type Import struct {
+	Context  *context.Context
+	Requests []action.Request
+}
+
+type variable struct {
+	key   string
+	value string
+}
+
+type decoder struct {
+	owner     string
+	resolver  FileResolver
+	variables []variable
+	usage     map[string][]context.ContextCategoy
+}
+
+// Unmarshal parses a VS Code REST Client / JetBrains HTTP Client file. Without
+// resolver the included files are kept as file references.
+func Unmarshal(owner string, data []byte, resolver FileResolver) (*Import, error) {
+	decoder := &decoder{
+		owner:     owner,
+		resolver:  resolver,
+		variables: make([]variable, 0),
+		usage:     make(map[string][]context.ContextCategoy),
+	}
+
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+
+	requests := make([]action.Request, 0)
+	for i, block := range splitBlocks(text) {
+		request, err := decoder.makeRequest(block)
+		if err != nil {
+			return nil, fmt.Errorf("request %d: %w", i+1, err)
+		}
+		if request != nil {
+			requests = append(requests, *request)
+		}
+	}
+
+	if len(requests) == 0 {
+		return nil, errors.New("the provided file does not contain any request")
+	}
+
+	return &Import{
+		Context:  decoder.makeContext(),
+		Requests: requests,
+	}, nil
+}
+
+type block struct {
+	name  string
+	lines []string
+}
+
+func splitBlocks(text string) []block {
+	blocks := make([]block, 0)
+	current := block{lines: make([]string, 0)}
+
+	for line := range strings.SplitSeq(text, "\n") {
+		if name, ok := strings.CutPrefix(strings.TrimSpace(line), REQUEST_SEPARATOR); ok {
+			blocks = append(blocks, current)
+			current = block{
+				name:  strings.TrimSpace(strings.TrimLeft(name, "#")),
+				lines: make([]string, 0),
+			}
+			continue
+		}
+		current.lines = append(current.lines, line)
+	}
+
+	return append(blocks, current)
+}
+
+func (d *decoder) makeRequest(source block) (*action.Request, error) {
+	name := source.name
+	lines := source.lines
+
+	cursor := 0
+	for ; cursor < len(lines); cursor++ {
+		line := strings.TrimSpace(lines[cursor])
+		if match := namePattern.FindStringSubmatch(line); match != nil {
+			name = strings.TrimSpace(match[1])
+			continue
+		}
+		if match := declarationPattern.FindStringSubmatch(line); match != nil {
+			d.variables = append(d.variables, variable{
+				key:   match[1],
+				value: strings.TrimSpace(match[2]),
+			})
+			continue
+		}
+		if line == "" || isComment(line) {
+			continue
+		}
+		break
+	}
+
+	if cursor == len(lines) {
+		return nil, nil
+	}
+
+	method, target, err := requestLine(lines[cursor])
+	if err != nil {
+		return nil, err
+	}
+
+	for cursor++; cursor < len(lines); cursor++ {
+		line := strings.TrimSpace(lines[cursor])
+		if !strings.HasPrefix(line, "?") && !strings.HasPrefix(line, "&") {
+			break
+		}
+		target += line
+	}
+
+	uri, queries := d.makeUrl(target)
+	if name == "" {
+		name = fmt.Sprintf("%s %s", method, uri)
+	}
+
+	request := action.NewRequest(name, method, uri)
+	request.Owner = d.owner
+	request.Query = *queries
+	request.Param = *d.makeParams(uri)
+
+	for ; cursor < len(lines); cursor++ {
+		line := strings.TrimSpace(lines[cursor])
+		if line == "" {
+			cursor++
+			break
+		}
+		if isComment(line) {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid header line '%s'", line)
+		}
+
+		d.addHeader(request, strings.TrimSpace(key), strings.TrimSpace(value))
+	}
+
+	payload, err := d.makeBody(request, trimBody(lines[min(cursor, len(lines)):]))
+	if err != nil {
+		return nil, err
+	}
+
+	request.Body = *payload
+
+	return request, nil
+}
+
+func requestLine(line string) (domain.HttpMethod, string, error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return "", "", errors.New("the request line is empty")
+	}
+
+	method := domain.GET
+	if result, ok := methods[strings.ToUpper(fields[0])]; ok {
+		method = result
+		fields = fields[1:]
+	}
+
+	if len(fields) > 1 && strings.HasPrefix(strings.ToUpper(fields[len(fields)-1]), "HTTP/") {
+		fields = fields[:len(fields)-1]
+	}
+
+	if len(fields) == 0 {
+		return "", "", fmt.Errorf("the request line '%s' does not define the URL", line)
+	}
+
+	return method, strings.Join(fields, " "), nil
+}
+
+func (d *decoder) makeUrl(target string) (string, *query.Queries) {
+	queries := query.NewQueries()
+
+	uri, rawQuery, _ := strings.Cut(target, "?")
+	uri, _, _ = strings.Cut(uri, "#")
+
+	for fragment := range strings.SplitSeq(rawQuery, "&") {
+		if fragment == "" {
+			continue
+		}
+
+		key, value, _ := strings.Cut(fragment, "=")
+		if unescaped, err := url.QueryUnescape(key); err == nil {
+			key = unescaped
+		}
+		if unescaped, err := url.QueryUnescape(value); err == nil {
+			value = unescaped
+		}
+
+		queries.Add(d.translate(context.QUERY, key), d.translate(context.QUERY, value))
+	}
+
+	return d.translate(context.URI, uri), queries
+}
+
+func (d *decoder) makeParams(uri string) *param.Params {
+	params := param.NewParams()
+	for _, k := range param.FindKeys(uri) {
+		params.AddStatus(k, "", false)
+	}
+	return params
+}
+
+func (d *decoder) addHeader(request *action.Request, key, value string) {
+	if strings.EqualFold(key, "Cookie") {
+		for fragment := range strings.SplitSeq(value, ";") {
+			name, content, ok := strings.Cut(strings.TrimSpace(fragment), "=")
+			if !ok {
+				continue
+			}
+			order := int64(len(request.Cookie.Cookies))
+			request.Cookie.Cookies[d.translate(context.COOKIE, name)] = cookie.NewCookieClient(order, true, d.translate(context.COOKIE, content))
+		}
+		return
+	}
+
+	if strings.EqualFold(key, "Authorization") && d.makeAuth(request, value) {
+		return
+	}
+
+	request.Header.Add(d.translate(context.HEADER, key), d.translate(context.HEADER, value))
+}
+
+// makeAuth maps the authorization schemes written in plain text, Basic with
+// "user:password" or "user password" and Bearer. Encoded credentials are kept
+// as headers.
+func (d *decoder) makeAuth(request *action.Request, value string) bool {
+	scheme, credentials, _ := strings.Cut(strings.TrimSpace(value), " ")
+	credentials = strings.TrimSpace(credentials)
+
+	switch strings.ToLower(scheme) {
+	case "basic":
+		user, pass, ok := strings.Cut(credentials, ":")
+		if !ok {
+			user, pass, ok = strings.Cut(credentials, " ")
+		}
+		if !ok {
+			return false
+		}
+		user = d.translate(context.AUTH, strings.TrimSpace(user))
+		pass = d.translate(context.AUTH, strings.TrimSpace(pass))
+		request.Auth.PutAuth(*auth_strategy.BasicAuth(true, user, pass))
+	case "bearer":
+		token := d.translate(context.AUTH, credentials)
+		request.Auth.PutAuth(*auth_strategy.BearerAuth(true, auth_strategy.DEFAULT_BEARER_PREFIX, token))
+	default:
+		return false
+	}
+
+	request.Auth.Status = true
+	return true
+}
+
+func (d *decoder) makeBody(request *action.Request, lines []string) (*body.BodyRequest, error) {
+	if len(lines) == 0 {
+		return body.EmptyBody(false, domain.None), nil
+	}
+
+	contentType := findHeader(request, "Content-Type")
+	if mediaType, params, err := mime.ParseMediaType(contentType); err == nil && mediaType == "multipart/form-data" && params["boundary"] != "" {
+		return d.makeForm(lines, params["boundary"])
+	}
+
+	typ := domain.Text
+	if result, ok := domain.ContentTypeFromHeader(contentType); ok && result != domain.Form {
+		typ = result
+	}
+
+	if len(lines) == 1 {
+		if path, ok := includePath(lines[0]); ok {
+			return d.makeInclude(typ, path)
+		}
+	}
+
+	content := d.translate(context.PAYLOAD, strings.Join(lines, "\n"))
+	return body_strategy.DocumentBody(true, typ, content), nil
+}
+
+func (d *decoder) makeInclude(typ domain.ContentType, path string) (*body.BodyRequest, error) {
+	if d.resolver == nil {
+		payload := body_strategy.DocumentBody(true, typ, "")
+		document := payload.Parameters[body_strategy.DOCUMENT_PARAM][body_strategy.PAYLOAD_PARAM]
+		document[0] = *body.NewFileParameter(0, true, fileType(path), path, "")
+		return payload, nil
+	}
+
+	content, err := d.resolver(path)
+	if err != nil {
+		return nil, fmt.Errorf("the included file '%s' cannot be read: %w", path, err)
+	}
+
+	return body_strategy.DocumentBody(true, typ, d.translate(context.PAYLOAD, string(content))), nil
+}
+
+// makeForm parses the multipart body written by hand in the file, every part
+// needs a Content-Disposition header with the field name.
+func (d *decoder) makeForm(lines []string, boundary string) (*body.BodyRequest, error) {
+	payload := body.EmptyBody(true, domain.Form)
+
+	delimiter := "--" + boundary
+	parts := make([][]string, 0)
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == delimiter+"--" {
+			break
+		}
+		if trimmed == delimiter {
+			parts = append(parts, make([]string, 0))
+			continue
+		}
+		if len(parts) > 0 {
+			parts[len(parts)-1] = append(parts[len(parts)-1], line)
+		}
+	}
+
+	for _, part := range parts {
+		cursor := 0
+		disposition := ""
+		for ; cursor < len(part); cursor++ {
+			line := strings.TrimSpace(part[cursor])
+			if line == "" {
+				cursor++
+				break
+			}
+			if key, value, ok := strings.Cut(line, ":"); ok && strings.EqualFold(strings.TrimSpace(key), "Content-Disposition") {
+				disposition = strings.TrimSpace(value)
+			}
+		}
+
+		_, params, err := mime.ParseMediaType(disposition)
+		if err != nil || params["name"] == "" {
+			return nil, errors.New("every multipart section must define a Content-Disposition name")
+		}
+
+		key := d.translate(context.PAYLOAD, params["name"])
+		content := trimBody(part[min(cursor, len(part)):])
+
+		parameter, err := d.makeFormParameter(content, params["filename"])
+		if err != nil {
+			return nil, err
+		}
+
+		payload = body_strategy.AddFormData(payload, key, parameter)
+	}
+
+	return payload, nil
+}
+
+func (d *decoder) makeFormParameter(lines []string, filename string) (*body.BodyParameter, error) {
+	path, ok := "", false
+	if len(lines) == 1 {
+		path, ok = includePath(lines[0])
+	}
+
+	if !ok {
+		value := d.translate(context.PAYLOAD, strings.Join(lines, "\n"))
+		if filename == "" {
+			return body.NewParameterActive(value), nil
+		}
+		encoded := base64.StdEncoding.EncodeToString([]byte(value))
+		return body.NewFileParameterActive(fileType(filename), filename, encoded), nil
+	}
+
+	if d.resolver == nil {
+		return body.NewFileParameterActive(fileType(path), path, ""), nil
+	}
+
+	content, err := d.resolver(path)
+	if err != nil {
+		return nil, fmt.Errorf("the included file '%s' cannot be read: %w", path, err)
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(content)
+	return body.NewFileParameterActive(fileType(path), path, encoded), nil
+}
+
+func (d *decoder) makeContext() *context.Context {
+	ctx := context.NewContext(d.owner)
+	for _, v := range d.variables {
+		categories, ok := d.usage[v.key]
+		if !ok {
+			categories = []context.ContextCategoy{context.URI}
+		}
+
+		value := d.translate("", v.value)
+		for _, c := range categories {
+			ctx.Put(c, v.key, value, false)
+		}
+	}
+	return ctx
+}
+
+// translate replaces the file variables with the context ones and records the
+// category where the variable is used. System variables like {{$guid}} and
+// request variables like {{login.response.body.token}} are kept as is.
+func (d *decoder) translate(category context.ContextCategoy, source string) string {
+	return variablePattern.ReplaceAllStringFunc(source, func(match string) string {
+		name := variablePattern.FindStringSubmatch(match)[1]
+		if strings.HasPrefix(name, "$") || strings.Contains(name, ".") {
+			return match
+		}
+
+		if category != "" {
+			d.use(name, category)
+		}
+
+		return fmt.Sprintf("${%s}", name)
+	})
+}
+
+func (d *decoder) use(name string, category context.ContextCategoy) {
+	for _, v := range d.usage[name] {
+		if v == category {
+			return
+		}
+	}
+	d.usage[name] = append(d.usage[name], category)
+}
+
+func findHeader(request *action.Request, key string) string {
+	for k, hs := range request.Header.Headers {
+		if strings.EqualFold(k, key) && len(hs) > 0 {
+			return hs[0].Value
+		}
+	}
+	return ""
+}
+
+func includePath(line string) (string, bool) {
+	path, ok := strings.CutPrefix(strings.TrimSpace(line), INCLUDE_PREFIX)
+	if !ok {
+		return "", false
+	}
+	// '<@' processes the variables of the file and '<@encoding' sets its
+	// encoding, both are read as plain includes.
+	if rest, ok := strings.CutPrefix(path, "@"); ok {
+		_, path, _ = strings.Cut(rest, " ")
+		if !strings.Contains(rest, " ") {
+			path = rest
+		}
+	}
+	path = strings.TrimSpace(path)
+	return path, path != ""
+}
+
+func isComment(line string) bool {
+	return strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//")
+}
+
+// trimBody removes the blank lines around the body, the requests are usually
+// separated by empty lines before the next separator.
+func trimBody(lines []string) []string {
+	start, end := 0, len(lines)
+	for start < end && strings.TrimSpace(lines[start]) == "" {
+		start++
+	}
+	for end > start && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+	return lines[start:end]
+}
+
+func fileType(path string) string {
+	return strings.TrimPrefix(filepath.Ext(path), ".")
+}
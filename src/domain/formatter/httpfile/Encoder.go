@@ -0,0 +1,321 @@
+package httpfile
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	"github.com/Rafael24595/go-api-core/src/domain/action/auth"
+	auth_strategy "github.com/Rafael24595/go-api-core/src/domain/action/auth/strategy"
+	body_strategy "github.com/Rafael24595/go-api-core/src/domain/action/body/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/action/param"
+	"github.com/Rafael24595/go-api-core/src/domain/context"
+)
+
+const FORM_BOUNDARY = "FormBoundary"
+
+var contextPattern = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// Marshal writes the context as file variables and every request as a block
+// of the .http file. Private variables are written without value so the file
+// can be committed next to the code.
+func Marshal(ctx *context.Context, requests ...action.Request) []byte {
+	var buffer strings.Builder
+
+	variables := contextToVariables(ctx)
+	for _, v := range variables {
+		fmt.Fprintf(&buffer, "@%s = %s\n", v.key, v.value)
+	}
+
+	for i, v := range requests {
+		if i > 0 || len(variables) > 0 {
+			buffer.WriteString("\n")
+		}
+		buffer.WriteString(MarshalRequest(v))
+	}
+
+	return []byte(buffer.String())
+}
+
+func MarshalRequest(request action.Request) string {
+	var buffer strings.Builder
+
+	fmt.Fprintf(&buffer, "%s %s\n", REQUEST_SEPARATOR, request.Name)
+	fmt.Fprintf(&buffer, "%s %s\n", request.Method, uriToLine(request))
+
+	separator := "?"
+	for _, k := range sortedKeys(request.Query.Queries) {
+		for _, v := range request.Query.Queries[k] {
+			if !v.Status {
+				continue
+			}
+			fmt.Fprintf(&buffer, "    %s%s=%s\n", separator, escapeQuery(k), escapeQuery(v.Value))
+			separator = "&"
+		}
+	}
+
+	for _, v := range headersToLines(request) {
+		buffer.WriteString(v + "\n")
+	}
+
+	if payload := bodyToLines(request); len(payload) > 0 {
+		buffer.WriteString("\n")
+		for _, v := range payload {
+			buffer.WriteString(v + "\n")
+		}
+	}
+
+	return buffer.String()
+}
+
+// uriToLine replaces the path parameters with their values, parameters
+// without value are written as file variables.
+func uriToLine(request action.Request) string {
+	var buffer strings.Builder
+	for _, s := range param.FindSegments(request.Uri) {
+		if s.Key == "" {
+			buffer.WriteString(untranslate(s.Raw))
+			continue
+		}
+
+		if v, ok := request.Param.Find(s.Key); ok && v.Status && v.Value != "" {
+			buffer.WriteString(untranslate(v.Value))
+			continue
+		}
+
+		buffer.WriteString("{{" + s.Key + "}}")
+	}
+	return buffer.String()
+}
+
+func headersToLines(request action.Request) []string {
+	lines := make([]string, 0)
+
+	for _, k := range sortedKeys(request.Header.Headers) {
+		if request.Body.ContentType == domain.Form && strings.EqualFold(k, "Content-Type") {
+			continue
+		}
+		for _, v := range request.Header.Headers[k] {
+			if v.Status {
+				lines = append(lines, fmt.Sprintf("%s: %s", untranslate(k), untranslate(v.Value)))
+			}
+		}
+	}
+
+	if header, ok := authToHeader(request.Auth); ok {
+		lines = append(lines, fmt.Sprintf("Authorization: %s", header))
+	}
+
+	cookies := make([]string, 0)
+	for _, k := range sortedKeys(request.Cookie.Cookies) {
+		if v := request.Cookie.Cookies[k]; v.Status {
+			cookies = append(cookies, untranslate(k)+"="+untranslate(v.Value))
+		}
+	}
+
+	if len(cookies) > 0 {
+		lines = append(lines, fmt.Sprintf("Cookie: %s", strings.Join(cookies, "; ")))
+	}
+
+	if request.Body.Status && request.Body.ContentType == domain.Form {
+		lines = append(lines, fmt.Sprintf("Content-Type: multipart/form-data; boundary=%s", FORM_BOUNDARY))
+	} else if request.Body.Status && !request.Body.Empty() && !hasHeader(request, "Content-Type") {
+		lines = append(lines, fmt.Sprintf("Content-Type: %s", request.Body.ContentType.ToHeader()))
+	}
+
+	return lines
+}
+
+func authToHeader(auths auth.Auths) (string, bool) {
+	if !auths.Status {
+		return "", false
+	}
+
+	if basic, ok := auths.Auths[auth.Basic.String()]; ok && basic.Status {
+		user := untranslate(basic.Parameters[auth_strategy.BASIC_PARAM_USER])
+		pass := untranslate(basic.Parameters[auth_strategy.BASIC_PARAM_PASSWORD])
+		return fmt.Sprintf("Basic %s:%s", user, pass), true
+	}
+
+	if bearer, ok := auths.Auths[auth.Bearer.String()]; ok && bearer.Status {
+		return fmt.Sprintf("Bearer %s", untranslate(bearer.Parameters[auth_strategy.BEARER_PARAM_TOKEN])), true
+	}
+
+	return "", false
+}
+
+func bodyToLines(request action.Request) []string {
+	payload := request.Body
+	if !payload.Status || payload.Empty() {
+		return nil
+	}
+
+	if payload.ContentType == domain.Form {
+		return formToLines(request)
+	}
+
+	document, ok := payload.Parameters[body_strategy.DOCUMENT_PARAM][body_strategy.PAYLOAD_PARAM]
+	if !ok || len(document) == 0 {
+		return nil
+	}
+
+	if document[0].IsFile {
+		return []string{fmt.Sprintf("%s %s", INCLUDE_PREFIX, document[0].FileName)}
+	}
+
+	return []string{untranslate(document[0].Value)}
+}
+
+// formToLines writes the form as a multipart body, the files are written as
+// includes of their original name since the content is not text.
+func formToLines(request action.Request) []string {
+	lines := make([]string, 0)
+	delimiter := "--" + FORM_BOUNDARY
+
+	parameters := request.Body.Parameters[body_strategy.FORM_DATA_PARAM]
+	for _, k := range sortedKeys(parameters) {
+		for _, v := range parameters[k] {
+			if !v.Status {
+				continue
+			}
+
+			lines = append(lines, delimiter)
+			if v.IsFile {
+				lines = append(lines,
+					fmt.Sprintf("Content-Disposition: form-data; name=\"%s\"; filename=\"%s\"", untranslate(k), fileName(v.FileName)),
+					"",
+					fmt.Sprintf("%s %s", INCLUDE_PREFIX, v.FileName),
+				)
+				continue
+			}
+
+			lines = append(lines,
+				fmt.Sprintf("Content-Disposition: form-data; name=\"%s\"", untranslate(k)),
+				"",
+				untranslate(v.Value),
+			)
+		}
+	}
+
+	return append(lines, delimiter+"--")
+}
+
+func contextToVariables(ctx *context.Context) []variable {
+	variables := make([]variable, 0)
+	if ctx == nil {
+		return variables
+	}
+
+	categories := make(map[string]context.DictionaryVariables)
+	for _, p := range ctx.Dictionary.Pairs() {
+		categories[p.Key()] = p.Value()
+	}
+
+	cache := make(map[string]bool)
+	for _, c := range sortedCategories(categories) {
+		values := categories[c]
+		items := values.Pairs()
+		sort.SliceStable(items, func(i, j int) bool {
+			return items[i].Value().Order < items[j].Value().Order
+		})
+
+		for _, v := range items {
+			if cache[v.Key()] || !v.Value().Status {
+				continue
+			}
+			cache[v.Key()] = true
+
+			value := untranslate(v.Value().Value)
+			if v.Value().Private {
+				value = ""
+			}
+
+			variables = append(variables, variable{
+				key:   v.Key(),
+				value: value,
+			})
+		}
+	}
+
+	return variables
+}
+
+func sortedCategories(categories map[string]context.DictionaryVariables) []string {
+	known := []context.ContextCategoy{
+		context.URI, context.QUERY, context.HEADER, context.COOKIE, context.PAYLOAD, context.AUTH,
+	}
+
+	result := make([]string, 0, len(categories))
+	cache := make(map[string]bool)
+	for _, v := range known {
+		if _, ok := categories[v.String()]; ok {
+			result = append(result, v.String())
+			cache[v.String()] = true
+		}
+	}
+
+	for _, k := range sortedKeys(categories) {
+		if !cache[k] {
+			result = append(result, k)
+		}
+	}
+
+	return result
+}
+
+// untranslate replaces the context variables with the file ones, the category
+// of qualified variables is discarded.
+func untranslate(source string) string {
+	return contextPattern.ReplaceAllStringFunc(source, func(match string) string {
+		name := contextPattern.FindStringSubmatch(match)[1]
+		if _, key, ok := strings.Cut(name, "."); ok {
+			name = key
+		}
+		return "{{" + name + "}}"
+	})
+}
+
+// escapeQuery encodes the query fragment keeping the variables readable.
+func escapeQuery(source string) string {
+	source = untranslate(source)
+
+	var buffer strings.Builder
+	cursor := 0
+	for _, match := range variablePattern.FindAllStringIndex(source, -1) {
+		buffer.WriteString(url.QueryEscape(source[cursor:match[0]]))
+		buffer.WriteString(source[match[0]:match[1]])
+		cursor = match[1]
+	}
+	buffer.WriteString(url.QueryEscape(source[cursor:]))
+
+	return buffer.String()
+}
+
+func hasHeader(request action.Request, key string) bool {
+	for k := range request.Header.Headers {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func fileName(path string) string {
+	if index := strings.LastIndexAny(path, "/\\"); index != -1 {
+		return path[index+1:]
+	}
+	return path
+}
+
+func sortedKeys[T any](items map[string]T) []string {
+	keys := make([]string, 0, len(items))
+	for k := range items {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
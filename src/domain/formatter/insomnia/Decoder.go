@@ -0,0 +1,477 @@
+package insomnia
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	auth_strategy "github.com/Rafael24595/go-api-core/src/domain/action/auth/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/action/body"
+	body_strategy "github.com/Rafael24595/go-api-core/src/domain/action/body/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/action/param"
+	"github.com/Rafael24595/go-api-core/src/domain/action/query"
+	"github.com/Rafael24595/go-api-core/src/domain/context"
+)
+
+var (
+	variablePattern = regexp.MustCompile(`\{\{\s*([^{}]+?)\s*\}\}`)
+	bracketPattern  = regexp.MustCompile(`^_\[\s*['"]([^'"]+)['"]\s*\]$`)
+	namePattern     = regexp.MustCompile(`^[A-Za-z0-9_\-.]+$`)
+	tagPattern      = regexp.MustCompile(`\{%.*?%\}`)
+)
+
+type decoder struct {
+	owner    string
+	children map[string][]Resource
+	visited  map[string]bool
+	usage    map[string][]context.ContextCategoy
+	report   *Report
+}
+
+func Unmarshal(owner string, data []byte) (*Import, error) {
+	export := &Export{}
+	if err := json.Unmarshal(data, export); err != nil {
+		return nil, fmt.Errorf("the provided file is not a valid Insomnia export: %s", err.Error())
+	}
+
+	if export.Type != "export" {
+		return nil, fmt.Errorf("the provided file is not a valid Insomnia export: unknown type '%s'", export.Type)
+	}
+
+	if export.ExportFormat != EXPORT_FORMAT {
+		return nil, fmt.Errorf("unsupported Insomnia export format '%d'; it must be %d", export.ExportFormat, EXPORT_FORMAT)
+	}
+
+	return UnmarshalExport(owner, export), nil
+}
+
+func UnmarshalExport(owner string, export *Export) *Import {
+	decoder := &decoder{
+		owner:    owner,
+		children: make(map[string][]Resource),
+		visited:  make(map[string]bool),
+		report:   &Report{Items: make([]ReportItem, 0)},
+	}
+
+	workspaces := make([]Resource, 0)
+	for _, v := range export.Resources {
+		if v.Type == TYPE_WORKSPACE {
+			workspaces = append(workspaces, v)
+			continue
+		}
+		decoder.children[v.ParentId] = append(decoder.children[v.ParentId], v)
+	}
+
+	for k := range decoder.children {
+		sort.SliceStable(decoder.children[k], func(i, j int) bool {
+			return decoder.children[k][i].MetaSortKey < decoder.children[k][j].MetaSortKey
+		})
+	}
+
+	result := make([]Workspace, 0, len(workspaces))
+	for _, v := range workspaces {
+		result = append(result, decoder.makeWorkspace(v))
+	}
+
+	for _, v := range export.Resources {
+		if decoder.visited[v.Id] {
+			continue
+		}
+
+		switch v.Type {
+		case TYPE_ENVIRONMENT, TYPE_REQUEST_GROUP, TYPE_REQUEST:
+			decoder.report.Add(v, "the resource does not belong to any workspace")
+		default:
+			decoder.report.Add(v, fmt.Sprintf("unsupported resource type '%s'", v.Type))
+		}
+	}
+
+	return &Import{
+		Workspaces: result,
+		Report:     *decoder.report,
+	}
+}
+
+func (d *decoder) makeWorkspace(workspace Resource) Workspace {
+	d.visited[workspace.Id] = true
+
+	environment := make(map[string]any)
+	root := make([]Resource, 0)
+	groups := make([]Resource, 0)
+
+	for _, v := range d.children[workspace.Id] {
+		switch v.Type {
+		case TYPE_ENVIRONMENT:
+			d.visited[v.Id] = true
+			mergeData(environment, v.Data)
+			for _, s := range d.children[v.Id] {
+				d.visited[s.Id] = true
+				d.report.Add(s, "only the base environment is imported")
+			}
+		case TYPE_REQUEST:
+			root = append(root, v)
+		case TYPE_REQUEST_GROUP:
+			groups = append(groups, v)
+		}
+	}
+
+	collections := make([]Collection, 0)
+	if len(root) > 0 {
+		collections = append(collections, d.makeCollection(workspace.Name, environment, root))
+	}
+
+	for _, v := range groups {
+		d.visited[v.Id] = true
+		data := copyData(environment)
+		mergeData(data, v.Environment)
+		collections = append(collections, d.makeCollection(v.Name, data, d.children[v.Id]))
+	}
+
+	return Workspace{
+		Name:        workspace.Name,
+		Collections: collections,
+	}
+}
+
+func (d *decoder) makeCollection(name string, environment map[string]any, resources []Resource) Collection {
+	d.usage = make(map[string][]context.ContextCategoy)
+
+	requests := d.makeRequests("", resources)
+
+	return Collection{
+		Name:     name,
+		Context:  d.makeContext(environment),
+		Requests: requests,
+	}
+}
+
+func (d *decoder) makeRequests(prefix string, resources []Resource) []action.Request {
+	requests := make([]action.Request, 0)
+	for _, v := range resources {
+		switch v.Type {
+		case TYPE_REQUEST:
+			d.visited[v.Id] = true
+			request := d.makeRequest(v)
+			if prefix != "" {
+				request.Name = fmt.Sprintf("%s / %s", prefix, request.Name)
+			}
+			requests = append(requests, *request)
+		case TYPE_REQUEST_GROUP:
+			d.visited[v.Id] = true
+			if len(v.Environment) > 0 {
+				d.report.Add(v, "the environment of nested folders is not imported")
+			}
+
+			name := v.Name
+			if prefix != "" {
+				name = fmt.Sprintf("%s / %s", prefix, v.Name)
+			}
+
+			requests = append(requests, d.makeRequests(name, d.children[v.Id])...)
+		}
+	}
+	return requests
+}
+
+func (d *decoder) makeRequest(resource Resource) *action.Request {
+	method := domain.GET
+	if result, err := domain.HttpMethodFromString(resource.Method); err == nil {
+		method = *result
+	} else if resource.Method != "" {
+		d.report.Add(resource, fmt.Sprintf("unsupported method '%s', GET is used instead", resource.Method))
+	}
+
+	uri, queries := d.makeUrl(resource)
+
+	request := action.NewRequest(resource.Name, method, uri)
+	request.Owner = d.owner
+	request.Query = *queries
+	request.Param = *d.makeParams(resource, uri)
+
+	for _, v := range resource.Headers {
+		key := d.translate(resource, context.HEADER, v.Name)
+		request.Header.AddStatus(key, d.translate(resource, context.HEADER, v.Value), !v.Disabled)
+	}
+
+	request.Body = *d.makeBody(resource)
+
+	if resource.Authentication != nil {
+		request = d.makeAuth(resource, request)
+	}
+
+	return request
+}
+
+func (d *decoder) makeUrl(resource Resource) (string, *query.Queries) {
+	queries := query.NewQueries()
+
+	uri, rawQuery, _ := strings.Cut(resource.Url, "?")
+	uri, _, _ = strings.Cut(uri, "#")
+	rawQuery, _, _ = strings.Cut(rawQuery, "#")
+
+	uri = d.translate(resource, context.URI, uri)
+
+	for fragment := range strings.SplitSeq(rawQuery, "&") {
+		if fragment == "" {
+			continue
+		}
+
+		key, value, _ := strings.Cut(fragment, "=")
+		if unescaped, err := url.QueryUnescape(key); err == nil {
+			key = unescaped
+		}
+		if unescaped, err := url.QueryUnescape(value); err == nil {
+			value = unescaped
+		}
+
+		queries.Add(d.translate(resource, context.QUERY, key), d.translate(resource, context.QUERY, value))
+	}
+
+	for _, v := range resource.Parameters {
+		key := d.translate(resource, context.QUERY, v.Name)
+		queries.AddStatus(key, d.translate(resource, context.QUERY, v.Value), !v.Disabled)
+	}
+
+	return uri, queries
+}
+
+func (d *decoder) makeParams(resource Resource, uri string) *param.Params {
+	params := param.NewParams()
+	for _, v := range resource.PathParameters {
+		value := d.translate(resource, context.URI, v.Value)
+		order := int64(len(params.Params))
+		params.AddParam(param.NewParam(order, !v.Disabled, v.Name, value, v.Description))
+	}
+
+	for _, k := range param.FindKeys(uri) {
+		if !params.Exists(k) {
+			params.AddStatus(k, "", false)
+		}
+	}
+
+	return params
+}
+
+func (d *decoder) makeBody(resource Resource) *body.BodyRequest {
+	source := resource.Body
+	mimeType := strings.ToLower(source.MimeType)
+
+	switch {
+	case source.MimeType == "" && source.Text == "":
+		return body.EmptyBody(false, domain.None)
+	case strings.Contains(mimeType, "multipart/form-data"),
+		strings.Contains(mimeType, "application/x-www-form-urlencoded"):
+		return d.makeForm(resource)
+	case strings.Contains(mimeType, "application/graphql"):
+		content := d.translate(resource, context.PAYLOAD, source.Text)
+		return body_strategy.DocumentBody(true, domain.Json, content)
+	case source.FileName != "" || strings.Contains(mimeType, "application/octet-stream"):
+		d.report.Add(resource, "binary file bodies are not supported")
+		return body.EmptyBody(false, domain.None)
+	}
+
+	contentType, ok := domain.ContentTypeFromHeader(mimeType)
+	if !ok || contentType == domain.Form {
+		contentType = domain.Text
+	}
+
+	content := d.translate(resource, context.PAYLOAD, source.Text)
+	return body_strategy.DocumentBody(true, contentType, content)
+}
+
+func (d *decoder) makeForm(resource Resource) *body.BodyRequest {
+	payload := body.EmptyBody(true, domain.Form)
+	for _, v := range resource.Body.Params {
+		key := d.translate(resource, context.PAYLOAD, v.Name)
+
+		var parameter *body.BodyParameter
+		if v.Type == "file" {
+			ext := strings.TrimPrefix(filepath.Ext(v.FileName), ".")
+			parameter = body.NewFileParameter(0, !v.Disabled, ext, v.FileName, "")
+			d.report.Add(resource, fmt.Sprintf("the content of the file '%s' is not included", v.FileName))
+		} else {
+			parameter = body.NewParameter(0, !v.Disabled, d.translate(resource, context.PAYLOAD, v.Value))
+		}
+
+		payload = body_strategy.AddFormData(payload, key, parameter)
+	}
+	return payload
+}
+
+func (d *decoder) makeAuth(resource Resource, request *action.Request) *action.Request {
+	source := resource.Authentication
+	status := !source.Disabled
+
+	switch source.Type {
+	case "", "none":
+	case "basic":
+		user := d.translate(resource, context.AUTH, source.Username)
+		pass := d.translate(resource, context.AUTH, source.Password)
+		request.Auth.PutAuth(*auth_strategy.BasicAuth(status, user, pass))
+		request.Auth.Status = status
+	case "bearer":
+		prefix := source.Prefix
+		if prefix == "" {
+			prefix = auth_strategy.DEFAULT_BEARER_PREFIX
+		}
+		token := d.translate(resource, context.AUTH, source.Token)
+		request.Auth.PutAuth(*auth_strategy.BearerAuth(status, prefix, token))
+		request.Auth.Status = status
+	case "apikey":
+		switch source.AddTo {
+		case "queryParams":
+			key := d.translate(resource, context.QUERY, source.Key)
+			request.Query.AddStatus(key, d.translate(resource, context.QUERY, source.Value), status)
+		case "cookie":
+			key := d.translate(resource, context.COOKIE, source.Key)
+			request.Cookie.PutStatus(key, d.translate(resource, context.COOKIE, source.Value), status)
+		default:
+			key := d.translate(resource, context.HEADER, source.Key)
+			request.Header.AddStatus(key, d.translate(resource, context.HEADER, source.Value), status)
+		}
+	default:
+		d.report.Add(resource, fmt.Sprintf("unsupported authentication type '%s'", source.Type))
+	}
+
+	return request
+}
+
+func (d *decoder) makeContext(environment map[string]any) *context.Context {
+	flat := make(map[string]string)
+	flattenData("", environment, flat)
+
+	keys := make([]string, 0, len(flat))
+	for k := range flat {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	ctx := context.NewContext(d.owner)
+	for _, k := range keys {
+		categories, ok := d.usage[k]
+		if !ok {
+			categories = []context.ContextCategoy{context.URI}
+		}
+
+		value := d.translateValue(flat[k])
+		for _, c := range categories {
+			ctx.Put(c, k, value, false)
+		}
+	}
+
+	return ctx
+}
+
+// translate replaces the Insomnia variables with the context ones and records
+// the category where they are used. Template tags and expressions that can
+// not be represented are kept as is and reported.
+func (d *decoder) translate(resource Resource, category context.ContextCategoy, source string) string {
+	if tagPattern.MatchString(source) {
+		d.reportOnce(resource, "template tags are not supported")
+	}
+
+	return variablePattern.ReplaceAllStringFunc(source, func(match string) string {
+		name, ok := variableName(variablePattern.FindStringSubmatch(match)[1])
+		if !ok {
+			d.reportOnce(resource, fmt.Sprintf("unsupported template expression '%s'", match))
+			return match
+		}
+
+		d.use(name, category)
+
+		return fmt.Sprintf("${%s}", name)
+	})
+}
+
+func (d *decoder) translateValue(source string) string {
+	return variablePattern.ReplaceAllStringFunc(source, func(match string) string {
+		name, ok := variableName(variablePattern.FindStringSubmatch(match)[1])
+		if !ok {
+			return match
+		}
+		return fmt.Sprintf("${%s}", name)
+	})
+}
+
+func (d *decoder) use(name string, category context.ContextCategoy) {
+	for _, v := range d.usage[name] {
+		if v == category {
+			return
+		}
+	}
+	d.usage[name] = append(d.usage[name], category)
+}
+
+func (d *decoder) reportOnce(resource Resource, reason string) {
+	for _, v := range d.report.Items {
+		if v.Id == resource.Id && v.Reason == reason {
+			return
+		}
+	}
+	d.report.Add(resource, reason)
+}
+
+func variableName(expression string) (string, bool) {
+	name := strings.TrimSpace(expression)
+	if match := bracketPattern.FindStringSubmatch(name); match != nil {
+		name = match[1]
+	} else {
+		name = strings.TrimPrefix(name, "_.")
+	}
+
+	if !namePattern.MatchString(name) {
+		return "", false
+	}
+
+	return strings.ReplaceAll(name, ".", "_"), true
+}
+
+func flattenData(prefix string, data map[string]any, result map[string]string) {
+	for k, v := range data {
+		key := k
+		if prefix != "" {
+			key = fmt.Sprintf("%s_%s", prefix, k)
+		}
+
+		switch value := v.(type) {
+		case map[string]any:
+			flattenData(key, value, result)
+		case string:
+			result[key] = value
+		case nil:
+			result[key] = ""
+		default:
+			bytes, err := json.Marshal(value)
+			if err != nil {
+				bytes = fmt.Appendf(nil, "%v", value)
+			}
+			result[key] = string(bytes)
+		}
+	}
+}
+
+func mergeData(target, source map[string]any) {
+	for k, v := range source {
+		current, okCurrent := target[k].(map[string]any)
+		next, okNext := v.(map[string]any)
+		if okCurrent && okNext {
+			merged := copyData(current)
+			mergeData(merged, next)
+			target[k] = merged
+			continue
+		}
+		target[k] = v
+	}
+}
+
+func copyData(source map[string]any) map[string]any {
+	target := make(map[string]any, len(source))
+	mergeData(target, source)
+	return target
+}
@@ -0,0 +1,128 @@
+package insomnia
+
+import (
+	"fmt"
+
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	"github.com/Rafael24595/go-api-core/src/domain/context"
+)
+
+const (
+	EXPORT_FORMAT = 4
+
+	TYPE_WORKSPACE     = "workspace"
+	TYPE_ENVIRONMENT   = "environment"
+	TYPE_REQUEST_GROUP = "request_group"
+	TYPE_REQUEST       = "request"
+)
+
+type Export struct {
+	Type         string     `json:"_type"`
+	ExportFormat int        `json:"__export_format"`
+	ExportDate   string     `json:"__export_date"`
+	ExportSource string     `json:"__export_source"`
+	Resources    []Resource `json:"resources"`
+}
+
+type Resource struct {
+	Id             string          `json:"_id"`
+	Type           string          `json:"_type"`
+	ParentId       string          `json:"parentId"`
+	Name           string          `json:"name"`
+	Description    string          `json:"description"`
+	MetaSortKey    float64         `json:"metaSortKey"`
+	Method         string          `json:"method"`
+	Url            string          `json:"url"`
+	Body           Body            `json:"body"`
+	Parameters     []Parameter     `json:"parameters"`
+	PathParameters []Parameter     `json:"pathParameters"`
+	Headers        []Parameter     `json:"headers"`
+	Authentication *Authentication `json:"authentication"`
+	Data           map[string]any  `json:"data"`
+	Environment    map[string]any  `json:"environment"`
+}
+
+type Body struct {
+	MimeType string      `json:"mimeType"`
+	Text     string      `json:"text"`
+	FileName string      `json:"fileName"`
+	Params   []Parameter `json:"params"`
+}
+
+type Parameter struct {
+	Name        string `json:"name"`
+	Value       string `json:"value"`
+	Description string `json:"description"`
+	Disabled    bool   `json:"disabled"`
+	Type        string `json:"type"`
+	FileName    string `json:"fileName"`
+}
+
+type Authentication struct {
+	Type     string `json:"type"`
+	Disabled bool   `json:"disabled"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Token    string `json:"token"`
+	Prefix   string `json:"prefix"`
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+	AddTo    string `json:"addTo"`
+}
+
+type Import struct {
+	Workspaces []Workspace
+	Report     Report
+}
+
+// Collections returns the collections of every workspace. A user owns a
+// single group, so the workspaces are flattened into it and, when there are
+// several of them, their name prefixes the collection names.
+func (i Import) Collections() []Collection {
+	collections := make([]Collection, 0)
+	for _, v := range i.Workspaces {
+		for _, c := range v.Collections {
+			if len(i.Workspaces) > 1 && c.Name != v.Name {
+				c.Name = fmt.Sprintf("%s - %s", v.Name, c.Name)
+			}
+			collections = append(collections, c)
+		}
+	}
+	return collections
+}
+
+type Workspace struct {
+	Name        string
+	Collections []Collection
+}
+
+type Collection struct {
+	Name     string
+	Context  *context.Context
+	Requests []action.Request
+}
+
+type Report struct {
+	Items []ReportItem `json:"items"`
+}
+
+type ReportItem struct {
+	Id     string `json:"id"`
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+func (r *Report) Add(resource Resource, reason string) *Report {
+	r.Items = append(r.Items, ReportItem{
+		Id:     resource.Id,
+		Type:   resource.Type,
+		Name:   resource.Name,
+		Reason: reason,
+	})
+	return r
+}
+
+func (r Report) Empty() bool {
+	return len(r.Items) == 0
+}
@@ -0,0 +1,176 @@
+package layout
+
+import (
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	"github.com/Rafael24595/go-api-core/src/domain/collection"
+	"github.com/Rafael24595/go-api-core/src/domain/context"
+	"github.com/Rafael24595/go-api-core/src/domain/group"
+)
+
+// UnmarshalGroup reads the group index and the collections of its nodes in
+// the same order. The owner is not part of the layout, so it must be set by
+// the importer.
+func UnmarshalGroup(tree Tree) (*group.Group, []Source, error) {
+	file, content, ok := tree.find(GROUP_INDEX)
+	if !ok {
+		return nil, nil, errors.New("the group index is not defined")
+	}
+
+	index := GroupIndex{}
+	if err := decode(file, content, &index); err != nil {
+		return nil, nil, fmt.Errorf("invalid group index: %s", err.Error())
+	}
+
+	sortNodes(index.Nodes)
+
+	result := group.NewGroup("")
+	result.Id = index.Id
+
+	sources := make([]Source, 0, len(index.Nodes))
+	for _, v := range index.Nodes {
+		source, err := unmarshalCollection(tree, v.File)
+		if err != nil {
+			return nil, nil, fmt.Errorf("collection '%s': %w", v.File, err)
+		}
+
+		result.Nodes = append(result.Nodes, domain.NodeReference{
+			Order: v.Order,
+			Item:  source.Collection.Id,
+		})
+
+		sources = append(sources, *source)
+	}
+
+	return result, sources, nil
+}
+
+func UnmarshalCollection(tree Tree) (*Source, error) {
+	return unmarshalCollection(tree, "")
+}
+
+func unmarshalCollection(tree Tree, dir string) (*Source, error) {
+	file, content, ok := tree.find(join(dir, COLLECTION_INDEX))
+	if !ok {
+		return nil, errors.New("the collection index is not defined")
+	}
+
+	index := CollectionIndex{}
+	if err := decode(file, content, &index); err != nil {
+		return nil, fmt.Errorf("invalid collection index: %s", err.Error())
+	}
+
+	if index.Status == "" {
+		index.Status = collection.FREE
+	}
+
+	sortNodes(index.Nodes)
+
+	coll := collection.Collection{
+		Id:        index.Id,
+		Name:      index.Name,
+		Timestamp: index.Timestamp,
+		Nodes:     make([]domain.NodeReference, 0, len(index.Nodes)),
+		Status:    index.Status,
+		Tags:      domain.NormalizeTags(index.Tags...),
+	}
+
+	ctx, err := unmarshalContext(tree, dir, index.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	requests := make([]action.Request, 0, len(index.Nodes))
+	for _, v := range index.Nodes {
+		request, err := unmarshalRequest(tree, join(dir, v.File))
+		if err != nil {
+			return nil, err
+		}
+
+		coll.Nodes = append(coll.Nodes, domain.NodeReference{
+			Order: v.Order,
+			Item:  request.Id,
+		})
+
+		requests = append(requests, *request)
+	}
+
+	return &Source{
+		Collection: coll,
+		Context:    ctx,
+		Requests:   requests,
+	}, nil
+}
+
+func unmarshalContext(tree Tree, dir, file string) (*context.Context, error) {
+	ctx := context.NewContext("")
+	if file == "" {
+		return ctx, nil
+	}
+
+	file = join(dir, file)
+	content, ok := tree[file]
+	if !ok {
+		return nil, fmt.Errorf("the context file '%s' is not defined", file)
+	}
+
+	source := ContextFile{}
+	if err := decode(file, content, &source); err != nil {
+		return nil, fmt.Errorf("invalid context file '%s': %s", file, err.Error())
+	}
+
+	ctx.Id = source.Id
+	ctx.Status = source.Status
+	if source.Timestamp != 0 {
+		ctx.Timestamp = source.Timestamp
+	}
+
+	for c, vs := range source.Dictionary {
+		ctx.PutAll(c, vs)
+	}
+
+	return ctx, nil
+}
+
+func unmarshalRequest(tree Tree, file string) (*action.Request, error) {
+	content, ok := tree[path.Clean(file)]
+	if !ok {
+		return nil, fmt.Errorf("the request file '%s' is not defined", file)
+	}
+
+	source := RequestFile{}
+	if err := decode(file, content, &source); err != nil {
+		return nil, fmt.Errorf("invalid request file '%s': %s", file, err.Error())
+	}
+
+	request := action.NewRequest(source.Name, source.Method, source.Uri)
+	request.Id = source.Id
+	request.Timestamp = source.Timestamp
+	request.Status = source.Status
+	request.Tags = domain.NormalizeTags(source.Tags...)
+
+	if source.Param.Params != nil {
+		request.Param = source.Param
+	}
+	if source.Query.Queries != nil {
+		request.Query = source.Query
+	}
+	if source.Header.Headers != nil {
+		request.Header = source.Header
+	}
+	if source.Cookie.Cookies != nil {
+		request.Cookie = source.Cookie
+	}
+	if source.Body.Parameters != nil {
+		request.Body = source.Body
+	}
+	if source.Auth.Auths != nil {
+		request.Auth = source.Auth
+	}
+
+	return request, nil
+}
@@ -0,0 +1,160 @@
+package layout
+
+import (
+	"sort"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	"github.com/Rafael24595/go-api-core/src/domain/context"
+	"github.com/Rafael24595/go-api-core/src/domain/group"
+)
+
+// MarshalGroup writes every collection in its own directory and an index with
+// the order of the group nodes.
+func MarshalGroup(format Format, group *group.Group, sources ...Source) (Tree, error) {
+	tree := make(Tree)
+
+	orders := make(map[string]int)
+	for _, v := range group.Nodes {
+		orders[v.Item] = v.Order
+	}
+
+	index := GroupIndex{
+		Id:    group.Id,
+		Nodes: make([]NodeFile, 0, len(sources)),
+	}
+
+	taken := make(map[string]bool)
+	for i, v := range sources {
+		dir := uniqueName(taken, v.Collection.Name, v.Collection.Id)
+		if err := marshalCollection(tree, format, dir, v); err != nil {
+			return nil, err
+		}
+
+		order, ok := orders[v.Collection.Id]
+		if !ok {
+			order = i
+		}
+
+		index.Nodes = append(index.Nodes, NodeFile{
+			Order: order,
+			Item:  v.Collection.Id,
+			File:  dir,
+		})
+	}
+
+	sortNodes(index.Nodes)
+
+	if err := tree.put(format, GROUP_INDEX+"."+string(format), index); err != nil {
+		return nil, err
+	}
+
+	return tree, nil
+}
+
+// MarshalCollection writes the collection index, its context and one file per
+// request. The request files are named after the request, so renaming a
+// request renames its file.
+func MarshalCollection(format Format, source Source) (Tree, error) {
+	tree := make(Tree)
+	if err := marshalCollection(tree, format, "", source); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+func marshalCollection(tree Tree, format Format, dir string, source Source) error {
+	extension := "." + string(format)
+
+	orders := make(map[string]int)
+	for _, v := range source.Collection.Nodes {
+		orders[v.Item] = v.Order
+	}
+
+	index := CollectionIndex{
+		Id:        source.Collection.Id,
+		Name:      source.Collection.Name,
+		Timestamp: source.Collection.Timestamp,
+		Status:    source.Collection.Status,
+		Context:   CONTEXT_FILE + extension,
+		Tags:      domain.NormalizeTags(source.Collection.Tags...),
+		Nodes:     make([]NodeFile, 0, len(source.Requests)),
+	}
+
+	taken := make(map[string]bool)
+	for i, v := range source.Requests {
+		file := join(REQUESTS_DIR, uniqueName(taken, v.Name, v.Id)+extension)
+		if err := tree.put(format, join(dir, file), fromRequest(v)); err != nil {
+			return err
+		}
+
+		order, ok := orders[v.Id]
+		if !ok {
+			order = i
+		}
+
+		index.Nodes = append(index.Nodes, NodeFile{
+			Order: order,
+			Item:  v.Id,
+			File:  file,
+		})
+	}
+
+	sortNodes(index.Nodes)
+
+	if err := tree.put(format, join(dir, index.Context), fromContext(source.Context)); err != nil {
+		return err
+	}
+
+	return tree.put(format, join(dir, COLLECTION_INDEX+extension), index)
+}
+
+func fromRequest(request action.Request) RequestFile {
+	return RequestFile{
+		Id:        request.Id,
+		Timestamp: request.Timestamp,
+		Name:      request.Name,
+		Method:    request.Method,
+		Uri:       request.Uri,
+		Param:     request.Param,
+		Query:     request.Query,
+		Header:    request.Header,
+		Cookie:    request.Cookie,
+		Body:      request.Body,
+		Auth:      request.Auth,
+		Status:    request.Status,
+		Tags:      domain.NormalizeTags(request.Tags...),
+	}
+}
+
+func fromContext(ctx *context.Context) ContextFile {
+	file := ContextFile{
+		Status:     true,
+		Dictionary: make(map[string]map[string]context.ItemContext),
+	}
+
+	if ctx == nil {
+		return file
+	}
+
+	file.Id = ctx.Id
+	file.Status = ctx.Status
+	file.Timestamp = ctx.Timestamp
+
+	for _, p := range ctx.Dictionary.Pairs() {
+		category := make(map[string]context.ItemContext)
+		values := p.Value()
+		for _, v := range values.Pairs() {
+			category[v.Key()] = v.Value()
+		}
+		file.Dictionary[p.Key()] = category
+	}
+
+	return file
+}
+
+func sortNodes(nodes []NodeFile) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i].Order < nodes[j].Order
+	})
+}
@@ -0,0 +1,308 @@
+package layout
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	"github.com/Rafael24595/go-api-core/src/domain/action/auth"
+	"github.com/Rafael24595/go-api-core/src/domain/action/body"
+	"github.com/Rafael24595/go-api-core/src/domain/action/cookie"
+	"github.com/Rafael24595/go-api-core/src/domain/action/header"
+	"github.com/Rafael24595/go-api-core/src/domain/action/param"
+	"github.com/Rafael24595/go-api-core/src/domain/action/query"
+	"github.com/Rafael24595/go-api-core/src/domain/collection"
+	"github.com/Rafael24595/go-api-core/src/domain/context"
+	"gopkg.in/yaml.v3"
+)
+
+type Format string
+
+const (
+	YAML Format = "yaml"
+	JSON Format = "json"
+)
+
+const (
+	GROUP_INDEX      = "group"
+	COLLECTION_INDEX = "collection"
+	CONTEXT_FILE     = "context"
+	REQUESTS_DIR     = "requests"
+)
+
+func FormatFromString(value string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "yaml", "yml", "":
+		return YAML, nil
+	case "json":
+		return JSON, nil
+	default:
+		return "", fmt.Errorf("unsupported layout format '%s'", value)
+	}
+}
+
+// Tree is the set of files of the layout indexed by their slash separated
+// path, so it can be written to disk, zipped or compared in memory.
+type Tree map[string][]byte
+
+// Source is a collection with its context and requests in node order.
+type Source struct {
+	Collection collection.Collection
+	Context    *context.Context
+	Requests   []action.Request
+}
+
+type GroupIndex struct {
+	Id    string     `json:"_id"`
+	Nodes []NodeFile `json:"nodes"`
+}
+
+type CollectionIndex struct {
+	Id        string                      `json:"_id"`
+	Name      string                      `json:"name"`
+	Timestamp int64                       `json:"timestamp"`
+	Status    collection.StatusCollection `json:"status"`
+	Context   string                      `json:"context"`
+	Tags      []string                    `json:"tags,omitempty"`
+	Nodes     []NodeFile                  `json:"nodes"`
+}
+
+// NodeFile is a domain.NodeReference with the relative path of the file or
+// directory that stores the item.
+type NodeFile struct {
+	Order int    `json:"order"`
+	Item  string `json:"item"`
+	File  string `json:"file"`
+}
+
+// ContextFile and RequestFile leave out the owner and the modification date,
+// they change on every save and would make the diffs noisy.
+type ContextFile struct {
+	Id         string                                    `json:"_id"`
+	Status     bool                                      `json:"status"`
+	Timestamp  int64                                     `json:"timestamp"`
+	Dictionary map[string]map[string]context.ItemContext `json:"dictionary"`
+}
+
+type RequestFile struct {
+	Id        string               `json:"_id"`
+	Timestamp int64                `json:"timestamp"`
+	Name      string               `json:"name"`
+	Method    domain.HttpMethod    `json:"method"`
+	Uri       string               `json:"uri"`
+	Param     param.Params         `json:"param"`
+	Query     query.Queries        `json:"query"`
+	Header    header.Headers       `json:"header"`
+	Cookie    cookie.CookiesClient `json:"cookie"`
+	Body      body.BodyRequest     `json:"body"`
+	Auth      auth.Auths           `json:"auth"`
+	Status    action.StatusRequest `json:"status"`
+	Tags      []string             `json:"tags,omitempty"`
+}
+
+func TreeFromDir(root string) (Tree, error) {
+	tree := make(Tree)
+	err := filepath.WalkDir(root, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() || !isLayoutFile(file) {
+			return nil
+		}
+
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		relative, err := filepath.Rel(root, file)
+		if err != nil {
+			return err
+		}
+
+		tree[filepath.ToSlash(relative)] = content
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tree, nil
+}
+
+// WriteDir writes the files of the tree under the root directory, existing
+// files are overwritten but stale ones are not removed.
+func (t Tree) WriteDir(root string) error {
+	for _, k := range t.Paths() {
+		file := filepath.Join(root, filepath.FromSlash(k))
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(file, t[k], 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t Tree) Paths() []string {
+	paths := make([]string, 0, len(t))
+	for k := range t {
+		paths = append(paths, k)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func (t Tree) put(format Format, file string, value any) error {
+	content, err := encode(format, value)
+	if err != nil {
+		return err
+	}
+	t[file] = content
+	return nil
+}
+
+// find returns the file with any of the supported extensions.
+func (t Tree) find(file string) (string, []byte, bool) {
+	for _, v := range []string{".yaml", ".yml", ".json"} {
+		if content, ok := t[file+v]; ok {
+			return file + v, content, true
+		}
+	}
+	return "", nil, false
+}
+
+func encode(format Format, value any) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if format == JSON {
+		buffer := new(bytes.Buffer)
+		if err := json.Indent(buffer, data, "", "  "); err != nil {
+			return nil, err
+		}
+		buffer.WriteString("\n")
+		return buffer.Bytes(), nil
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+
+	clearNodeStyle(&node)
+
+	buffer := new(bytes.Buffer)
+	encoder := yaml.NewEncoder(buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// decode reads both formats through JSON, so the json tags of the domain
+// structures are used for the YAML files too.
+func decode(file string, content []byte, value any) error {
+	if strings.HasSuffix(file, ".json") {
+		return json.Unmarshal(content, value)
+	}
+
+	var raw any
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, value)
+}
+
+func clearNodeStyle(node *yaml.Node) {
+	node.Style = 0
+	if node.Kind == yaml.ScalarNode && strings.Contains(node.Value, "\n") {
+		node.Style = yaml.LiteralStyle
+	}
+	for _, v := range node.Content {
+		clearNodeStyle(v)
+	}
+}
+
+func isLayoutFile(file string) bool {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
+
+// slug makes a file name from the item name, ascii letters and digits are
+// kept and the rest collapse into dashes.
+func slug(name string) string {
+	var buffer strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			buffer.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && buffer.Len() > 0 {
+			buffer.WriteRune('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(buffer.String(), "-")
+}
+
+// uniqueName returns a stable name for the item, the id is appended when the
+// name is already taken or empty.
+func uniqueName(taken map[string]bool, name, id string) string {
+	base := slug(name)
+	if base == "" {
+		base = "item"
+	}
+
+	result := base
+	if taken[result] && id != "" {
+		result = fmt.Sprintf("%s-%s", base, id)
+	}
+
+	for i := 2; taken[result]; i++ {
+		result = fmt.Sprintf("%s-%d", base, i)
+	}
+
+	taken[result] = true
+	return result
+}
+
+func join(elements ...string) string {
+	result := make([]string, 0, len(elements))
+	for _, v := range elements {
+		if v != "" {
+			result = append(result, v)
+		}
+	}
+	return path.Join(result...)
+}
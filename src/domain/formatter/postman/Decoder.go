@@ -0,0 +1,449 @@
+package postman
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	"github.com/Rafael24595/go-api-core/src/domain/action/auth"
+	auth_strategy "github.com/Rafael24595/go-api-core/src/domain/action/auth/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/action/body"
+	body_strategy "github.com/Rafael24595/go-api-core/src/domain/action/body/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/action/param"
+	"github.com/Rafael24595/go-api-core/src/domain/action/query"
+	"github.com/Rafael24595/go-api-core/src/domain/context"
+)
+
+var variablePattern = regexp.MustCompile(`\{\{\s*([^{}\s]+)\s*\}\}`)
+
+type Import struct {
+	Name    string
+	Context *context.Context
+	Folders []Folder
+}
+
+// Requests returns the requests of every folder in a single list, prefixing
+// the request names with the folder they belong to.
+func (i Import) Requests() []action.Request {
+	requests := make([]action.Request, 0)
+	for _, f := range i.Folders {
+		for _, r := range f.Requests {
+			if f.Name != "" {
+				r.Name = fmt.Sprintf("%s / %s", f.Name, r.Name)
+			}
+			requests = append(requests, r)
+		}
+	}
+	return requests
+}
+
+type decoder struct {
+	owner string
+	usage map[string][]context.ContextCategoy
+}
+
+func Unmarshal(owner string, data []byte, environments ...[]byte) (*Import, error) {
+	source := &Collection{}
+	if err := json.Unmarshal(data, source); err != nil {
+		return nil, fmt.Errorf("the provided file is not a valid Postman collection: %s", err.Error())
+	}
+
+	if source.Info.Schema != "" && source.Info.Schema != SCHEMA_V2_1 && source.Info.Schema != SCHEMA_V2_0 {
+		return nil, fmt.Errorf("unsupported Postman collection schema '%s'; it must be v2.1.0", source.Info.Schema)
+	}
+
+	if source.Info.Name == "" && len(source.Item) == 0 {
+		return nil, errors.New("the provided file is not a valid Postman collection: info not found")
+	}
+
+	envs := make([]Environment, len(environments))
+	for i, v := range environments {
+		if err := json.Unmarshal(v, &envs[i]); err != nil {
+			return nil, fmt.Errorf("the provided environment is not a valid Postman environment: %s", err.Error())
+		}
+	}
+
+	return UnmarshalCollection(owner, source, envs...), nil
+}
+
+func UnmarshalCollection(owner string, source *Collection, environments ...Environment) *Import {
+	decoder := &decoder{
+		owner: owner,
+		usage: make(map[string][]context.ContextCategoy),
+	}
+
+	root := Folder{
+		Name:     "",
+		Requests: make([]action.Request, 0),
+	}
+
+	folders := make([]Folder, 0)
+	for _, v := range source.Item {
+		if !v.IsFolder() {
+			root.Requests = append(root.Requests, decoder.makeRequest(v, source.Auth))
+			continue
+		}
+
+		folder := Folder{
+			Name:     v.Name,
+			Requests: decoder.makeFolder("", v, inheritAuth(v.Auth, source.Auth)),
+		}
+		folders = append(folders, folder)
+	}
+
+	if len(root.Requests) > 0 {
+		folders = append([]Folder{root}, folders...)
+	}
+
+	return &Import{
+		Name:    source.Info.Name,
+		Context: decoder.makeContext(source.Variable, environments),
+		Folders: folders,
+	}
+}
+
+func (d *decoder) makeFolder(prefix string, folder Item, parent *Auth) []action.Request {
+	requests := make([]action.Request, 0)
+	for _, v := range folder.Item {
+		if !v.IsFolder() {
+			request := d.makeRequest(v, parent)
+			if prefix != "" {
+				request.Name = fmt.Sprintf("%s / %s", prefix, request.Name)
+			}
+			requests = append(requests, request)
+			continue
+		}
+
+		name := v.Name
+		if prefix != "" {
+			name = fmt.Sprintf("%s / %s", prefix, v.Name)
+		}
+
+		requests = append(requests, d.makeFolder(name, v, inheritAuth(v.Auth, parent))...)
+	}
+	return requests
+}
+
+func (d *decoder) makeRequest(item Item, parent *Auth) action.Request {
+	source := item.Request
+
+	method := domain.GET
+	if result, err := domain.HttpMethodFromString(source.Method); err == nil {
+		method = *result
+	}
+
+	uri, queries := d.makeUrl(source.Url)
+
+	request := action.NewRequest(item.Name, method, uri)
+	request.Owner = d.owner
+	request.Query = *queries
+	request.Param = *d.makeParams(uri, source.Url.Variable)
+
+	for _, v := range source.Header {
+		key := d.translate(context.HEADER, v.Key)
+		request.Header.AddStatus(key, d.translate(context.HEADER, v.Value), !v.Disabled)
+	}
+
+	request.Body = *d.makeBody(source.Body, request)
+
+	if requestAuth := inheritAuth(source.Auth, parent); requestAuth != nil {
+		request = d.makeAuth(*requestAuth, request)
+	}
+
+	return *request
+}
+
+func (d *decoder) makeUrl(source Url) (string, *query.Queries) {
+	queries := query.NewQueries()
+
+	raw := source.Raw
+	if raw == "" {
+		raw = buildRaw(source)
+	}
+
+	uri, rawQuery, _ := strings.Cut(raw, "?")
+	uri, _, _ = strings.Cut(uri, "#")
+	rawQuery, _, _ = strings.Cut(rawQuery, "#")
+
+	uri = d.translate(context.URI, uri)
+
+	if len(source.Query) > 0 {
+		for _, v := range source.Query {
+			key := d.translate(context.QUERY, v.Key)
+			queries.AddStatus(key, d.translate(context.QUERY, v.Value), !v.Disabled)
+		}
+		return uri, queries
+	}
+
+	for fragment := range strings.SplitSeq(rawQuery, "&") {
+		if fragment == "" {
+			continue
+		}
+
+		key, value, _ := strings.Cut(fragment, "=")
+		if unescaped, err := url.QueryUnescape(key); err == nil {
+			key = unescaped
+		}
+		if unescaped, err := url.QueryUnescape(value); err == nil {
+			value = unescaped
+		}
+
+		queries.Add(d.translate(context.QUERY, key), d.translate(context.QUERY, value))
+	}
+
+	return uri, queries
+}
+
+func buildRaw(source Url) string {
+	raw := strings.Join(source.Host, ".")
+	if source.Protocol != "" {
+		raw = fmt.Sprintf("%s://%s", source.Protocol, raw)
+	}
+	if source.Port != "" {
+		raw = fmt.Sprintf("%s:%s", raw, source.Port)
+	}
+	if len(source.Path) > 0 {
+		raw = fmt.Sprintf("%s/%s", raw, strings.Join(source.Path, "/"))
+	}
+	return raw
+}
+
+func (d *decoder) makeParams(uri string, variables []Variable) *param.Params {
+	params := param.NewParams()
+	for _, v := range variables {
+		value := d.translate(context.URI, stringify(v.Value))
+		order := int64(len(params.Params))
+		params.AddParam(param.NewParam(order, !v.Disabled, v.Key, value, string(v.Description)))
+	}
+
+	for _, k := range param.FindKeys(uri) {
+		if !params.Exists(k) {
+			params.AddStatus(k, "", false)
+		}
+	}
+
+	return params
+}
+
+func (d *decoder) makeBody(source *Body, request *action.Request) *body.BodyRequest {
+	if source == nil {
+		return body.EmptyBody(false, domain.None)
+	}
+
+	switch source.Mode {
+	case "raw":
+		content := d.translate(context.PAYLOAD, source.Raw)
+		return body_strategy.DocumentBody(!source.Disabled, rawContentType(source, request), content)
+	case "urlencoded":
+		return d.makeForm(source.Urlencoded, !source.Disabled)
+	case "formdata":
+		return d.makeForm(source.Formdata, !source.Disabled)
+	case "graphql":
+		if source.Graphql == nil {
+			break
+		}
+
+		variables := json.RawMessage("{}")
+		if strings.TrimSpace(source.Graphql.Variables) != "" && json.Valid([]byte(source.Graphql.Variables)) {
+			variables = json.RawMessage(source.Graphql.Variables)
+		}
+
+		payload, err := json.Marshal(map[string]any{
+			"query":     source.Graphql.Query,
+			"variables": variables,
+		})
+		if err != nil {
+			break
+		}
+
+		content := d.translate(context.PAYLOAD, string(payload))
+		return body_strategy.DocumentBody(!source.Disabled, domain.Json, content)
+	}
+
+	return body.EmptyBody(false, domain.None)
+}
+
+func rawContentType(source *Body, request *action.Request) domain.ContentType {
+	if source.Options.Raw != nil {
+		switch strings.ToLower(source.Options.Raw.Language) {
+		case "json":
+			return domain.Json
+		case "xml":
+			return domain.Xml
+		case "html":
+			return domain.Html
+		case "text":
+			return domain.Text
+		}
+	}
+
+	for k, hs := range request.Header.Headers {
+		if !strings.EqualFold(k, "Content-Type") || len(hs) == 0 {
+			continue
+		}
+		if contentType, ok := domain.ContentTypeFromHeader(hs[0].Value); ok && contentType != domain.Form {
+			return contentType
+		}
+	}
+
+	return domain.Text
+}
+
+func (d *decoder) makeForm(source []KeyValue, status bool) *body.BodyRequest {
+	payload := body.EmptyBody(status, domain.Form)
+	for _, v := range source {
+		key := d.translate(context.PAYLOAD, v.Key)
+
+		var parameter *body.BodyParameter
+		if v.Type == "file" {
+			src := stringify(v.Src)
+			ext := strings.TrimPrefix(filepath.Ext(src), ".")
+			parameter = body.NewFileParameter(0, !v.Disabled, ext, src, "")
+		} else {
+			parameter = body.NewParameter(0, !v.Disabled, d.translate(context.PAYLOAD, v.Value))
+		}
+
+		payload = body_strategy.AddFormData(payload, key, parameter)
+	}
+	payload.Status = status
+	return payload
+}
+
+func (d *decoder) makeAuth(source Auth, request *action.Request) *action.Request {
+	switch source.Type {
+	case "basic":
+		user := d.translate(context.AUTH, source.Find(source.Basic, "username"))
+		pass := d.translate(context.AUTH, source.Find(source.Basic, "password"))
+		request.Auth.PutAuth(*auth_strategy.BasicAuth(true, user, pass))
+		request.Auth.Status = true
+	case "bearer":
+		token := d.translate(context.AUTH, source.Find(source.Bearer, "token"))
+		request.Auth.PutAuth(*auth_strategy.BearerAuth(true, auth_strategy.DEFAULT_BEARER_PREFIX, token))
+		request.Auth.Status = true
+	case "apikey":
+		key := source.Find(source.Apikey, "key")
+		value := source.Find(source.Apikey, "value")
+		if source.Find(source.Apikey, "in") == "query" {
+			request.Query.Add(d.translate(context.QUERY, key), d.translate(context.QUERY, value))
+		} else {
+			request.Header.Add(d.translate(context.HEADER, key), d.translate(context.HEADER, value))
+		}
+	case "noauth":
+		request.Auth = *auth.NewAuths(false)
+	}
+	return request
+}
+
+func (d *decoder) makeContext(variables []Variable, environments []Environment) *context.Context {
+	values := make(map[string]context.ItemContext)
+	keys := make([]string, 0)
+
+	put := func(key, value string, private, status bool) {
+		if _, ok := values[key]; !ok {
+			keys = append(keys, key)
+		}
+		values[key] = context.NewItemContext(0, private, status, value)
+	}
+
+	for _, v := range variables {
+		put(v.Key, d.translate("", stringify(v.Value)), v.Type == "secret", !v.Disabled)
+	}
+
+	for _, e := range environments {
+		for _, v := range e.Values {
+			put(v.Key, d.translate("", stringify(v.Value)), v.Type == "secret", v.Enabled)
+		}
+	}
+
+	ctx := context.NewContext(d.owner)
+	for _, k := range keys {
+		categories, ok := d.usage[k]
+		if !ok {
+			categories = []context.ContextCategoy{context.URI}
+		}
+
+		item := values[k]
+		for _, c := range categories {
+			ctx.Put(c, k, item.Value, item.Private)
+			if !item.Status {
+				disableItem(ctx, c, k)
+			}
+		}
+	}
+
+	return ctx
+}
+
+func disableItem(ctx *context.Context, category context.ContextCategoy, key string) {
+	variables, ok := ctx.Dictionary.Get(category.String())
+	if !ok {
+		return
+	}
+
+	item, ok := variables.Get(key)
+	if !ok {
+		return
+	}
+
+	item.Status = false
+	variables.Put(key, item)
+}
+
+// translate replaces the Postman variables with the context ones and records
+// the category where the variable is used. Dynamic variables are kept as is.
+func (d *decoder) translate(category context.ContextCategoy, source string) string {
+	return variablePattern.ReplaceAllStringFunc(source, func(match string) string {
+		name := variablePattern.FindStringSubmatch(match)[1]
+		if strings.HasPrefix(name, "$") {
+			return match
+		}
+
+		if category != "" {
+			d.use(name, category)
+		}
+
+		return fmt.Sprintf("${%s}", name)
+	})
+}
+
+func (d *decoder) use(name string, category context.ContextCategoy) {
+	for _, v := range d.usage[name] {
+		if v == category {
+			return
+		}
+	}
+	d.usage[name] = append(d.usage[name], category)
+}
+
+func inheritAuth(auth, parent *Auth) *Auth {
+	if auth == nil {
+		return parent
+	}
+	return auth
+}
+
+func stringify(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []any:
+		if len(v) > 0 {
+			return stringify(v[0])
+		}
+		return ""
+	default:
+		bytes, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(bytes)
+	}
+}
@@ -0,0 +1,347 @@
+package postman
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	"github.com/Rafael24595/go-api-core/src/domain/action/auth"
+	auth_strategy "github.com/Rafael24595/go-api-core/src/domain/action/auth/strategy"
+	body_strategy "github.com/Rafael24595/go-api-core/src/domain/action/body/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/action/param"
+	"github.com/Rafael24595/go-api-core/src/domain/context"
+)
+
+var contextPattern = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+func Marshal(name string, ctx *context.Context, folders ...Folder) ([]byte, error) {
+	return json.MarshalIndent(MarshalCollection(name, ctx, folders...), "", "  ")
+}
+
+func MarshalCollection(name string, ctx *context.Context, folders ...Folder) *Collection {
+	items := make([]Item, 0)
+	for _, f := range folders {
+		requests := make([]Item, len(f.Requests))
+		for i, r := range f.Requests {
+			requests[i] = MarshalRequest(r)
+		}
+
+		if f.Name == "" {
+			items = append(items, requests...)
+			continue
+		}
+
+		items = append(items, Item{
+			Name: f.Name,
+			Item: requests,
+		})
+	}
+
+	return &Collection{
+		Info: Info{
+			Name:   name,
+			Schema: SCHEMA_V2_1,
+		},
+		Item:     items,
+		Variable: contextToVariables(ctx),
+	}
+}
+
+func MarshalRequest(request action.Request) Item {
+	return Item{
+		Name: request.Name,
+		Request: &Request{
+			Method: request.Method.String(),
+			Header: headersToPostman(request),
+			Body:   bodyToPostman(request),
+			Url:    urlToPostman(request),
+			Auth:   authToPostman(request.Auth),
+		},
+		Response: make([]json.RawMessage, 0),
+	}
+}
+
+func urlToPostman(request action.Request) Url {
+	uri := request.Uri
+	for _, s := range param.FindSegments(uri) {
+		if strings.HasPrefix(s.Raw, "{") {
+			uri = strings.Replace(uri, s.Raw, ":"+s.Key, 1)
+		}
+	}
+	uri = untranslate(uri)
+
+	queries := make([]KeyValue, 0)
+	active := make([]string, 0)
+	for _, k := range sortedKeys(request.Query.Queries) {
+		for _, v := range request.Query.Queries[k] {
+			key := k
+			if v.Property != "" {
+				key = k + "[" + v.Property + "]"
+			}
+
+			queries = append(queries, KeyValue{
+				Key:      untranslate(key),
+				Value:    untranslate(v.Value),
+				Disabled: !v.Status,
+			})
+
+			if v.Status {
+				active = append(active, untranslate(key)+"="+untranslate(v.Value))
+			}
+		}
+	}
+
+	raw := uri
+	if len(active) > 0 {
+		raw = raw + "?" + strings.Join(active, "&")
+	}
+
+	variables := make([]Variable, 0)
+	for _, v := range request.Param.Params {
+		variables = append(variables, Variable{
+			Key:         v.Key,
+			Value:       untranslate(v.Value),
+			Disabled:    !v.Status,
+			Description: Description(v.Description),
+		})
+	}
+
+	result := Url{
+		Raw:      raw,
+		Query:    queries,
+		Variable: variables,
+	}
+
+	return splitRaw(uri, result)
+}
+
+func splitRaw(uri string, result Url) Url {
+	protocol, rest, ok := strings.Cut(uri, "://")
+	if !ok {
+		rest = uri
+		protocol = ""
+	}
+
+	host, path, _ := strings.Cut(rest, "/")
+	if host == "" {
+		return result
+	}
+
+	if index := strings.LastIndex(host, ":"); index != -1 && !strings.Contains(host[index:], "}") {
+		result.Port = host[index+1:]
+		host = host[:index]
+	}
+
+	result.Protocol = protocol
+	result.Host = strings.Split(host, ".")
+	if path != "" {
+		result.Path = strings.Split(path, "/")
+	}
+
+	return result
+}
+
+func headersToPostman(request action.Request) []KeyValue {
+	headers := make([]KeyValue, 0)
+	for _, k := range sortedKeys(request.Header.Headers) {
+		for _, v := range request.Header.Headers[k] {
+			headers = append(headers, KeyValue{
+				Key:      untranslate(k),
+				Value:    untranslate(v.Value),
+				Disabled: !v.Status,
+			})
+		}
+	}
+
+	cookies := make([]string, 0)
+	for _, k := range sortedKeys(request.Cookie.Cookies) {
+		if v := request.Cookie.Cookies[k]; v.Status {
+			cookies = append(cookies, untranslate(k)+"="+untranslate(v.Value))
+		}
+	}
+
+	if len(cookies) > 0 {
+		headers = append(headers, KeyValue{
+			Key:   "Cookie",
+			Value: strings.Join(cookies, "; "),
+		})
+	}
+
+	return headers
+}
+
+func bodyToPostman(request action.Request) *Body {
+	payload := request.Body
+	if payload.Empty() {
+		return nil
+	}
+
+	if payload.ContentType == domain.Form {
+		form := make([]KeyValue, 0)
+		parameters := payload.Parameters[body_strategy.FORM_DATA_PARAM]
+		for _, k := range sortedKeys(parameters) {
+			for _, v := range parameters[k] {
+				item := KeyValue{
+					Key:      untranslate(k),
+					Value:    untranslate(v.Value),
+					Type:     "text",
+					Disabled: !v.Status,
+				}
+				if v.IsFile {
+					item.Value = ""
+					item.Type = "file"
+					item.Src = v.FileName
+				}
+				form = append(form, item)
+			}
+		}
+
+		return &Body{
+			Mode:     "formdata",
+			Formdata: form,
+			Disabled: !payload.Status,
+		}
+	}
+
+	document, ok := payload.Parameters[body_strategy.DOCUMENT_PARAM][body_strategy.PAYLOAD_PARAM]
+	if !ok || len(document) == 0 || document[0].IsFile {
+		return nil
+	}
+
+	language := "text"
+	switch payload.ContentType {
+	case domain.Json:
+		language = "json"
+	case domain.Xml:
+		language = "xml"
+	case domain.Html:
+		language = "html"
+	}
+
+	return &Body{
+		Mode: "raw",
+		Raw:  untranslate(document[0].Value),
+		Options: BodyOptions{
+			Raw: &RawOptions{
+				Language: language,
+			},
+		},
+		Disabled: !payload.Status,
+	}
+}
+
+func authToPostman(auths auth.Auths) *Auth {
+	if !auths.Status {
+		return nil
+	}
+
+	if basic, ok := auths.Auths[auth.Basic.String()]; ok && basic.Status {
+		return &Auth{
+			Type: "basic",
+			Basic: []AuthElement{
+				{Key: "username", Value: untranslate(basic.Parameters[auth_strategy.BASIC_PARAM_USER]), Type: "string"},
+				{Key: "password", Value: untranslate(basic.Parameters[auth_strategy.BASIC_PARAM_PASSWORD]), Type: "string"},
+			},
+		}
+	}
+
+	if bearer, ok := auths.Auths[auth.Bearer.String()]; ok && bearer.Status {
+		return &Auth{
+			Type: "bearer",
+			Bearer: []AuthElement{
+				{Key: "token", Value: untranslate(bearer.Parameters[auth_strategy.BEARER_PARAM_TOKEN]), Type: "string"},
+			},
+		}
+	}
+
+	return nil
+}
+
+func contextToVariables(ctx *context.Context) []Variable {
+	variables := make([]Variable, 0)
+	if ctx == nil {
+		return variables
+	}
+
+	categories := make(map[string]context.DictionaryVariables)
+	for _, p := range ctx.Dictionary.Pairs() {
+		categories[p.Key()] = p.Value()
+	}
+
+	cache := make(map[string]bool)
+	for _, c := range sortedCategories(categories) {
+		values := categories[c]
+		items := values.Pairs()
+		sort.SliceStable(items, func(i, j int) bool {
+			return items[i].Value().Order < items[j].Value().Order
+		})
+
+		for _, v := range items {
+			if cache[v.Key()] {
+				continue
+			}
+			cache[v.Key()] = true
+
+			typ := "string"
+			if v.Value().Private {
+				typ = "secret"
+			}
+
+			variables = append(variables, Variable{
+				Key:      v.Key(),
+				Value:    untranslate(v.Value().Value),
+				Type:     typ,
+				Disabled: !v.Value().Status,
+			})
+		}
+	}
+
+	return variables
+}
+
+func sortedCategories(categories map[string]context.DictionaryVariables) []string {
+	known := []context.ContextCategoy{
+		context.URI, context.QUERY, context.HEADER, context.COOKIE, context.PAYLOAD, context.AUTH,
+	}
+
+	result := make([]string, 0, len(categories))
+	cache := make(map[string]bool)
+	for _, v := range known {
+		if _, ok := categories[v.String()]; ok {
+			result = append(result, v.String())
+			cache[v.String()] = true
+		}
+	}
+
+	for _, k := range sortedKeys(categories) {
+		if !cache[k] {
+			result = append(result, k)
+		}
+	}
+
+	return result
+}
+
+// untranslate replaces the context variables with the Postman ones, the
+// category of qualified variables is discarded.
+func untranslate(source string) string {
+	return contextPattern.ReplaceAllStringFunc(source, func(match string) string {
+		name := contextPattern.FindStringSubmatch(match)[1]
+		if _, key, ok := strings.Cut(name, "."); ok {
+			name = key
+		}
+		return "{{" + name + "}}"
+	})
+}
+
+func sortedKeys[T any](items map[string]T) []string {
+	keys := make([]string, 0, len(items))
+	for k := range items {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,206 @@
+package postman
+
+import (
+	"encoding/json"
+
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+)
+
+const (
+	SCHEMA_V2_1 = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+	SCHEMA_V2_0 = "https://schema.getpostman.com/json/collection/v2.0.0/collection.json"
+)
+
+type Collection struct {
+	Info     Info       `json:"info"`
+	Item     []Item     `json:"item"`
+	Auth     *Auth      `json:"auth,omitempty"`
+	Variable []Variable `json:"variable,omitempty"`
+}
+
+type Info struct {
+	PostmanId   string      `json:"_postman_id,omitempty"`
+	Name        string      `json:"name"`
+	Description Description `json:"description,omitempty"`
+	Schema      string      `json:"schema"`
+}
+
+type Item struct {
+	Name        string            `json:"name"`
+	Description Description       `json:"description,omitempty"`
+	Item        []Item            `json:"item,omitempty"`
+	Request     *Request          `json:"request,omitempty"`
+	Response    []json.RawMessage `json:"response,omitempty"`
+	Auth        *Auth             `json:"auth,omitempty"`
+}
+
+func (i Item) IsFolder() bool {
+	return i.Request == nil
+}
+
+type Request struct {
+	Method      string      `json:"method"`
+	Header      []KeyValue  `json:"header"`
+	Body        *Body       `json:"body,omitempty"`
+	Url         Url         `json:"url"`
+	Auth        *Auth       `json:"auth,omitempty"`
+	Description Description `json:"description,omitempty"`
+}
+
+func (r *Request) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err == nil {
+		r.Method = "GET"
+		r.Url = Url{Raw: raw}
+		return nil
+	}
+
+	type alias Request
+	value := alias{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	*r = Request(value)
+	return nil
+}
+
+type Url struct {
+	Raw      string     `json:"raw"`
+	Protocol string     `json:"protocol,omitempty"`
+	Host     []string   `json:"host,omitempty"`
+	Port     string     `json:"port,omitempty"`
+	Path     []string   `json:"path,omitempty"`
+	Query    []KeyValue `json:"query,omitempty"`
+	Variable []Variable `json:"variable,omitempty"`
+}
+
+func (u *Url) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err == nil {
+		u.Raw = raw
+		return nil
+	}
+
+	type alias Url
+	value := alias{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	*u = Url(value)
+	return nil
+}
+
+type KeyValue struct {
+	Key         string      `json:"key"`
+	Value       string      `json:"value"`
+	Type        string      `json:"type,omitempty"`
+	Src         any         `json:"src,omitempty"`
+	Disabled    bool        `json:"disabled,omitempty"`
+	Description Description `json:"description,omitempty"`
+}
+
+type Variable struct {
+	Key         string      `json:"key"`
+	Value       any         `json:"value"`
+	Type        string      `json:"type,omitempty"`
+	Disabled    bool        `json:"disabled,omitempty"`
+	Description Description `json:"description,omitempty"`
+}
+
+type Body struct {
+	Mode       string      `json:"mode"`
+	Raw        string      `json:"raw,omitempty"`
+	Urlencoded []KeyValue  `json:"urlencoded,omitempty"`
+	Formdata   []KeyValue  `json:"formdata,omitempty"`
+	File       *BodyFile   `json:"file,omitempty"`
+	Graphql    *Graphql    `json:"graphql,omitempty"`
+	Options    BodyOptions `json:"options,omitempty"`
+	Disabled   bool        `json:"disabled,omitempty"`
+}
+
+type BodyFile struct {
+	Src string `json:"src"`
+}
+
+type Graphql struct {
+	Query     string `json:"query"`
+	Variables string `json:"variables,omitempty"`
+}
+
+type BodyOptions struct {
+	Raw *RawOptions `json:"raw,omitempty"`
+}
+
+type RawOptions struct {
+	Language string `json:"language"`
+}
+
+type Auth struct {
+	Type   string        `json:"type"`
+	Basic  []AuthElement `json:"basic,omitempty"`
+	Bearer []AuthElement `json:"bearer,omitempty"`
+	Apikey []AuthElement `json:"apikey,omitempty"`
+}
+
+func (a Auth) Find(elements []AuthElement, key string) string {
+	for _, v := range elements {
+		if v.Key != key {
+			continue
+		}
+		if value, ok := v.Value.(string); ok {
+			return value
+		}
+		if v.Value != nil {
+			bytes, _ := json.Marshal(v.Value)
+			return string(bytes)
+		}
+	}
+	return ""
+}
+
+type AuthElement struct {
+	Key   string `json:"key"`
+	Value any    `json:"value"`
+	Type  string `json:"type,omitempty"`
+}
+
+type Description string
+
+func (d *Description) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err == nil {
+		*d = Description(raw)
+		return nil
+	}
+
+	value := struct {
+		Content string `json:"content"`
+	}{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	*d = Description(value.Content)
+	return nil
+}
+
+type Environment struct {
+	Id     string             `json:"id,omitempty"`
+	Name   string             `json:"name"`
+	Values []EnvironmentValue `json:"values"`
+	Scope  string             `json:"_postman_variable_scope,omitempty"`
+}
+
+type EnvironmentValue struct {
+	Key     string `json:"key"`
+	Value   any    `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Enabled bool   `json:"enabled"`
+}
+
+type Folder struct {
+	Name     string
+	Requests []action.Request
+}
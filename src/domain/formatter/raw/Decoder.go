@@ -0,0 +1,380 @@
+package raw
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	auth_strategy "github.com/Rafael24595/go-api-core/src/domain/action/auth/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/action/body"
+	body_strategy "github.com/Rafael24595/go-api-core/src/domain/action/body/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/action/cookie"
+	"github.com/Rafael24595/go-api-core/src/domain/action/param"
+	"github.com/Rafael24595/go-api-core/src/domain/action/query"
+)
+
+const DEFAULT_SCHEME = "http"
+
+type field struct {
+	key   string
+	value string
+}
+
+// Unmarshal parses a raw HTTP/1.x request message. The scheme is not part of
+// the message, so it is deduced from the port of the Host header.
+func Unmarshal(data []byte) (*action.Request, error) {
+	return UnmarshalScheme("", data)
+}
+
+func UnmarshalScheme(scheme string, data []byte) (*action.Request, error) {
+	reader := bufio.NewReader(bytes.NewReader(bytes.TrimLeft(data, "\r\n\t ")))
+
+	line, err := readLine(reader)
+	if err != nil {
+		return nil, errors.New("the request line is not defined")
+	}
+
+	method, target, err := requestLine(line)
+	if err != nil {
+		return nil, err
+	}
+
+	headers, err := readHeaders(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	uri, err := makeUri(scheme, method, target, findField(headers, "Host"))
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := readBody(reader, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	base, rawQuery, _ := strings.Cut(uri, "?")
+
+	request := action.NewRequest(fmt.Sprintf("[RAW] %s", base), method, base)
+	request.Query = *makeQueries(rawQuery)
+	request.Param = *makeParams(base)
+
+	for _, v := range headers {
+		switch {
+		case strings.EqualFold(v.key, "Host"),
+			strings.EqualFold(v.key, "Content-Length"),
+			strings.EqualFold(v.key, "Transfer-Encoding"):
+			continue
+		case strings.EqualFold(v.key, "Cookie"):
+			makeCookies(request, v.value)
+		case strings.EqualFold(v.key, "Authorization") && makeAuth(request, v.value):
+			continue
+		default:
+			request.Header.Add(v.key, v.value)
+		}
+	}
+
+	request.Body, err = makeBody(payload, findField(headers, "Content-Type"))
+	if err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+func requestLine(line string) (domain.HttpMethod, string, error) {
+	fields := strings.Fields(line)
+	if len(fields) != 3 || !strings.HasPrefix(strings.ToUpper(fields[2]), "HTTP/") {
+		return "", "", fmt.Errorf("invalid request line '%s'", line)
+	}
+
+	method, err := domain.HttpMethodFromString(strings.ToUpper(fields[0]))
+	if err != nil {
+		return "", "", fmt.Errorf("unsupported method '%s'", fields[0])
+	}
+
+	return *method, fields[1], nil
+}
+
+// readHeaders reads the header section, obsolete folded lines are joined to
+// the previous header.
+func readHeaders(reader *bufio.Reader) ([]field, error) {
+	headers := make([]field, 0)
+	for {
+		line, err := readLine(reader)
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+
+		if line == "" {
+			return headers, nil
+		}
+
+		if (line[0] == ' ' || line[0] == '\t') && len(headers) > 0 {
+			last := &headers[len(headers)-1]
+			last.value = fmt.Sprintf("%s %s", last.value, strings.TrimSpace(line))
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid header line '%s'", line)
+		}
+
+		headers = append(headers, field{
+			key:   strings.TrimSpace(key),
+			value: strings.TrimSpace(value),
+		})
+
+		if err == io.EOF {
+			return headers, nil
+		}
+	}
+}
+
+func readLine(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), err
+}
+
+// readBody decodes the chunked bodies and cuts the body at the Content-Length,
+// a shorter body is kept as is since pasted messages are usually edited.
+func readBody(reader *bufio.Reader, headers []field) ([]byte, error) {
+	if strings.EqualFold(findField(headers, "Transfer-Encoding"), "chunked") {
+		payload, err := readChunked(reader)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chunked body: %s", err.Error())
+		}
+		return payload, nil
+	}
+
+	payload, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	length := findField(headers, "Content-Length")
+	if length == "" {
+		return bytes.TrimRight(payload, "\r\n"), nil
+	}
+
+	size, err := strconv.Atoi(length)
+	if err != nil || size < 0 {
+		return nil, fmt.Errorf("invalid Content-Length '%s'", length)
+	}
+
+	if size < len(payload) {
+		payload = payload[:size]
+	}
+
+	return payload, nil
+}
+
+// readChunked decodes the chunked body accepting bare line feeds, which the
+// standard reader rejects but are common in messages copied from logs.
+func readChunked(reader *bufio.Reader) ([]byte, error) {
+	payload := new(bytes.Buffer)
+	for {
+		line, err := readLine(reader)
+		if err != nil {
+			return nil, err
+		}
+
+		length, _, _ := strings.Cut(line, ";")
+		size, err := strconv.ParseInt(strings.TrimSpace(length), 16, 64)
+		if err != nil || size < 0 {
+			return nil, fmt.Errorf("invalid chunk size '%s'", line)
+		}
+
+		if size == 0 {
+			return payload.Bytes(), nil
+		}
+
+		if _, err := io.CopyN(payload, reader, size); err != nil {
+			return nil, err
+		}
+
+		if line, err := readLine(reader); err != nil && err != io.EOF || line != "" {
+			return nil, errors.New("the chunk does not end with a line break")
+		}
+	}
+}
+
+func makeUri(scheme string, method domain.HttpMethod, target, host string) (string, error) {
+	if strings.Contains(target, "://") {
+		if _, err := url.Parse(target); err != nil {
+			return "", err
+		}
+		return target, nil
+	}
+
+	if host == "" {
+		return "", errors.New("the Host header is required for relative request targets")
+	}
+
+	if scheme == "" {
+		scheme = DEFAULT_SCHEME
+		if strings.HasSuffix(host, ":443") {
+			scheme = "https"
+		}
+	}
+
+	host = strings.TrimSuffix(host, ":443")
+	if scheme == "http" {
+		host = strings.TrimSuffix(host, ":80")
+	}
+
+	switch {
+	case method == domain.CONNECT:
+		return fmt.Sprintf("%s://%s", scheme, target), nil
+	case target == "*":
+		return fmt.Sprintf("%s://%s", scheme, host), nil
+	default:
+		return fmt.Sprintf("%s://%s%s", scheme, host, target), nil
+	}
+}
+
+func makeQueries(rawQuery string) *query.Queries {
+	queries := query.NewQueries()
+	for fragment := range strings.SplitSeq(rawQuery, "&") {
+		if fragment == "" {
+			continue
+		}
+
+		key, value, _ := strings.Cut(fragment, "=")
+		if unescaped, err := url.QueryUnescape(key); err == nil {
+			key = unescaped
+		}
+		if unescaped, err := url.QueryUnescape(value); err == nil {
+			value = unescaped
+		}
+
+		queries.Add(key, value)
+	}
+	return queries
+}
+
+func makeParams(uri string) *param.Params {
+	params := param.NewParams()
+	for _, k := range param.FindKeys(uri) {
+		params.AddStatus(k, "", false)
+	}
+	return params
+}
+
+func makeCookies(request *action.Request, value string) {
+	for fragment := range strings.SplitSeq(value, ";") {
+		name, content, ok := strings.Cut(strings.TrimSpace(fragment), "=")
+		if !ok {
+			continue
+		}
+		order := int64(len(request.Cookie.Cookies))
+		request.Cookie.Cookies[strings.TrimSpace(name)] = cookie.NewCookieClient(order, true, strings.TrimSpace(content))
+	}
+}
+
+// makeAuth maps the Basic and Bearer credentials, any other scheme or an
+// invalid encoding is kept as header.
+func makeAuth(request *action.Request, value string) bool {
+	scheme, credentials, _ := strings.Cut(strings.TrimSpace(value), " ")
+	credentials = strings.TrimSpace(credentials)
+
+	switch strings.ToLower(scheme) {
+	case "basic":
+		decoded, err := base64.StdEncoding.DecodeString(credentials)
+		if err != nil {
+			return false
+		}
+		user, pass, ok := strings.Cut(string(decoded), ":")
+		if !ok {
+			return false
+		}
+		request.Auth.PutAuth(*auth_strategy.BasicAuth(true, user, pass))
+	case "bearer":
+		if credentials == "" {
+			return false
+		}
+		request.Auth.PutAuth(*auth_strategy.BearerAuth(true, auth_strategy.DEFAULT_BEARER_PREFIX, credentials))
+	default:
+		return false
+	}
+
+	request.Auth.Status = true
+	return true
+}
+
+func makeBody(payload []byte, contentType string) (body.BodyRequest, error) {
+	if len(payload) == 0 {
+		return *body.EmptyBody(false, domain.None), nil
+	}
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err == nil && mediaType == "multipart/form-data" && params["boundary"] != "" {
+		form, err := makeForm(payload, params["boundary"])
+		if err != nil {
+			return body.BodyRequest{}, err
+		}
+		return *form, nil
+	}
+
+	typ := domain.Text
+	if result, ok := domain.ContentTypeFromHeader(contentType); ok && result != domain.Form {
+		typ = result
+	}
+
+	return *body_strategy.DocumentBody(true, typ, string(payload)), nil
+}
+
+func makeForm(payload []byte, boundary string) (*body.BodyRequest, error) {
+	form := body.EmptyBody(true, domain.Form)
+
+	reader := multipart.NewReader(bytes.NewReader(payload), boundary)
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return form, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid multipart body: %s", err.Error())
+		}
+
+		content, err := io.ReadAll(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid multipart body: %s", err.Error())
+		}
+
+		var parameter *body.BodyParameter
+		if filename := part.FileName(); filename != "" {
+			ext := strings.TrimPrefix(filepath.Ext(filename), ".")
+			parameter = body.NewFileParameterActive(ext, filename, base64.StdEncoding.EncodeToString(content))
+		} else {
+			parameter = body.NewParameterActive(string(content))
+		}
+
+		form = body_strategy.AddFormData(form, part.FormName(), parameter)
+	}
+}
+
+func findField(fields []field, key string) string {
+	for _, v := range fields {
+		if strings.EqualFold(v.key, key) {
+			return v.value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,251 @@
+package raw
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"maps"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	auth_strategy "github.com/Rafael24595/go-api-core/src/domain/action/auth/strategy"
+	body_strategy "github.com/Rafael24595/go-api-core/src/domain/action/body/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/action/header"
+	"github.com/Rafael24595/go-api-core/src/domain/context"
+)
+
+const (
+	PROTOCOL      = "HTTP/1.1"
+	LINE_BREAK    = "\r\n"
+	FORM_BOUNDARY = "RawFormBoundary"
+)
+
+func MarshalContext(ctx *context.Context, req *action.Request) ([]byte, error) {
+	req = context.ProcessRequest(req, ctx)
+	return Marshal(req)
+}
+
+// Marshal serializes the request as it would be written on the wire. Forms
+// with files are written as multipart with a fixed boundary and the rest of
+// the forms as url encoded.
+func Marshal(req *action.Request) ([]byte, error) {
+	method := strings.ToUpper(req.Method.String())
+	uri := strings.TrimSpace(req.Param.Apply(req.Uri))
+
+	if method == "" || uri == "" {
+		return nil, errors.New("the method or the URI are empty")
+	}
+
+	base, rawQuery, _ := strings.Cut(uri, "?")
+
+	target, err := url.Parse(base)
+	if err != nil || target.Host == "" {
+		return nil, fmt.Errorf("the URI '%s' is not absolute", uri)
+	}
+
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		values = url.Values{}
+	}
+
+	request := *req
+	request.Header.Headers = maps.Clone(req.Header.Headers)
+	if request.Header.Headers == nil {
+		request.Header.Headers = make(map[string][]header.Header)
+	}
+
+	authorized := auth_strategy.ApplyAuth(&request)
+
+	path := target.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if encoded := authorized.Query.Encode(values); encoded != "" {
+		path = fmt.Sprintf("%s?%s", path, encoded)
+	}
+
+	payload, contentType := makePayload(authorized)
+
+	lines := []string{
+		fmt.Sprintf("%s %s %s", method, path, PROTOCOL),
+		fmt.Sprintf("Host: %s", target.Host),
+	}
+
+	for _, k := range sortedKeys(authorized.Header.Headers) {
+		if isFraming(k) || (contentType != "" && strings.EqualFold(k, "Content-Type")) {
+			continue
+		}
+		for _, v := range authorized.Header.Headers[k] {
+			if v.Status {
+				lines = append(lines, fmt.Sprintf("%s: %s", k, strings.TrimSpace(v.Value)))
+			}
+		}
+	}
+
+	if cookies := makeCookieHeader(authorized); cookies != "" {
+		lines = append(lines, fmt.Sprintf("Cookie: %s", cookies))
+	}
+
+	if contentType != "" {
+		lines = append(lines, fmt.Sprintf("Content-Type: %s", contentType))
+	}
+
+	if len(payload) > 0 {
+		lines = append(lines, fmt.Sprintf("Content-Length: %d", len(payload)))
+	}
+
+	return writeMessage(lines, payload), nil
+}
+
+// MarshalResponse serializes the response with the decoded body, so the
+// framing headers are recalculated.
+func MarshalResponse(res *action.Response) []byte {
+	status := int(res.Status)
+
+	lines := []string{
+		strings.TrimSpace(fmt.Sprintf("%s %d %s", PROTOCOL, status, http.StatusText(status))),
+	}
+
+	for _, k := range sortedKeys(res.Headers.Headers) {
+		if isFraming(k) {
+			continue
+		}
+		for _, v := range res.Headers.Headers[k] {
+			if v.Status {
+				lines = append(lines, fmt.Sprintf("%s: %s", k, v.Value))
+			}
+		}
+	}
+
+	payload := []byte(res.Body.Payload)
+	lines = append(lines, fmt.Sprintf("Content-Length: %d", len(payload)))
+
+	return writeMessage(lines, payload)
+}
+
+func writeMessage(lines []string, payload []byte) []byte {
+	buffer := new(bytes.Buffer)
+	for _, v := range lines {
+		buffer.WriteString(v + LINE_BREAK)
+	}
+	buffer.WriteString(LINE_BREAK)
+	buffer.Write(payload)
+	return buffer.Bytes()
+}
+
+func makePayload(req *action.Request) ([]byte, string) {
+	method := req.Method.String()
+	if !req.Body.Status || req.Body.Empty() || method == "GET" || method == "HEAD" {
+		return nil, ""
+	}
+
+	if req.Body.ContentType == domain.Form {
+		return makeFormPayload(req)
+	}
+
+	document := req.Body.Parameters[body_strategy.DOCUMENT_PARAM][body_strategy.PAYLOAD_PARAM]
+	if len(document) == 0 || document[0].IsFile {
+		return nil, ""
+	}
+
+	if hasHeader(req, "Content-Type") {
+		return []byte(document[0].Value), ""
+	}
+
+	return []byte(document[0].Value), req.Body.ContentType.ToHeader()
+}
+
+func makeFormPayload(req *action.Request) ([]byte, string) {
+	parameters := req.Body.Parameters[body_strategy.FORM_DATA_PARAM]
+
+	files := false
+	for _, p := range parameters {
+		for _, v := range p {
+			files = files || (v.Status && v.IsFile)
+		}
+	}
+
+	if !files {
+		values := url.Values{}
+		for _, k := range sortedKeys(parameters) {
+			for _, v := range parameters[k] {
+				if v.Status {
+					values.Add(k, v.Value)
+				}
+			}
+		}
+		return []byte(values.Encode()), "application/x-www-form-urlencoded"
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := multipart.NewWriter(buffer)
+	_ = writer.SetBoundary(FORM_BOUNDARY)
+
+	for _, k := range sortedKeys(parameters) {
+		for _, v := range parameters[k] {
+			if !v.Status {
+				continue
+			}
+
+			if !v.IsFile {
+				_ = writer.WriteField(k, v.Value)
+				continue
+			}
+
+			content, err := base64.StdEncoding.DecodeString(v.Value)
+			if err != nil {
+				content = []byte(v.Value)
+			}
+
+			part, err := writer.CreateFormFile(k, v.FileName)
+			if err == nil {
+				_, _ = part.Write(content)
+			}
+		}
+	}
+
+	_ = writer.Close()
+
+	return buffer.Bytes(), writer.FormDataContentType()
+}
+
+func makeCookieHeader(req *action.Request) string {
+	cookies := make([]string, 0)
+	for _, k := range sortedKeys(req.Cookie.Cookies) {
+		if v := req.Cookie.Cookies[k]; v.Status {
+			cookies = append(cookies, fmt.Sprintf("%s=%s", k, strings.TrimSpace(v.Value)))
+		}
+	}
+	return strings.Join(cookies, "; ")
+}
+
+// isFraming defines the headers calculated from the serialized message.
+func isFraming(key string) bool {
+	return strings.EqualFold(key, "Host") ||
+		strings.EqualFold(key, "Content-Length") ||
+		strings.EqualFold(key, "Transfer-Encoding")
+}
+
+func hasHeader(req *action.Request, key string) bool {
+	for k, v := range req.Header.Headers {
+		if strings.EqualFold(k, key) && len(v) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys[T any](items map[string]T) []string {
+	keys := make([]string, 0, len(items))
+	for k := range items {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
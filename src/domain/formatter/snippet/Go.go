@@ -0,0 +1,133 @@
+package snippet
+
+import (
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+)
+
+func marshalGo(snapshot *Snapshot) (string, error) {
+	imports := map[string]bool{
+		"fmt":      true,
+		"io":       true,
+		"net/http": true,
+	}
+
+	lines := make([]string, 0)
+
+	body := "nil"
+	switch snapshot.Body.Kind {
+	case BODY_RAW:
+		imports["strings"] = true
+		lines = append(lines, fmt.Sprintf("body := strings.NewReader(%s)", goLiteral(snapshot.Body.Raw)), "")
+		body = "body"
+	case BODY_FORM:
+		imports["bytes"] = true
+		imports["mime/multipart"] = true
+		lines = append(lines, goForm(snapshot.Body.Fields, imports)...)
+		body = "body"
+	}
+
+	lines = append(lines,
+		fmt.Sprintf("req, err := http.NewRequest(%s, %s, %s)", quoteString(snapshot.Method), quoteString(snapshot.Url), body),
+		"if err != nil {",
+		"panic(err)",
+		"}",
+		"",
+	)
+
+	for _, v := range snapshot.Headers {
+		lines = append(lines, fmt.Sprintf("req.Header.Add(%s, %s)", quoteString(v.Key), quoteString(v.Value)))
+	}
+	if snapshot.Body.Kind == BODY_FORM {
+		lines = append(lines, `req.Header.Set("Content-Type", writer.FormDataContentType())`)
+	}
+
+	lines = append(lines,
+		"",
+		"res, err := http.DefaultClient.Do(req)",
+		"if err != nil {",
+		"panic(err)",
+		"}",
+		"defer res.Body.Close()",
+		"",
+		"payload, err := io.ReadAll(res.Body)",
+		"if err != nil {",
+		"panic(err)",
+		"}",
+		"",
+		"fmt.Println(res.Status)",
+		"fmt.Println(string(payload))",
+	)
+
+	packages := make([]string, 0, len(imports))
+	for k := range imports {
+		packages = append(packages, quoteString(k))
+	}
+	sort.Strings(packages)
+
+	source := fmt.Sprintf("package main\n\nimport (\n%s\n)\n\nfunc main() {\n%s\n}\n",
+		strings.Join(packages, "\n"),
+		strings.Join(lines, "\n"))
+
+	formatted, err := format.Source([]byte(source))
+	if err != nil {
+		return "", fmt.Errorf("cannot format the Go snippet: %s", err.Error())
+	}
+
+	return string(formatted), nil
+}
+
+func goForm(fields []Field, imports map[string]bool) []string {
+	lines := []string{
+		"body := &bytes.Buffer{}",
+		"writer := multipart.NewWriter(body)",
+		"",
+	}
+
+	for _, v := range fields {
+		if !v.IsFile {
+			lines = append(lines,
+				fmt.Sprintf("if err := writer.WriteField(%s, %s); err != nil {", quoteString(v.Name), quoteString(v.Value)),
+				"panic(err)",
+				"}",
+				"",
+			)
+			continue
+		}
+
+		imports["os"] = true
+		lines = append(lines,
+			"{",
+			fmt.Sprintf("part, err := writer.CreateFormFile(%s, %s)", quoteString(v.Name), quoteString(v.FileName)),
+			"if err != nil {",
+			"panic(err)",
+			"}",
+			fmt.Sprintf("content, err := os.ReadFile(%s)", quoteString(v.FileName)),
+			"if err != nil {",
+			"panic(err)",
+			"}",
+			"if _, err := part.Write(content); err != nil {",
+			"panic(err)",
+			"}",
+			"}",
+			"",
+		)
+	}
+
+	return append(lines,
+		"if err := writer.Close(); err != nil {",
+		"panic(err)",
+		"}",
+		"",
+	)
+}
+
+// goLiteral prefers raw strings to keep the payloads readable.
+func goLiteral(value string) string {
+	if strings.Contains(value, "`") || strings.Contains(value, "\r") {
+		return quoteString(value)
+	}
+	return fmt.Sprintf("`%s`", value)
+}
@@ -0,0 +1,32 @@
+package snippet
+
+import (
+	"fmt"
+	"strings"
+)
+
+func marshalHttpie(snapshot *Snapshot) (string, error) {
+	buffer := []string{
+		fmt.Sprintf("http --ignore-stdin %s %s", snapshot.Method, shellQuote(snapshot.Url)),
+	}
+
+	for _, v := range snapshot.Headers {
+		buffer = append(buffer, shellQuote(fmt.Sprintf("%s:%s", v.Key, v.Value)))
+	}
+
+	switch snapshot.Body.Kind {
+	case BODY_RAW:
+		buffer = append(buffer, fmt.Sprintf("--raw %s", shellQuote(snapshot.Body.Raw)))
+	case BODY_FORM:
+		buffer = append(buffer, "--multipart")
+		for _, v := range snapshot.Body.Fields {
+			if v.IsFile {
+				buffer = append(buffer, shellQuote(fmt.Sprintf("%s@%s", v.Name, v.FileName)))
+				continue
+			}
+			buffer = append(buffer, shellQuote(fmt.Sprintf("%s=%s", v.Name, v.Value)))
+		}
+	}
+
+	return strings.Join(buffer, " \\\n  "), nil
+}
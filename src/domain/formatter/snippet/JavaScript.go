@@ -0,0 +1,63 @@
+package snippet
+
+import (
+	"fmt"
+	"strings"
+)
+
+func marshalJavaScript(snapshot *Snapshot) (string, error) {
+	lines := make([]string, 0)
+	options := []string{
+		fmt.Sprintf("  method: %s,", quoteString(snapshot.Method)),
+	}
+
+	if hasFiles(snapshot.Body.Fields) {
+		lines = append(lines, `import fs from "node:fs";`, "")
+	}
+
+	if len(snapshot.Headers) > 0 {
+		lines = append(lines, "const headers = {")
+		for _, v := range snapshot.Headers {
+			lines = append(lines, fmt.Sprintf("  %s: %s,", quoteString(v.Key), quoteString(v.Value)))
+		}
+		lines = append(lines, "};", "")
+		options = append(options, "  headers,")
+	}
+
+	switch snapshot.Body.Kind {
+	case BODY_RAW:
+		lines = append(lines, fmt.Sprintf("const body = %s;", quoteString(snapshot.Body.Raw)), "")
+		options = append(options, "  body,")
+	case BODY_FORM:
+		lines = append(lines, "const body = new FormData();")
+		for _, v := range snapshot.Body.Fields {
+			if v.IsFile {
+				lines = append(lines, fmt.Sprintf("body.append(%s, await fs.openAsBlob(%s), %s);", quoteString(v.Name), quoteString(v.FileName), quoteString(v.FileName)))
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("body.append(%s, %s);", quoteString(v.Name), quoteString(v.Value)))
+		}
+		lines = append(lines, "")
+		options = append(options, "  body,")
+	}
+
+	lines = append(lines, fmt.Sprintf("const response = await fetch(%s, {", quoteString(snapshot.Url)))
+	lines = append(lines, options...)
+	lines = append(lines,
+		"});",
+		"",
+		"console.log(response.status);",
+		"console.log(await response.text());",
+	)
+
+	return strings.Join(lines, "\n"), nil
+}
+
+func hasFiles(fields []Field) bool {
+	for _, v := range fields {
+		if v.IsFile {
+			return true
+		}
+	}
+	return false
+}
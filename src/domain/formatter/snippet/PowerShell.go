@@ -0,0 +1,114 @@
+package snippet
+
+import (
+	"fmt"
+	"strings"
+)
+
+var powerShellMethods = map[string]string{
+	"GET":     "Get",
+	"HEAD":    "Head",
+	"POST":    "Post",
+	"PUT":     "Put",
+	"DELETE":  "Delete",
+	"TRACE":   "Trace",
+	"OPTIONS": "Options",
+	"PATCH":   "Patch",
+}
+
+func marshalPowerShell(snapshot *Snapshot) (string, error) {
+	lines := make([]string, 0)
+
+	command := []string{
+		"Invoke-RestMethod",
+		fmt.Sprintf("-Uri %s", powerShellQuote(snapshot.Url)),
+	}
+
+	if method, ok := powerShellMethods[snapshot.Method]; ok {
+		command = append(command, fmt.Sprintf("-Method %s", method))
+	} else {
+		command = append(command, fmt.Sprintf("-CustomMethod %s", powerShellQuote(snapshot.Method)))
+	}
+
+	headers := make([]string, 0)
+	for _, v := range snapshot.Headers {
+		if strings.EqualFold(v.Key, "Content-Type") {
+			command = append(command, fmt.Sprintf("-ContentType %s", powerShellQuote(v.Value)))
+			continue
+		}
+		headers = append(headers, fmt.Sprintf("    %s = %s", powerShellQuote(v.Key), powerShellQuote(v.Value)))
+	}
+
+	if len(headers) > 0 {
+		lines = append(lines, "$headers = @{")
+		lines = append(lines, headers...)
+		lines = append(lines, "}", "")
+		command = append(command, "-Headers $headers")
+	}
+
+	switch snapshot.Body.Kind {
+	case BODY_RAW:
+		lines = append(lines, fmt.Sprintf("$body = %s", powerShellQuote(snapshot.Body.Raw)), "")
+		command = append(command, "-Body $body")
+	case BODY_FORM:
+		lines = append(lines, "$form = @{")
+		for _, v := range groupFields(snapshot.Body.Fields) {
+			lines = append(lines, fmt.Sprintf("    %s = %s", powerShellQuote(v.name), powerShellValues(v.fields)))
+		}
+		lines = append(lines, "}", "")
+		command = append(command, "-Form $form")
+	}
+
+	lines = append(lines, fmt.Sprintf("$response = %s", strings.Join(command, " ")))
+	lines = append(lines, "$response | ConvertTo-Json -Depth 10")
+
+	return strings.Join(lines, "\n"), nil
+}
+
+func powerShellValues(fields []Field) string {
+	if len(fields) == 1 {
+		return powerShellValue(fields[0])
+	}
+
+	values := make([]string, len(fields))
+	for i, v := range fields {
+		values[i] = powerShellValue(v)
+		if v.IsFile {
+			values[i] = fmt.Sprintf("(%s)", values[i])
+		}
+	}
+
+	return fmt.Sprintf("@(%s)", strings.Join(values, ", "))
+}
+
+func powerShellValue(field Field) string {
+	if field.IsFile {
+		return fmt.Sprintf("Get-Item -Path %s", powerShellQuote(field.FileName))
+	}
+	return powerShellQuote(field.Value)
+}
+
+func powerShellQuote(value string) string {
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(value, "'", "''"))
+}
+
+type fieldGroup struct {
+	name   string
+	fields []Field
+}
+
+// groupFields joins the fields sharing the same name keeping their order.
+func groupFields(fields []Field) []fieldGroup {
+	groups := make([]fieldGroup, 0)
+	for _, v := range fields {
+		if len(groups) > 0 && groups[len(groups)-1].name == v.Name {
+			groups[len(groups)-1].fields = append(groups[len(groups)-1].fields, v)
+			continue
+		}
+		groups = append(groups, fieldGroup{
+			name:   v.Name,
+			fields: []Field{v},
+		})
+	}
+	return groups
+}
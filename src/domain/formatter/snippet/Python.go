@@ -0,0 +1,71 @@
+package snippet
+
+import (
+	"fmt"
+	"strings"
+)
+
+func marshalPython(snapshot *Snapshot) (string, error) {
+	lines := []string{
+		"import requests",
+		"",
+		fmt.Sprintf("url = %s", quoteString(snapshot.Url)),
+		"",
+	}
+
+	arguments := []string{
+		quoteString(snapshot.Method),
+		"url",
+	}
+
+	if len(snapshot.Headers) > 0 {
+		lines = append(lines, "headers = {")
+		for _, v := range snapshot.Headers {
+			lines = append(lines, fmt.Sprintf("    %s: %s,", quoteString(v.Key), quoteString(v.Value)))
+		}
+		lines = append(lines, "}", "")
+		arguments = append(arguments, "headers=headers")
+	}
+
+	switch snapshot.Body.Kind {
+	case BODY_RAW:
+		lines = append(lines, fmt.Sprintf("data = %s", quoteString(snapshot.Body.Raw)), "")
+		arguments = append(arguments, "data=data")
+	case BODY_FORM:
+		data := make([]string, 0)
+		files := make([]string, 0)
+		for _, v := range snapshot.Body.Fields {
+			if v.IsFile {
+				file := fmt.Sprintf("%s, open(%s, \"rb\")", quoteString(v.FileName), quoteString(v.FileName))
+				if v.FileType != "" {
+					file = fmt.Sprintf("%s, %s", file, quoteString(v.FileType))
+				}
+				files = append(files, fmt.Sprintf("    (%s, (%s)),", quoteString(v.Name), file))
+				continue
+			}
+			data = append(data, fmt.Sprintf("    (%s, %s),", quoteString(v.Name), quoteString(v.Value)))
+		}
+
+		if len(data) > 0 {
+			lines = append(lines, "data = [")
+			lines = append(lines, data...)
+			lines = append(lines, "]", "")
+			arguments = append(arguments, "data=data")
+		}
+
+		// An empty files list still forces requests to send a multipart body.
+		lines = append(lines, "files = [")
+		lines = append(lines, files...)
+		lines = append(lines, "]", "")
+		arguments = append(arguments, "files=files")
+	}
+
+	lines = append(lines,
+		fmt.Sprintf("response = requests.request(%s)", strings.Join(arguments, ", ")),
+		"",
+		"print(response.status_code)",
+		"print(response.text)",
+	)
+
+	return strings.Join(lines, "\n"), nil
+}
@@ -0,0 +1,309 @@
+package snippet
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	auth_strategy "github.com/Rafael24595/go-api-core/src/domain/action/auth/strategy"
+	body_strategy "github.com/Rafael24595/go-api-core/src/domain/action/body/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/action/header"
+	"github.com/Rafael24595/go-api-core/src/domain/context"
+)
+
+type Language string
+
+const (
+	HTTPIE     Language = "httpie"
+	WGET       Language = "wget"
+	POWERSHELL Language = "powershell"
+	GO         Language = "go"
+	PYTHON     Language = "python"
+	JAVASCRIPT Language = "javascript"
+)
+
+func (l Language) String() string {
+	return string(l)
+}
+
+// Generator renders a snippet from the normalized request.
+type Generator func(snapshot *Snapshot) (string, error)
+
+var generators = map[Language]Generator{
+	HTTPIE:     marshalHttpie,
+	WGET:       marshalWget,
+	POWERSHELL: marshalPowerShell,
+	GO:         marshalGo,
+	PYTHON:     marshalPython,
+	JAVASCRIPT: marshalJavaScript,
+}
+
+// Register adds or replaces the generator of a language.
+func Register(language Language, generator Generator) {
+	generators[language] = generator
+}
+
+func Languages() []Language {
+	languages := make([]Language, 0, len(generators))
+	for k := range generators {
+		languages = append(languages, k)
+	}
+	sort.Slice(languages, func(i, j int) bool {
+		return languages[i] < languages[j]
+	})
+	return languages
+}
+
+func LanguageFromString(value string) (*Language, error) {
+	language := Language(strings.ToLower(strings.TrimSpace(value)))
+	if _, ok := generators[language]; !ok {
+		return nil, fmt.Errorf("unsupported snippet language '%s'", value)
+	}
+	return &language, nil
+}
+
+func MarshalContext(ctx *context.Context, language Language, req *action.Request) (string, error) {
+	req = context.ProcessRequest(req, ctx)
+	return Marshal(language, req)
+}
+
+func Marshal(language Language, req *action.Request) (string, error) {
+	generator, ok := generators[language]
+	if !ok {
+		return "", fmt.Errorf("unsupported snippet language '%s'", language)
+	}
+
+	snapshot, err := MakeSnapshot(req)
+	if err != nil {
+		return "", err
+	}
+
+	return generator(snapshot)
+}
+
+type BodyKind string
+
+const (
+	BODY_NONE BodyKind = "none"
+	BODY_RAW  BodyKind = "raw"
+	BODY_FORM BodyKind = "form"
+)
+
+// Snapshot is the request as it would be sent by the HTTP client, with the
+// path parameters, queries, cookies and authentication already applied.
+type Snapshot struct {
+	Method  string
+	Url     string
+	Headers []Header
+	Body    Body
+}
+
+type Header struct {
+	Key   string
+	Value string
+}
+
+type Body struct {
+	Kind        BodyKind
+	ContentType string
+	Raw         string
+	Fields      []Field
+}
+
+type Field struct {
+	Name     string
+	Value    string
+	IsFile   bool
+	FileName string
+	FileType string
+}
+
+func MakeSnapshot(req *action.Request) (*Snapshot, error) {
+	method := strings.ToUpper(req.Method.String())
+	uri := strings.TrimSpace(req.Param.Apply(req.Uri))
+
+	if method == "" || uri == "" {
+		return nil, errors.New("the method or the URI are empty")
+	}
+
+	request := *req
+	request.Header.Headers = maps.Clone(req.Header.Headers)
+	if request.Header.Headers == nil {
+		request.Header.Headers = make(map[string][]header.Header)
+	}
+
+	authorized := auth_strategy.ApplyAuth(&request)
+
+	snapshot := &Snapshot{
+		Method:  method,
+		Url:     makeUrl(uri, authorized),
+		Headers: makeHeaders(authorized),
+		Body:    makeBody(authorized),
+	}
+
+	if snapshot.Body.Kind == BODY_FORM {
+		snapshot.Headers = removeHeader(snapshot.Headers, "Content-Type")
+	}
+
+	if snapshot.Body.Kind == BODY_RAW && !snapshot.HasHeader("Content-Type") {
+		snapshot.Headers = append(snapshot.Headers, Header{
+			Key:   "Content-Type",
+			Value: snapshot.Body.ContentType,
+		})
+	}
+
+	return snapshot, nil
+}
+
+func (s Snapshot) HasHeader(key string) bool {
+	for _, v := range s.Headers {
+		if strings.EqualFold(v.Key, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// removeHeader drops the header, the multipart boundary must be defined by
+// the library that builds the form.
+func removeHeader(headers []Header, key string) []Header {
+	result := make([]Header, 0, len(headers))
+	for _, v := range headers {
+		if !strings.EqualFold(v.Key, key) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func makeUrl(uri string, req *action.Request) string {
+	base, rawQuery, _ := strings.Cut(uri, "?")
+
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		values = url.Values{}
+	}
+
+	encoded := req.Query.Encode(values)
+	if encoded == "" {
+		return base
+	}
+
+	return fmt.Sprintf("%s?%s", base, encoded)
+}
+
+func makeHeaders(req *action.Request) []Header {
+	headers := make([]Header, 0)
+
+	for _, k := range sortedKeys(req.Header.Headers) {
+		for _, v := range req.Header.Headers[k] {
+			if !v.Status {
+				continue
+			}
+			headers = append(headers, Header{
+				Key:   k,
+				Value: strings.TrimSpace(v.Value),
+			})
+		}
+	}
+
+	cookies := make([]string, 0)
+	for _, k := range sortedKeys(req.Cookie.Cookies) {
+		cookie := req.Cookie.Cookies[k]
+		if !cookie.Status {
+			continue
+		}
+		cookies = append(cookies, fmt.Sprintf("%s=%s", k, strings.TrimSpace(cookie.Value)))
+	}
+
+	if len(cookies) > 0 {
+		headers = append(headers, Header{
+			Key:   "Cookie",
+			Value: strings.Join(cookies, "; "),
+		})
+	}
+
+	return headers
+}
+
+func makeBody(req *action.Request) Body {
+	method := req.Method.String()
+	if !req.Body.Status || req.Body.Empty() || method == "GET" || method == "HEAD" {
+		return Body{Kind: BODY_NONE}
+	}
+
+	if req.Body.ContentType == domain.Form {
+		return makeFormBody(req)
+	}
+
+	payload := req.Body.Parameters[body_strategy.DOCUMENT_PARAM][body_strategy.PAYLOAD_PARAM]
+	if len(payload) == 0 || payload[0].IsFile {
+		return Body{Kind: BODY_NONE}
+	}
+
+	return Body{
+		Kind:        BODY_RAW,
+		ContentType: req.Body.ContentType.ToHeader(),
+		Raw:         payload[0].Value,
+	}
+}
+
+func makeFormBody(req *action.Request) Body {
+	form := req.Body.Parameters[body_strategy.FORM_DATA_PARAM]
+
+	fields := make([]Field, 0)
+	for _, k := range sortedKeys(form) {
+		for _, v := range form[k] {
+			if !v.Status {
+				continue
+			}
+			fields = append(fields, Field{
+				Name:     k,
+				Value:    v.Value,
+				IsFile:   v.IsFile,
+				FileName: v.FileName,
+				FileType: v.FileType,
+			})
+		}
+	}
+
+	return Body{
+		Kind:        BODY_FORM,
+		ContentType: req.Body.ContentType.ToHeader(),
+		Fields:      fields,
+	}
+}
+
+func sortedKeys[T any](items map[string]T) []string {
+	keys := make([]string, 0, len(items))
+	for k := range items {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// shellQuote wraps the value in single quotes for POSIX shells.
+func shellQuote(value string) string {
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(value, "'", `'\''`))
+}
+
+// quoteString renders a double quoted literal valid in Go, Python and
+// JavaScript sources.
+func quoteString(value string) string {
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return strconv.Quote(value)
+	}
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
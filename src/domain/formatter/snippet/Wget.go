@@ -0,0 +1,41 @@
+package snippet
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+func marshalWget(snapshot *Snapshot) (string, error) {
+	buffer := []string{
+		"wget --quiet",
+		fmt.Sprintf("--method=%s", snapshot.Method),
+	}
+
+	for _, v := range snapshot.Headers {
+		buffer = append(buffer, fmt.Sprintf("--header=%s", shellQuote(fmt.Sprintf("%s: %s", v.Key, v.Value))))
+	}
+
+	switch snapshot.Body.Kind {
+	case BODY_RAW:
+		buffer = append(buffer, fmt.Sprintf("--body-data=%s", shellQuote(snapshot.Body.Raw)))
+	case BODY_FORM:
+		// wget cannot build multipart payloads, text fields are sent url encoded.
+		values := url.Values{}
+		for _, v := range snapshot.Body.Fields {
+			if v.IsFile {
+				return "", errors.New("wget cannot send multipart files")
+			}
+			values.Add(v.Name, v.Value)
+		}
+		buffer = append(buffer,
+			fmt.Sprintf("--header=%s", shellQuote("Content-Type: application/x-www-form-urlencoded")),
+			fmt.Sprintf("--body-data=%s", shellQuote(values.Encode())),
+		)
+	}
+
+	buffer = append(buffer, "--output-document=-", shellQuote(snapshot.Url))
+
+	return strings.Join(buffer, " \\\n  "), nil
+}
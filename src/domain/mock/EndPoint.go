@@ -10,6 +10,7 @@ import (
 	"github.com/Rafael24595/go-api-core/src/domain/action/body"
 	"github.com/Rafael24595/go-api-core/src/domain/action/cookie"
 	"github.com/Rafael24595/go-api-core/src/domain/action/header"
+	"github.com/Rafael24595/go-api-core/src/domain/action/param"
 	"github.com/Rafael24595/go-api-core/src/domain/action/query"
 	"github.com/Rafael24595/go-api-core/src/domain/mock/swr"
 	"github.com/Rafael24595/go-collections/collection"
@@ -27,6 +28,7 @@ type EndPoint struct {
 	Responses []Response        `json:"responses"`
 	Safe      bool              `json:"safe"`
 	Owner     string            `json:"owner"`
+	Tags      []string          `json:"tags"`
 }
 
 func (r EndPoint) DefaultResponse() Response {
@@ -44,6 +46,7 @@ func ToRequest(server string, endPoint *EndPoint) *action.Request {
 		Name:      endPoint.Name,
 		Method:    endPoint.Method,
 		Uri:       fmt.Sprintf("%s%s", server, endPoint.Path),
+		Param:     *param.NewParams(),
 		Query:     *query.NewQueries(),
 		Header:    *header.NewHeaders(),
 		Cookie:    *cookie.NewCookiesClient(),
@@ -67,6 +70,7 @@ type EndPointLite struct {
 	Responses []string          `json:"responses"`
 	Safe      bool              `json:"safe"`
 	Owner     string            `json:"owner"`
+	Tags      []string          `json:"tags"`
 }
 
 func LiteFromEndPoint(endPoint *EndPoint) *EndPointLite {
@@ -86,6 +90,7 @@ func LiteFromEndPoint(endPoint *EndPoint) *EndPointLite {
 		Responses: keys.Collect(),
 		Safe:      endPoint.Safe,
 		Owner:     endPoint.Owner,
+		Tags:      endPoint.Tags,
 	}
 }
 
@@ -101,6 +106,7 @@ type EndPointFull struct {
 	Responses []ResponseFull    `json:"responses"`
 	Safe      bool              `json:"safe"`
 	Owner     string            `json:"owner"`
+	Tags      []string          `json:"tags"`
 }
 
 func FullFromEndPoint(endPoint *EndPoint) (*EndPointFull, []error) {
@@ -129,6 +135,7 @@ func FullFromEndPoint(endPoint *EndPoint) (*EndPointFull, []error) {
 		Responses: responses,
 		Safe:      endPoint.Safe,
 		Owner:     endPoint.Owner,
+		Tags:      endPoint.Tags,
 	}, errs
 }
 
@@ -157,6 +164,7 @@ func ToEndPointFromFull(endPoint *EndPointFull) (*EndPoint, []error) {
 		Responses: responses,
 		Safe:      endPoint.Safe,
 		Owner:     endPoint.Owner,
+		Tags:      endPoint.Tags,
 	}, make([]error, 0)
 }
 
@@ -188,6 +196,7 @@ func FixEndPoint(owner string, endPoint *EndPoint) *EndPoint {
 	}
 
 	endPoint.Modified = time.Now().UnixMilli()
+	endPoint.Tags = domain.NormalizeTags(endPoint.Tags...)
 
 	if endPoint.Name == "" {
 		endPoint.Name = endPoint.Path
@@ -196,6 +205,12 @@ func FixEndPoint(owner string, endPoint *EndPoint) *EndPoint {
 	return endPoint
 }
 
+// FindPathResponse finds the response of the end point for the request path,
+// the values of the templated path segments are available as arguments.
+func FindPathResponse(payload, path string, arguments map[string]string, endPoint *EndPoint) (*Response, bool) {
+	return FindResponse(payload, PathArguments(endPoint, path, arguments), endPoint)
+}
+
 func FindResponse(payload string, arguments map[string]string, endPoint *EndPoint) (*Response, bool) {
 	vecResp := collection.VectorFromList(endPoint.Responses).
 		Sort(func(i, j Response) bool {
@@ -0,0 +1,63 @@
+package mock
+
+import (
+	"strings"
+)
+
+// MatchPath matches the path against an end point path that may contain
+// OpenAPI templates such as "/users/{id}", each template matches one path
+// segment. It returns the values of the templated segments by name.
+func MatchPath(template, path string) (map[string]string, bool) {
+	params := make(map[string]string)
+	if template == path {
+		return params, true
+	}
+
+	templates := strings.Split(strings.Trim(template, "/"), "/")
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(templates) != len(segments) {
+		return nil, false
+	}
+
+	for i, v := range templates {
+		name, ok := templateName(v)
+		if !ok {
+			if v != segments[i] {
+				return nil, false
+			}
+			continue
+		}
+
+		if segments[i] == "" {
+			return nil, false
+		}
+		params[name] = segments[i]
+	}
+
+	return params, true
+}
+
+// PathArguments adds the templated path values of the end point to a copy of
+// the arguments, so SWR conditions can reference them as arguments. The
+// request arguments win over path values with the same name.
+func PathArguments(endPoint *EndPoint, path string, arguments map[string]string) map[string]string {
+	result := make(map[string]string, len(arguments))
+	if params, ok := MatchPath(endPoint.Path, path); ok {
+		for k, v := range params {
+			result[k] = v
+		}
+	}
+
+	for k, v := range arguments {
+		result[k] = v
+	}
+
+	return result
+}
+
+func templateName(segment string) (string, bool) {
+	if len(segment) < 3 || segment[0] != '{' || segment[len(segment)-1] != '}' {
+		return "", false
+	}
+	return segment[1 : len(segment)-1], true
+}
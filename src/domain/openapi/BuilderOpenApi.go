@@ -2,10 +2,26 @@ package openapi
 
 import (
 	"encoding/json"
+	"errors"
 
 	"gopkg.in/yaml.v3"
 )
 
+// MakeFromFile decodes a JSON or YAML specification.
+func MakeFromFile(file []byte) (*OpenAPI, *map[string]any, error) {
+	oapi, raw, err := MakeFromJson(file)
+	if err == nil {
+		return oapi, raw, nil
+	}
+
+	oapi, raw, err = MakeFromYaml(file)
+	if err != nil {
+		return nil, nil, errors.New("the provided file has an invalid format, it must be an JSON or YAML")
+	}
+
+	return oapi, raw, nil
+}
+
 func MakeFromYaml(file []byte) (*OpenAPI, *map[string]any, error) {
 	raw, err := DeserializeFromYaml(file)
 	if err != nil {
@@ -22,7 +38,7 @@ func DeserializeFromYaml(file []byte) (map[string]any, error) {
 		return nil, err
 	}
 
-	return raw, nil
+	return normalizeRaw(raw).(map[string]any), nil
 }
 
 func MakeFromJson(file []byte) (*OpenAPI, *map[string]any, error) {
@@ -35,7 +51,21 @@ func MakeFromJson(file []byte) (*OpenAPI, *map[string]any, error) {
 	return makeFromInterface(&raw)
 }
 
+func MakeFromBundle(bundle *Bundle) (*OpenAPI, *map[string]any, error) {
+	raw, err := bundle.Load()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return makeFromInterface(&raw)
+}
+
 func makeFromInterface(raw *map[string]any) (*OpenAPI, *map[string]any, error) {
+	if IsSwagger(*raw) {
+		converted := ConvertSwagger(*raw)
+		raw = &converted
+	}
+
 	jsonData, err := json.Marshal(raw)
 	if err != nil {
 		return nil, raw, err
@@ -48,4 +78,32 @@ func makeFromInterface(raw *map[string]any) (*OpenAPI, *map[string]any, error) {
 	}
 
 	return &openAPI, raw, nil
-}
\ No newline at end of file
+}
+
+func SerializeToJson(oapi *OpenAPI) ([]byte, error) {
+	return json.MarshalIndent(oapi, "", "  ")
+}
+
+func SerializeToYaml(oapi *OpenAPI) ([]byte, error) {
+	jsonData, err := json.Marshal(oapi)
+	if err != nil {
+		return nil, err
+	}
+
+	var node yaml.Node
+	err = yaml.Unmarshal(jsonData, &node)
+	if err != nil {
+		return nil, err
+	}
+
+	clearNodeStyle(&node)
+
+	return yaml.Marshal(&node)
+}
+
+func clearNodeStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, v := range node.Content {
+		clearNodeStyle(v)
+	}
+}
@@ -0,0 +1,480 @@
+package openapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	SWAGGER_VERSION      = "2.0"
+	DEFAULT_MEDIA_TYPE   = "application/json"
+	FORM_DATA_MEDIA_TYPE = "multipart/form-data"
+	URL_ENCODED_TYPE     = "application/x-www-form-urlencoded"
+)
+
+var swaggerMethods = []string{"get", "put", "post", "delete", "options", "head", "patch"}
+
+var swaggerReferences = map[string]string{
+	"#/definitions/":         "#/components/schemas/",
+	"#/responses/":           "#/components/responses/",
+	"#/securityDefinitions/": "#/components/securitySchemes/",
+}
+
+// IsSwagger reports whether the raw document declares the Swagger 2.0 format.
+func IsSwagger(raw map[string]any) bool {
+	version, ok := raw["swagger"]
+	return ok && fmt.Sprintf("%v", version) == SWAGGER_VERSION
+}
+
+// ConvertSwagger translates a raw Swagger 2.0 document into the equivalent
+// OpenAPI 3.0 structure so the rest of the importer can treat both the same way.
+func ConvertSwagger(raw map[string]any) map[string]any {
+	raw = normalizeRaw(raw).(map[string]any)
+	raw = rewriteReferences(raw).(map[string]any)
+
+	converter := swaggerConverter{
+		raw:        raw,
+		consumes:   asStrings(raw["consumes"]),
+		produces:   asStrings(raw["produces"]),
+		parameters: asMap(raw["parameters"]),
+	}
+
+	result := map[string]any{
+		"openapi": "3.0.0",
+		"info":    raw["info"],
+		"servers": converter.servers(),
+		"paths":   converter.paths(),
+		"components": map[string]any{
+			"schemas":         asMap(raw["definitions"]),
+			"securitySchemes": converter.securitySchemes(),
+			"responses":       converter.responses(asMap(raw["responses"]), converter.produces),
+		},
+	}
+
+	if security, ok := raw["security"]; ok {
+		result["security"] = security
+	}
+	if tags, ok := raw["tags"]; ok {
+		result["tags"] = tags
+	}
+
+	return result
+}
+
+type swaggerConverter struct {
+	raw        map[string]any
+	consumes   []string
+	produces   []string
+	parameters map[string]any
+}
+
+func (c swaggerConverter) servers() []any {
+	host, _ := c.raw["host"].(string)
+	basePath, _ := c.raw["basePath"].(string)
+	basePath = strings.TrimSuffix(basePath, "/")
+
+	if host == "" {
+		if basePath == "" {
+			return []any{}
+		}
+		return []any{map[string]any{"url": basePath}}
+	}
+
+	schemes := asStrings(c.raw["schemes"])
+	if len(schemes) == 0 {
+		schemes = []string{"https"}
+	}
+
+	servers := make([]any, 0, len(schemes))
+	for _, v := range schemes {
+		servers = append(servers, map[string]any{
+			"url": fmt.Sprintf("%s://%s%s", v, host, basePath),
+		})
+	}
+
+	return servers
+}
+
+func (c swaggerConverter) paths() map[string]any {
+	paths := make(map[string]any)
+	for path, v := range asMap(c.raw["paths"]) {
+		item := asMap(v)
+		shared := c.resolveParameters(asSlice(item["parameters"]))
+
+		result := make(map[string]any)
+		for _, method := range swaggerMethods {
+			operation, ok := item[method]
+			if !ok {
+				continue
+			}
+			result[method] = c.operation(asMap(operation), shared)
+		}
+
+		paths[path] = result
+	}
+	return paths
+}
+
+func (c swaggerConverter) operation(operation map[string]any, shared []map[string]any) map[string]any {
+	consumes := c.consumes
+	if values, ok := operation["consumes"]; ok {
+		consumes = asStrings(values)
+	}
+
+	produces := c.produces
+	if values, ok := operation["produces"]; ok {
+		produces = asStrings(values)
+	}
+
+	result := make(map[string]any)
+	for k, v := range operation {
+		switch k {
+		case "consumes", "produces", "parameters", "responses", "schemes":
+			continue
+		}
+		result[k] = v
+	}
+
+	parameters := mergeParameters(shared, c.resolveParameters(asSlice(operation["parameters"])))
+
+	converted := make([]any, 0)
+	form := make([]map[string]any, 0)
+	for _, v := range parameters {
+		switch v["in"] {
+		case "body":
+			result["requestBody"] = c.bodyParameter(v, consumes)
+		case "formData":
+			form = append(form, v)
+		default:
+			converted = append(converted, c.parameter(v))
+		}
+	}
+
+	if len(form) > 0 {
+		result["requestBody"] = c.formParameters(form, consumes)
+	}
+
+	if len(converted) > 0 {
+		result["parameters"] = converted
+	}
+
+	result["responses"] = c.responses(asMap(operation["responses"]), produces)
+
+	return result
+}
+
+func (c swaggerConverter) resolveParameters(parameters []any) []map[string]any {
+	result := make([]map[string]any, 0, len(parameters))
+	for _, v := range parameters {
+		parameter := asMap(v)
+		if ref, ok := parameter["$ref"].(string); ok {
+			name := strings.TrimPrefix(ref, "#/parameters/")
+			parameter = asMap(c.parameters[name])
+		}
+		if len(parameter) > 0 {
+			result = append(result, parameter)
+		}
+	}
+	return result
+}
+
+func (c swaggerConverter) parameter(parameter map[string]any) map[string]any {
+	result := map[string]any{
+		"name":   parameter["name"],
+		"in":     parameter["in"],
+		"schema": parameterSchema(parameter),
+	}
+
+	for _, k := range []string{"required", "description"} {
+		if v, ok := parameter[k]; ok {
+			result[k] = v
+		}
+	}
+
+	if example, ok := parameter["x-example"]; ok {
+		result["example"] = example
+	}
+
+	if format, ok := parameter["collectionFormat"].(string); ok && parameter["type"] == "array" {
+		style, explode := collectionStyle(format)
+		if parameter["in"] != "query" && style == "form" {
+			style = "simple"
+		}
+		result["style"] = style
+		result["explode"] = explode
+	}
+
+	return result
+}
+
+func (c swaggerConverter) bodyParameter(parameter map[string]any, consumes []string) map[string]any {
+	if len(consumes) == 0 {
+		consumes = []string{DEFAULT_MEDIA_TYPE}
+	}
+
+	content := make(map[string]any)
+	for _, v := range consumes {
+		content[v] = map[string]any{
+			"schema": parameter["schema"],
+		}
+	}
+
+	result := map[string]any{
+		"content": content,
+	}
+
+	for _, k := range []string{"required", "description"} {
+		if v, ok := parameter[k]; ok {
+			result[k] = v
+		}
+	}
+
+	return result
+}
+
+func (c swaggerConverter) formParameters(parameters []map[string]any, consumes []string) map[string]any {
+	mediaType := URL_ENCODED_TYPE
+	for _, v := range consumes {
+		if v == FORM_DATA_MEDIA_TYPE {
+			mediaType = FORM_DATA_MEDIA_TYPE
+		}
+	}
+
+	properties := make(map[string]any)
+	required := make([]any, 0)
+	for _, v := range parameters {
+		name := fmt.Sprintf("%v", v["name"])
+		if v["type"] == "file" {
+			mediaType = FORM_DATA_MEDIA_TYPE
+		}
+		properties[name] = parameterSchema(v)
+		if isRequired, _ := v["required"].(bool); isRequired {
+			required = append(required, name)
+		}
+	}
+
+	schema := map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+
+	return map[string]any{
+		"content": map[string]any{
+			mediaType: map[string]any{
+				"schema": schema,
+			},
+		},
+	}
+}
+
+func (c swaggerConverter) responses(responses map[string]any, produces []string) map[string]any {
+	if len(produces) == 0 {
+		produces = []string{DEFAULT_MEDIA_TYPE}
+	}
+
+	result := make(map[string]any)
+	for code, v := range responses {
+		response := asMap(v)
+		if ref, ok := response["$ref"]; ok {
+			result[code] = map[string]any{"$ref": ref}
+			continue
+		}
+
+		converted := map[string]any{
+			"description": response["description"],
+		}
+
+		examples := asMap(response["examples"])
+		if schema, ok := response["schema"]; ok {
+			content := make(map[string]any)
+			for _, mediaType := range produces {
+				media := map[string]any{
+					"schema": schema,
+				}
+				if example, ok := examples[mediaType]; ok {
+					media["example"] = example
+				}
+				content[mediaType] = media
+			}
+			converted["content"] = content
+		}
+
+		if headers, ok := response["headers"]; ok {
+			converted["headers"] = headers
+		}
+
+		result[code] = converted
+	}
+
+	return result
+}
+
+func (c swaggerConverter) securitySchemes() map[string]any {
+	result := make(map[string]any)
+	for name, v := range asMap(c.raw["securityDefinitions"]) {
+		definition := asMap(v)
+
+		scheme := make(map[string]any)
+		switch definition["type"] {
+		case "basic":
+			scheme["type"] = "http"
+			scheme["scheme"] = "basic"
+		case "apiKey":
+			scheme["type"] = "apiKey"
+			scheme["in"] = definition["in"]
+			scheme["name"] = definition["name"]
+		default:
+			for k, p := range definition {
+				scheme[k] = p
+			}
+		}
+
+		if description, ok := definition["description"]; ok {
+			scheme["description"] = description
+		}
+
+		result[name] = scheme
+	}
+	return result
+}
+
+func parameterSchema(parameter map[string]any) map[string]any {
+	schema := make(map[string]any)
+	for _, k := range []string{"type", "format", "items", "enum", "default", "minimum", "maximum", "pattern"} {
+		if v, ok := parameter[k]; ok {
+			schema[k] = v
+		}
+	}
+
+	if schema["type"] == "file" {
+		schema["type"] = "string"
+		schema["format"] = "binary"
+	}
+
+	if items, ok := schema["items"]; ok {
+		schema["items"] = parameterSchema(asMap(items))
+	}
+
+	if example, ok := parameter["x-example"]; ok {
+		schema["example"] = example
+	}
+
+	return schema
+}
+
+func mergeParameters(shared, own []map[string]any) []map[string]any {
+	result := make([]map[string]any, 0, len(shared)+len(own))
+	for _, v := range shared {
+		overridden := false
+		for _, o := range own {
+			if o["name"] == v["name"] && o["in"] == v["in"] {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			result = append(result, v)
+		}
+	}
+	return append(result, own...)
+}
+
+func collectionStyle(format string) (string, bool) {
+	switch format {
+	case "ssv":
+		return "spaceDelimited", false
+	case "pipes":
+		return "pipeDelimited", false
+	case "multi":
+		return "form", true
+	default:
+		return "form", false
+	}
+}
+
+func rewriteReferences(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for k, p := range v {
+			if ref, ok := p.(string); ok && k == "$ref" {
+				v[k] = rewriteReference(ref)
+				continue
+			}
+			v[k] = rewriteReferences(p)
+		}
+		return v
+	case []any:
+		for i, p := range v {
+			v[i] = rewriteReferences(p)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+func rewriteReference(ref string) string {
+	prefixes := make([]string, 0, len(swaggerReferences))
+	for k := range swaggerReferences {
+		prefixes = append(prefixes, k)
+	}
+	sort.Strings(prefixes)
+
+	for _, k := range prefixes {
+		if strings.HasPrefix(ref, k) {
+			return swaggerReferences[k] + strings.TrimPrefix(ref, k)
+		}
+	}
+	return ref
+}
+
+func normalizeRaw(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for k, p := range v {
+			v[k] = normalizeRaw(p)
+		}
+		return v
+	case map[any]any:
+		result := make(map[string]any, len(v))
+		for k, p := range v {
+			result[fmt.Sprintf("%v", k)] = normalizeRaw(p)
+		}
+		return result
+	case []any:
+		for i, p := range v {
+			v[i] = normalizeRaw(p)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+func asMap(value any) map[string]any {
+	if result, ok := value.(map[string]any); ok {
+		return result
+	}
+	return make(map[string]any)
+}
+
+func asSlice(value any) []any {
+	if result, ok := value.([]any); ok {
+		return result
+	}
+	return make([]any, 0)
+}
+
+func asStrings(value any) []string {
+	result := make([]string, 0)
+	for _, v := range asSlice(value) {
+		if str, ok := v.(string); ok {
+			result = append(result, str)
+		}
+	}
+	return result
+}
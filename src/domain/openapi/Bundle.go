@@ -0,0 +1,284 @@
+package openapi
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+var entryNames = []string{"openapi.yaml", "openapi.yml", "openapi.json", "swagger.yaml", "swagger.yml", "swagger.json"}
+
+// Bundle groups the files of a specification split across several documents.
+// References between them are resolved relative to the file that declares them.
+type Bundle struct {
+	Entry     string
+	Files     map[string][]byte
+	documents map[string]map[string]any
+}
+
+func NewBundle(entry string, files map[string][]byte) *Bundle {
+	normalized := make(map[string][]byte, len(files))
+	for k, v := range files {
+		normalized[cleanBundlePath(k)] = v
+	}
+
+	if entry != "" {
+		entry = cleanBundlePath(entry)
+	}
+
+	return &Bundle{
+		Entry:     entry,
+		Files:     normalized,
+		documents: make(map[string]map[string]any),
+	}
+}
+
+func BundleFromZip(data []byte) (*Bundle, error) {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]byte)
+	for _, v := range reader.File {
+		if v.FileInfo().IsDir() || !isSpecificationFile(v.Name) {
+			continue
+		}
+
+		file, err := v.Open()
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := io.ReadAll(file)
+		file.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		files[v.Name] = content
+	}
+
+	return NewBundle("", files), nil
+}
+
+func BundleFromDir(root string) (*Bundle, error) {
+	files := make(map[string][]byte)
+	err := filepath.WalkDir(root, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() || !isSpecificationFile(file) {
+			return nil
+		}
+
+		relative, err := filepath.Rel(root, file)
+		if err != nil {
+			return err
+		}
+
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		files[filepath.ToSlash(relative)] = content
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewBundle("", files), nil
+}
+
+// Load parses every file of the bundle and returns the raw entry document.
+func (b *Bundle) Load() (map[string]any, error) {
+	if b.Entry == "" {
+		entry, err := b.findEntry()
+		if err != nil {
+			return nil, err
+		}
+		b.Entry = entry
+	}
+
+	if _, ok := b.Files[b.Entry]; !ok {
+		return nil, fmt.Errorf("bundle entry '%s' not found", b.Entry)
+	}
+
+	for name, content := range b.Files {
+		document, err := deserializeDocument(content)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bundle file '%s': %s", name, err.Error())
+		}
+		b.documents[name] = b.canonicalizeReferences(name, document).(map[string]any)
+	}
+
+	return b.documents[b.Entry], nil
+}
+
+// Find resolves a canonical "file#/pointer" reference into the given item.
+func (b *Bundle) Find(ref string, item any) error {
+	file, pointer, _ := strings.Cut(ref, "#")
+	if file == "" {
+		file = b.Entry
+	}
+
+	document, ok := b.documents[file]
+	if !ok {
+		return fmt.Errorf("bundle file not found: %s", file)
+	}
+
+	value, ok := FindPointer(document, pointer)
+	if !ok {
+		return fmt.Errorf("reference not found: %s", ref)
+	}
+
+	return remarshal(value, item)
+}
+
+func (b *Bundle) findEntry() (string, error) {
+	candidates := make([]string, 0)
+	for name := range b.Files {
+		for _, entry := range entryNames {
+			if path.Base(name) == entry {
+				candidates = append(candidates, name)
+			}
+		}
+	}
+
+	if len(candidates) == 0 && len(b.Files) == 1 {
+		for name := range b.Files {
+			return name, nil
+		}
+	}
+
+	if len(candidates) == 0 {
+		return "", errors.New("the bundle does not contain an openapi or swagger entry file")
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		di := strings.Count(candidates[i], "/")
+		dj := strings.Count(candidates[j], "/")
+		if di != dj {
+			return di < dj
+		}
+		return candidates[i] < candidates[j]
+	})
+
+	return candidates[0], nil
+}
+
+func (b *Bundle) canonicalizeReferences(base string, value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for k, p := range v {
+			if ref, ok := p.(string); ok && k == "$ref" {
+				v[k] = b.canonicalReference(base, ref)
+				continue
+			}
+			v[k] = b.canonicalizeReferences(base, p)
+		}
+		return v
+	case []any:
+		for i, p := range v {
+			v[i] = b.canonicalizeReferences(base, p)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+func (b *Bundle) canonicalReference(base, ref string) string {
+	file, pointer, _ := strings.Cut(ref, "#")
+	if strings.Contains(file, "://") {
+		return ref
+	}
+
+	if file == "" {
+		if base == b.Entry {
+			return ref
+		}
+		file = base
+	} else {
+		file = cleanBundlePath(path.Join(path.Dir(base), file))
+	}
+
+	if file == b.Entry {
+		return "#" + pointer
+	}
+
+	return file + "#" + pointer
+}
+
+// FindPointer walks a decoded document following a JSON pointer.
+func FindPointer(document map[string]any, pointer string) (any, bool) {
+	var level any = document
+	if pointer == "" || pointer == "/" {
+		return level, true
+	}
+
+	for _, fragment := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		fragment = strings.ReplaceAll(fragment, "~1", "/")
+		fragment = strings.ReplaceAll(fragment, "~0", "~")
+
+		switch node := level.(type) {
+		case map[string]any:
+			value, ok := node[fragment]
+			if !ok {
+				return nil, false
+			}
+			level = value
+		case []any:
+			index := -1
+			if _, err := fmt.Sscanf(fragment, "%d", &index); err != nil || index < 0 || index >= len(node) {
+				return nil, false
+			}
+			level = node[index]
+		default:
+			return nil, false
+		}
+	}
+
+	return level, true
+}
+
+func deserializeDocument(content []byte) (map[string]any, error) {
+	var document map[string]any
+	if err := json.Unmarshal(content, &document); err == nil {
+		return document, nil
+	}
+	return DeserializeFromYaml(content)
+}
+
+func remarshal(value any, item any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, item)
+}
+
+func isSpecificationFile(name string) bool {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	default:
+		return false
+	}
+}
+
+func cleanBundlePath(name string) string {
+	return strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(name)), "/")
+}
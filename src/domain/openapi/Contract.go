@@ -0,0 +1,81 @@
+package openapi
+
+import (
+	"errors"
+	"time"
+)
+
+// Contract is the OpenAPI specification a collection was imported from, it
+// is kept to validate the requests of the collection and to compare the
+// next versions of the specification. The files of a single file import are
+// stored without an entry.
+type Contract struct {
+	Id        string            `json:"_id"`
+	Timestamp int64             `json:"timestamp"`
+	Entry     string            `json:"entry"`
+	Files     map[string]string `json:"files"`
+	Owner     string            `json:"owner"`
+}
+
+func NewFileContract(owner, collection string, file []byte) *Contract {
+	return &Contract{
+		Id:        collection,
+		Timestamp: time.Now().UnixMilli(),
+		Entry:     "",
+		Files:     map[string]string{"": string(file)},
+		Owner:     owner,
+	}
+}
+
+func NewBundleContract(owner, collection string, bundle *Bundle) *Contract {
+	files := make(map[string]string, len(bundle.Files))
+	for k, v := range bundle.Files {
+		files[k] = string(v)
+	}
+
+	return &Contract{
+		Id:        collection,
+		Timestamp: time.Now().UnixMilli(),
+		Entry:     bundle.Entry,
+		Files:     files,
+		Owner:     owner,
+	}
+}
+
+func (c Contract) PersistenceId() string {
+	return c.Id
+}
+
+// Load parses the stored specification, the bundle is nil for single file
+// contracts.
+func (c Contract) Load() (*OpenAPI, *map[string]any, *Bundle, error) {
+	if c.Entry == "" {
+		file, ok := c.Files[""]
+		if !ok {
+			return nil, nil, nil, errors.New("the contract has no specification file")
+		}
+		oapi, raw, err := MakeFromFile([]byte(file))
+		return oapi, raw, nil, err
+	}
+
+	files := make(map[string][]byte, len(c.Files))
+	for k, v := range c.Files {
+		files[k] = []byte(v)
+	}
+
+	bundle := NewBundle(c.Entry, files)
+	oapi, raw, err := MakeFromBundle(bundle)
+	return oapi, raw, bundle, err
+}
+
+// Validator returns the validator of the stored specification.
+func (c Contract) Validator() (*ValidatorContract, error) {
+	oapi, raw, bundle, err := c.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewValidatorContract(oapi).
+		SetRaw(*raw).
+		SetBundle(bundle), nil
+}
@@ -1,13 +1,16 @@
 package openapi
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"maps"
+	"math"
+	"net/url"
+	"sort"
 	"strings"
 	"time"
 
-	"github.com/Rafael24595/go-api-core/src/commons/utils"
 	"github.com/Rafael24595/go-api-core/src/domain"
 	"github.com/Rafael24595/go-api-core/src/domain/action"
 	"github.com/Rafael24595/go-api-core/src/domain/action/auth"
@@ -16,15 +19,22 @@ import (
 	body_strategy "github.com/Rafael24595/go-api-core/src/domain/action/body/strategy"
 	"github.com/Rafael24595/go-api-core/src/domain/action/cookie"
 	"github.com/Rafael24595/go-api-core/src/domain/action/header"
+	"github.com/Rafael24595/go-api-core/src/domain/action/param"
 	"github.com/Rafael24595/go-api-core/src/domain/action/query"
 	"github.com/Rafael24595/go-api-core/src/domain/collection"
 	"github.com/Rafael24595/go-api-core/src/domain/context"
 )
 
+const (
+	WEBHOOK_KEY         = "webhook"
+	WEBHOOK_DEFAULT_URL = "http://localhost:8080"
+)
+
 type FactoryCollection struct {
 	owner   string
 	openapi OpenAPI
 	raw     map[string]any
+	bundle  *Bundle
 }
 
 type BuildParameter struct {
@@ -47,38 +57,19 @@ func (b *FactoryCollection) SetRaw(raw map[string]any) *FactoryCollection {
 	return b
 }
 
+func (b *FactoryCollection) SetBundle(bundle *Bundle) *FactoryCollection {
+	b.bundle = bundle
+	return b
+}
+
 func (b *FactoryCollection) Make() (*collection.Collection, *context.Context, []action.Request, error) {
 	now := time.Now().UnixMilli()
 
-	ctx := context.NewContext(b.owner)
-	nodes := make([]action.Request, 0)
+	ctx, operations := b.makeOperations()
 
-	server := ""
-	for i, v := range b.openapi.Servers {
-		key := fmt.Sprintf("server-%d", i)
-		ctx.Put(context.URI, fmt.Sprintf("server-%d", i), v.URL, false)
-		server = fmt.Sprintf("${%s}", key)
-	}
-
-	var node *action.Request
-	for path, v := range b.openapi.Paths {
-		pathFull := fmt.Sprintf("%s%s", server, path)
-		if operation := v.Get; operation != nil {
-			ctx, node = b.MakeFromOperation(domain.GET, pathFull, operation, ctx)
-			nodes = append(nodes, *node)
-		}
-		if operation := v.Post; operation != nil {
-			ctx, node = b.MakeFromOperation(domain.POST, pathFull, operation, ctx)
-			nodes = append(nodes, *node)
-		}
-		if operation := v.Put; operation != nil {
-			ctx, node = b.MakeFromOperation(domain.PUT, pathFull, operation, ctx)
-			nodes = append(nodes, *node)
-		}
-		if operation := v.Delete; operation != nil {
-			ctx, node = b.MakeFromOperation(domain.DELETE, pathFull, operation, ctx)
-			nodes = append(nodes, *node)
-		}
+	nodes := make([]action.Request, len(operations))
+	for i, v := range operations {
+		nodes[i] = v.request
 	}
 
 	return &collection.Collection{
@@ -93,6 +84,88 @@ func (b *FactoryCollection) Make() (*collection.Collection, *context.Context, []
 	}, ctx, nodes, nil
 }
 
+// operationRequest links a generated request with the operation and the
+// path template it was built from.
+type operationRequest struct {
+	path      string
+	operation *Operation
+	request   action.Request
+}
+
+func (b *FactoryCollection) makeOperations() (*context.Context, []operationRequest) {
+	ctx := context.NewContext(b.owner)
+	nodes := make([]operationRequest, 0)
+
+	server := ""
+	for i, v := range b.openapi.Servers {
+		key := fmt.Sprintf("server-%d", i)
+		ctx.Put(context.URI, fmt.Sprintf("server-%d", i), v.URL, false)
+		server = fmt.Sprintf("${%s}", key)
+	}
+
+	var items []operationRequest
+	for _, path := range sortedKeys(b.openapi.Paths) {
+		ctx, items = b.makeFromPathItem(server, path, b.openapi.Paths[path], ctx)
+		nodes = append(nodes, items...)
+	}
+
+	if len(b.openapi.Webhooks) > 0 {
+		ctx.Put(context.URI, WEBHOOK_KEY, WEBHOOK_DEFAULT_URL, false)
+	}
+
+	for _, name := range sortedKeys(b.openapi.Webhooks) {
+		server := fmt.Sprintf("${%s}", WEBHOOK_KEY)
+		ctx, items = b.makeFromPathItem(server, "/"+url.PathEscape(name), b.openapi.Webhooks[name], ctx)
+		for i := range items {
+			items[i].request.Name = fmt.Sprintf("Webhook / %s", items[i].request.Name)
+		}
+		nodes = append(nodes, items...)
+	}
+
+	return ctx, nodes
+}
+
+func (b *FactoryCollection) makeFromPathItem(server, path string, item PathItem, ctx *context.Context) (*context.Context, []operationRequest) {
+	nodes := make([]operationRequest, 0)
+	for _, v := range pathOperations(item) {
+		var node *action.Request
+		ctx, node = b.MakeFromOperation(v.method, server+path, v.operation, ctx)
+		nodes = append(nodes, operationRequest{
+			path:      path,
+			operation: v.operation,
+			request:   *node,
+		})
+	}
+
+	return ctx, nodes
+}
+
+type pathOperation struct {
+	method    domain.HttpMethod
+	operation *Operation
+}
+
+func pathOperations(item PathItem) []pathOperation {
+	operations := []pathOperation{
+		{domain.GET, item.Get},
+		{domain.POST, item.Post},
+		{domain.PUT, item.Put},
+		{domain.DELETE, item.Delete},
+		{domain.PATCH, item.Patch},
+		{domain.HEAD, item.Head},
+		{domain.OPTIONS, item.Options},
+	}
+
+	result := make([]pathOperation, 0)
+	for _, v := range operations {
+		if v.operation != nil {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
 func (b *FactoryCollection) MakeFromOperation(method domain.HttpMethod, path string, operation *Operation, ctx *context.Context) (*context.Context, *action.Request) {
 	now := time.Now().UnixMilli()
 
@@ -101,7 +174,7 @@ func (b *FactoryCollection) MakeFromOperation(method domain.HttpMethod, path str
 		name = operation.Summary
 	}
 
-	path, ctx, queries, headers, cookies := b.MakeFromParameters(path, operation.Parameters, ctx)
+	path, ctx, params, queries, headers, cookies := b.MakeFromParameters(path, operation.Parameters, ctx)
 	payload := b.MakeFromRequestBody(operation.RequestBody)
 	auth := b.MakeFromSecurity(operation.Security, headers)
 
@@ -111,6 +184,7 @@ func (b *FactoryCollection) MakeFromOperation(method domain.HttpMethod, path str
 		Name:      name,
 		Method:    method,
 		Uri:       path,
+		Param:     *params,
 		Query:     *queries,
 		Header:    *headers,
 		Cookie:    *cookies,
@@ -122,7 +196,8 @@ func (b *FactoryCollection) MakeFromOperation(method domain.HttpMethod, path str
 	}
 }
 
-func (b *FactoryCollection) MakeFromParameters(path string, parameters []Parameter, ctx *context.Context) (string, *context.Context, *query.Queries, *header.Headers, *cookie.CookiesClient) {
+func (b *FactoryCollection) MakeFromParameters(path string, parameters []Parameter, ctx *context.Context) (string, *context.Context, *param.Params, *query.Queries, *header.Headers, *cookie.CookiesClient) {
+	params := param.NewParams()
 	queries := query.NewQueries()
 	headers := header.NewHeaders()
 	cookies := cookie.NewCookiesClient()
@@ -130,12 +205,11 @@ func (b *FactoryCollection) MakeFromParameters(path string, parameters []Paramet
 	for _, v := range parameters {
 		switch v.In {
 		case "path":
-			ctx.Put(context.URI, v.Name, v.Description, false)
-			placeholder := fmt.Sprintf("{%s}", v.Name)
-			replacement := fmt.Sprintf("${%s}", v.Name)
-			path = strings.ReplaceAll(path, placeholder, replacement)
+			order := int64(len(params.Params))
+			example := b.parameterExample(v)
+			params.AddParam(param.NewParam(order, example != "", v.Name, example, v.Description))
 		case "query":
-			queries.Add(v.Name, v.Description)
+			b.makeFromQueryParameter(queries, v)
 		case "header":
 			headers.Add(v.Name, v.Description)
 		case "cookie":
@@ -143,7 +217,66 @@ func (b *FactoryCollection) MakeFromParameters(path string, parameters []Paramet
 		}
 	}
 
-	return path, ctx, queries, headers, cookies
+	return path, ctx, params, queries, headers, cookies
+}
+
+func (b *FactoryCollection) makeFromQueryParameter(queries *query.Queries, parameter Parameter) *query.Queries {
+	serialization := b.querySerialization(parameter)
+	queries.PutSerialization(parameter.Name, serialization)
+
+	schema, err := b.findSchema(&parameter.Schema)
+	if err != nil || schema.Type != "object" || len(schema.Properties) == 0 {
+		return queries.Add(parameter.Name, parameter.Description)
+	}
+
+	if serialization.Style != query.DEEP_OBJECT && serialization.Style != query.FORM {
+		return queries.Add(parameter.Name, parameter.Description)
+	}
+
+	properties := make([]string, 0, len(schema.Properties))
+	for k := range schema.Properties {
+		properties = append(properties, k)
+	}
+	sort.Strings(properties)
+
+	for i, k := range properties {
+		value := ""
+		if example := schema.Properties[k].Example; example != nil {
+			value = fmt.Sprintf("%v", example)
+		}
+		queries.AddQuery(parameter.Name, query.NewPropertyQuery(int64(i), true, k, value))
+	}
+
+	return queries
+}
+
+func (b *FactoryCollection) querySerialization(parameter Parameter) query.Serialization {
+	style := query.FORM
+	if parameter.Style != "" {
+		if result, err := query.StyleFromString(parameter.Style); err == nil {
+			style = *result
+		}
+	}
+
+	explode := style == query.FORM
+	if parameter.Explode != nil {
+		explode = *parameter.Explode
+	}
+
+	return query.NewSerialization(style, explode)
+}
+
+func (b *FactoryCollection) parameterExample(parameter Parameter) string {
+	example := parameter.Example
+	if example == nil {
+		example = parameter.Schema.Example
+	}
+
+	if example == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%v", example)
 }
 
 func (b *FactoryCollection) MakeFromRequestBody(requestBody *RequestBody) *body.BodyRequest {
@@ -159,34 +292,54 @@ func (b *FactoryCollection) MakeFromRequestBody(requestBody *RequestBody) *body.
 		return b.MakeFromRequestBody(reference)
 	}
 
-	for k, v := range requestBody.Content {
-		schema, err := b.findSchema(&v.Schema)
-		if schema == nil {
-			continue
-		}
-		if err != nil {
-			break
+	for _, k := range sortedKeys(requestBody.Content) {
+		if payload, ok := b.MakeFromMediaType(k, requestBody.Content[k]); ok {
+			return payload
 		}
+	}
 
-		if schema.Example != nil && schema.Example != "" {
-			return b.fromExample(k, schema)
-		}
+	return body.EmptyBody(false, domain.Text)
+}
 
-		parameters, _ := b.MakeFromSchema(k, schema, make(map[string]int))
+func (b *FactoryCollection) MakeFromMediaType(content string, mediaType MediaType) (*body.BodyRequest, bool) {
+	if example, ok := mediaTypeExample(mediaType); ok {
+		return b.fromExample(content, example), true
+	}
+
+	schema, err := b.findSchema(&mediaType.Schema)
+	if schema == nil || err != nil {
+		return nil, false
+	}
 
-		switch k {
-		case "multipart/form-data":
-			return b.toFormData(parameters)
-		default:
-			return b.toDocument(k, schema, parameters)
+	schema = b.resolveSchema(schema, make(map[string]bool))
+	if schema == nil {
+		return nil, false
+	}
+
+	if example, ok := schemaExample(schema); ok {
+		return b.fromExample(content, example), true
+	}
+
+	if parameter, ok := b.makeFromPrimitiveProperty("value", schema); ok && schemaType(schema) != "array" {
+		var value any = parameter.Value
+		if err := json.Unmarshal([]byte(parameter.Value), &value); err != nil {
+			value = parameter.Value
 		}
+		return b.fromExample(content, value), true
 	}
 
-	return body.EmptyBody(false, domain.Text)
+	parameters, _ := b.MakeFromSchema(content, schema, make(map[string]int))
+
+	switch content {
+	case "multipart/form-data":
+		return b.toFormData(parameters), true
+	default:
+		return b.toDocument(content, schema, parameters), true
+	}
 }
 
-func (b *FactoryCollection) fromExample(content string, schema *Schema) *body.BodyRequest {
-	example, err := json.Marshal(schema.Example)
+func (b *FactoryCollection) fromExample(content string, value any) *body.BodyRequest {
+	example, err := json.Marshal(value)
 	if err != nil {
 		fmt.Printf("%s", err.Error())
 	}
@@ -203,6 +356,10 @@ func (b *FactoryCollection) fromExample(content string, schema *Schema) *body.Bo
 		bodyType = domain.Text
 	}
 
+	if text, ok := value.(string); ok && bodyType != domain.Json {
+		example = []byte(text)
+	}
+
 	if bodyType != domain.Form {
 		return body_strategy.DocumentBody(false, bodyType, string(example))
 	}
@@ -214,7 +371,8 @@ func (b *FactoryCollection) toFormData(parameters map[string]BuildParameter) *bo
 	builder := body_strategy.NewBuilderFromDataBody()
 
 	count := int64(0)
-	for k, v := range parameters {
+	for _, k := range sortedKeys(parameters) {
+		v := parameters[k]
 		parameter := &body.BodyParameter{
 			Order:    count,
 			Status:   true,
@@ -253,7 +411,8 @@ func (b *FactoryCollection) formatDocument(content string, schema *Schema, param
 func (b *FactoryCollection) formatXml(parameters map[string]BuildParameter) string {
 	lines := make([]string, 0)
 
-	for k, v := range parameters {
+	for _, k := range sortedKeys(parameters) {
+		v := parameters[k]
 		value := v.Value
 		if v.Children != nil {
 			value = b.formatJson(*v.Children, v.Vector)
@@ -269,7 +428,8 @@ func (b *FactoryCollection) formatXml(parameters map[string]BuildParameter) stri
 func (b *FactoryCollection) formatJson(parameters map[string]BuildParameter, vector bool) string {
 	lines := make([]string, 0)
 
-	for k, v := range parameters {
+	for _, k := range sortedKeys(parameters) {
+		v := parameters[k]
 		value := v.Value
 		if v.Children != nil {
 			value = b.formatJson(*v.Children, v.Vector)
@@ -294,12 +454,36 @@ func (b *FactoryCollection) MakeFromSchema(content string, schema *Schema, visit
 		return b.makeFromReference(content, schema, visited)
 	}
 
+	if isComposed(schema) {
+		refs := composedRefs(schema)
+		for _, ref := range refs {
+			if _, hasVisited := visited[ref]; hasVisited {
+				return circularParameter(ref), visited
+			}
+		}
+		for _, ref := range refs {
+			visited[ref] = 0
+		}
+
+		resolved := b.resolveSchema(schema, make(map[string]bool))
+		if resolved == nil {
+			return make(map[string]BuildParameter), visited
+		}
+		return b.MakeFromSchema(content, resolved, visited)
+	}
+
 	if schema.Items != nil {
-		return b.makeFromReference(content, schema.Items, visited)
+		return b.MakeFromSchema(content, schema.Items, visited)
 	}
 
 	if len(schema.Properties) > 0 {
-		return b.makeFromProperties(content, schema, visited)
+		return b.makeFromProperties(content, schema.Properties, visited)
+	}
+
+	if additional := schema.AdditionalProperties; additional != nil && additional.Schema != nil {
+		return b.makeFromProperties(content, map[string]Schema{
+			"additionalProp1": *additional.Schema,
+		}, visited)
 	}
 
 	return make(map[string]BuildParameter), visited
@@ -313,12 +497,7 @@ func (b *FactoryCollection) makeFromReference(content string, schema *Schema, vi
 
 	_, hasVisited := visited[schema.Ref]
 	if hasVisited {
-		data := make(map[string]BuildParameter)
-		data["$Circular"] = BuildParameter{
-			Value:  fmt.Sprintf("\"Circular schema '%s'.\"", schema.Ref),
-			Binary: false,
-		}
-		return data, visited
+		return circularParameter(schema.Ref), visited
 	}
 
 	if ref != nil {
@@ -331,16 +510,45 @@ func (b *FactoryCollection) makeFromReference(content string, schema *Schema, vi
 	return make(map[string]BuildParameter), visited
 }
 
-func (b *FactoryCollection) makeFromProperties(content string, schema *Schema, visited map[string]int) (map[string]BuildParameter, map[string]int) {
+func circularParameter(ref string) map[string]BuildParameter {
+	data := make(map[string]BuildParameter)
+	data["$Circular"] = BuildParameter{
+		Value:  fmt.Sprintf("\"Circular schema '%s'.\"", ref),
+		Binary: false,
+	}
+	return data
+}
+
+// composedRefs returns the references combined by the composition keywords.
+func composedRefs(schema *Schema) []string {
+	refs := make([]string, 0)
+	for _, variants := range [][]Schema{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for _, v := range variants {
+			if v.Ref != "" {
+				refs = append(refs, v.Ref)
+			}
+		}
+	}
+	return refs
+}
+
+func (b *FactoryCollection) makeFromProperties(content string, properties map[string]Schema, visited map[string]int) (map[string]BuildParameter, map[string]int) {
 	parameters := make(map[string]BuildParameter)
 
-	for key, v := range schema.Properties {
-		parameter, exists := b.makeFromPrimitiveProperty(key, &v)
+	for _, key := range sortedKeys(properties) {
+		v := properties[key]
+
+		resolved := b.resolveSchema(&v, make(map[string]bool))
+		if resolved == nil {
+			resolved = &v
+		}
+
+		parameter, exists := b.makeFromPrimitiveProperty(key, resolved)
 		if exists {
 			parameters[key] = *parameter
 		}
 
-		if exists || (v.Ref == "" && v.Items == nil) {
+		if exists || !isStructured(&v) {
 			continue
 		}
 
@@ -353,7 +561,7 @@ func (b *FactoryCollection) makeFromProperties(content string, schema *Schema, v
 			parameters[key] = BuildParameter{
 				Value:    "",
 				Children: &children,
-				Vector:   v.Type == "array",
+				Vector:   resolved.Type == "array",
 				Binary:   false,
 			}
 		}
@@ -364,26 +572,48 @@ func (b *FactoryCollection) makeFromProperties(content string, schema *Schema, v
 }
 
 func (b *FactoryCollection) makeFromPrimitiveProperty(field string, schema *Schema) (*BuildParameter, bool) {
-	example := schema.Example
+	example, hasExample := schemaExample(schema)
 
-	switch schema.Type {
+	switch exampleType(schema, example, hasExample) {
 	case "string":
-		if example == nil {
-			example = field
+		if !hasExample {
+			example = formatPlaceholder(field, schema.Format)
 		}
-		example = fmt.Sprintf("\"%s\"", example)
+		example = marshalExample(fmt.Sprintf("%v", example))
 	case "number":
-		if example == nil {
+		if !hasExample {
 			example = "0.0"
 		}
 	case "integer":
-		if example == nil {
+		if !hasExample {
 			example = "0"
 		}
 	case "boolean":
-		if example == nil {
+		if !hasExample {
 			example = "false"
 		}
+	case "array":
+		if hasExample {
+			example = marshalExample(example)
+			break
+		}
+		if schema.Items == nil {
+			return nil, false
+		}
+		items := b.resolveSchema(schema.Items, make(map[string]bool))
+		if items == nil {
+			return nil, false
+		}
+		item, ok := b.makeFromPrimitiveProperty(field, items)
+		if !ok {
+			return nil, false
+		}
+		example = fmt.Sprintf("[ %s ]", item.Value)
+	case "object":
+		if !hasExample {
+			return nil, false
+		}
+		example = marshalExample(example)
 	default:
 		return nil, false
 	}
@@ -396,6 +626,250 @@ func (b *FactoryCollection) makeFromPrimitiveProperty(field string, schema *Sche
 	}, true
 }
 
+func (b *FactoryCollection) resolveSchema(schema *Schema, seen map[string]bool) *Schema {
+	if schema.Ref != "" {
+		if seen[schema.Ref] {
+			return nil
+		}
+
+		ref, err := b.findSchemaReference(schema.Ref)
+		if err != nil || ref == nil {
+			return nil
+		}
+
+		seen[schema.Ref] = true
+		defer delete(seen, schema.Ref)
+
+		resolved := b.resolveSchema(ref, seen)
+		if resolved == nil {
+			return nil
+		}
+
+		result := *schema
+		result.Ref = ""
+		result.Properties = maps.Clone(schema.Properties)
+		mergeSchema(&result, resolved)
+
+		return b.resolveSchema(&result, seen)
+	}
+
+	if !isComposed(schema) {
+		return schema
+	}
+
+	result := *schema
+	result.AllOf = nil
+	result.OneOf = nil
+	result.AnyOf = nil
+	result.Properties = maps.Clone(schema.Properties)
+
+	for i := range schema.AllOf {
+		if part := b.resolveSchema(&schema.AllOf[i], seen); part != nil {
+			mergeSchema(&result, part)
+		}
+	}
+
+	if variant := b.firstVariant(schema.OneOf, seen); variant != nil {
+		mergeSchema(&result, variant)
+	}
+
+	if variant := b.firstVariant(schema.AnyOf, seen); variant != nil {
+		mergeSchema(&result, variant)
+	}
+
+	return &result
+}
+
+func (b *FactoryCollection) firstVariant(variants []Schema, seen map[string]bool) *Schema {
+	for i := range variants {
+		variant := b.resolveSchema(&variants[i], seen)
+		if variant != nil && variant.Type != "null" {
+			return variant
+		}
+	}
+	return nil
+}
+
+func mergeSchema(target *Schema, source *Schema) {
+	if target.Type == "" {
+		target.Type = source.Type
+		target.Types = source.Types
+	}
+	if target.Format == "" {
+		target.Format = source.Format
+	}
+	if source.Nullable {
+		target.Nullable = true
+	}
+	if target.Items == nil {
+		target.Items = source.Items
+	}
+	if target.AdditionalProperties == nil {
+		target.AdditionalProperties = source.AdditionalProperties
+	}
+	if target.Enum == nil {
+		target.Enum = source.Enum
+	}
+	if target.Const == nil {
+		target.Const = source.Const
+	}
+	if target.Default == nil {
+		target.Default = source.Default
+	}
+	if target.Example == nil {
+		target.Example = source.Example
+	}
+	if target.Examples == nil {
+		target.Examples = source.Examples
+	}
+
+	if len(source.Properties) > 0 && target.Properties == nil {
+		target.Properties = make(map[string]Schema)
+	}
+
+	for k, v := range source.Properties {
+		if _, exists := target.Properties[k]; !exists {
+			target.Properties[k] = v
+		}
+	}
+}
+
+func isComposed(schema *Schema) bool {
+	return len(schema.AllOf) > 0 || len(schema.OneOf) > 0 || len(schema.AnyOf) > 0
+}
+
+func isStructured(schema *Schema) bool {
+	return schema.Ref != "" ||
+		schema.Items != nil ||
+		len(schema.Properties) > 0 ||
+		isComposed(schema) ||
+		(schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil)
+}
+
+// exampleType returns the type of the schema the example belongs to, so an
+// example of a schema with several types keeps its own type.
+func exampleType(schema *Schema, example any, hasExample bool) string {
+	if hasExample && len(schema.Types) > 1 {
+		for _, v := range schema.Types {
+			if matchesType(v, normalizeNumber(example)) {
+				return v
+			}
+		}
+	}
+	return schemaType(schema)
+}
+
+func schemaType(schema *Schema) string {
+	if schema.Type != "" {
+		return schema.Type
+	}
+
+	sample := schema.Const
+	if sample == nil && len(schema.Enum) > 0 {
+		sample = schema.Enum[0]
+	}
+
+	switch value := sample.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case int, int64, uint64:
+		return "integer"
+	case float64:
+		if value == math.Trunc(value) {
+			return "integer"
+		}
+		return "number"
+	default:
+		return ""
+	}
+}
+
+func mediaTypeExample(mediaType MediaType) (any, bool) {
+	if mediaType.Example != nil {
+		return mediaType.Example, true
+	}
+
+	for _, k := range sortedKeys(mediaType.Examples) {
+		if value := mediaType.Examples[k].Value; value != nil {
+			return value, true
+		}
+	}
+
+	return nil, false
+}
+
+func schemaExample(schema *Schema) (any, bool) {
+	if schema.Const != nil {
+		return schema.Const, true
+	}
+
+	if schema.Example != nil && schema.Example != "" {
+		return schema.Example, true
+	}
+
+	switch examples := schema.Examples.(type) {
+	case []any:
+		if len(examples) > 0 {
+			return examples[0], true
+		}
+	case map[string]any:
+		for _, k := range sortedKeys(examples) {
+			return examples[k], true
+		}
+	}
+
+	if schema.Default != nil {
+		return schema.Default, true
+	}
+
+	if len(schema.Enum) > 0 {
+		return schema.Enum[0], true
+	}
+
+	return nil, false
+}
+
+func formatPlaceholder(field, format string) string {
+	switch format {
+	case "date":
+		return "2024-01-01"
+	case "date-time":
+		return "2024-01-01T00:00:00Z"
+	case "time":
+		return "00:00:00"
+	case "uuid":
+		return "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+	case "email":
+		return "user@example.com"
+	case "uri", "url":
+		return "https://example.com"
+	case "hostname":
+		return "example.com"
+	case "ipv4":
+		return "192.168.0.1"
+	case "ipv6":
+		return "::1"
+	case "byte":
+		return "c3RyaW5n"
+	default:
+		return field
+	}
+}
+
+func marshalExample(value any) string {
+	buffer := new(bytes.Buffer)
+
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Sprintf("\"%v\"", value)
+	}
+
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
+
 func (b *FactoryCollection) MakeFromSecurity(security []SecurityRequirement, queries *header.Headers) *auth.Auths {
 	auths := auth.NewAuths(false)
 
@@ -451,18 +925,16 @@ func (b *FactoryCollection) findRequestBodyReference(ref string) (*RequestBody,
 		return &requestBody, nil
 	}
 
-	fixRef := strings.TrimPrefix(ref, "#")
-	fixRef = strings.ReplaceAll(fixRef, "/", ".")
-
-	var requestBody *RequestBody
-	err := utils.FindJson(fixRef, b.raw, requestBody)
+	var requestBody RequestBody
+	if err := b.findReference(ref, &requestBody); err != nil {
+		return nil, err
+	}
 
-	return requestBody, err
+	return &requestBody, nil
 }
 
 func (b *FactoryCollection) findSchemaReference(ref string) (*Schema, error) {
-	if strings.HasPrefix(ref, "#/components/schemas/") {
-		schemaName := strings.TrimPrefix(ref, "#/components/schemas/")
+	if schemaName, ok := strings.CutPrefix(ref, "#/components/schemas/"); ok && !strings.Contains(schemaName, "/") {
 		schema, exists := b.openapi.Components.Schemas[schemaName]
 		if !exists {
 			return nil, fmt.Errorf("schema not found: %s", schemaName)
@@ -470,13 +942,28 @@ func (b *FactoryCollection) findSchemaReference(ref string) (*Schema, error) {
 		return &schema, nil
 	}
 
-	fixRef := strings.TrimPrefix(ref, "#")
-	fixRef = strings.ReplaceAll(fixRef, "/", ".")
+	var schema Schema
+	if err := b.findReference(ref, &schema); err != nil {
+		return nil, err
+	}
+
+	return &schema, nil
+}
 
-	var schema *Schema
-	err := utils.FindJson(fixRef, b.raw, schema)
+func (b *FactoryCollection) findReference(ref string, item any) error {
+	if !strings.HasPrefix(ref, "#") {
+		if b.bundle == nil {
+			return fmt.Errorf("external reference '%s' requires a specification bundle", ref)
+		}
+		return b.bundle.Find(ref, item)
+	}
+
+	value, ok := FindPointer(b.raw, strings.TrimPrefix(ref, "#"))
+	if !ok {
+		return fmt.Errorf("reference not found: %s", ref)
+	}
 
-	return schema, err
+	return remarshal(value, item)
 }
 
 func (b *FactoryCollection) findAuth(auth string) (*SecurityScheme, error) {
@@ -0,0 +1,282 @@
+package openapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	body_strategy "github.com/Rafael24595/go-api-core/src/domain/action/body/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/mock"
+	"github.com/Rafael24595/go-api-core/src/domain/mock/swr"
+)
+
+const (
+	CONTENT_TYPE_ARGUMENT = "content-type"
+	MISSING_PARAMETERS    = "Missing required parameters"
+)
+
+type FactoryMock struct {
+	owner      string
+	openapi    OpenAPI
+	collection *FactoryCollection
+}
+
+func NewFactoryMock(owner string, openapi *OpenAPI) *FactoryMock {
+	return &FactoryMock{
+		owner:      owner,
+		openapi:    *openapi,
+		collection: NewFactoryCollection(owner, openapi),
+	}
+}
+
+func (b *FactoryMock) SetRaw(raw map[string]any) *FactoryMock {
+	b.collection.SetRaw(raw)
+	return b
+}
+
+func (b *FactoryMock) SetBundle(bundle *Bundle) *FactoryMock {
+	b.collection.SetBundle(bundle)
+	return b
+}
+
+func (b *FactoryMock) Make() []mock.EndPoint {
+	endPoints := make([]mock.EndPoint, 0)
+	for _, path := range sortedKeys(b.openapi.Paths) {
+		for _, v := range pathOperations(b.openapi.Paths[path]) {
+			endPoint := b.MakeFromOperation(v.method, path, v.operation)
+			endPoint.Order = len(endPoints)
+			endPoints = append(endPoints, *endPoint)
+		}
+	}
+	return endPoints
+}
+
+func (b *FactoryMock) MakeFromOperation(method domain.HttpMethod, path string, operation *Operation) *mock.EndPoint {
+	now := time.Now().UnixMilli()
+
+	name := fmt.Sprintf("%s %s", method, path)
+	if operation.Summary != "" {
+		name = operation.Summary
+	}
+
+	required := requiredArguments(operation.Parameters)
+
+	codes := sortedKeys(operation.Responses)
+	success := findResponseCode(codes, '2')
+	failure := findFailureCode(codes)
+	if success == "" && hasCode(codes, "default") {
+		success = "default"
+	}
+
+	responses := make([]mock.Response, 0, len(codes)+1)
+	for _, code := range codes {
+		response := b.MakeFromResponse(code, operation.Responses[code])
+		if code == "default" && code == success {
+			response.Code = 200
+		}
+
+		switch {
+		case code == success && len(required) > 0:
+			response.Condition = makeRequiredCondition(required)
+		case code == success:
+			response.Name = mock.DefaultResponse
+		case code == failure && len(required) > 0:
+			response.Name = mock.DefaultResponse
+		}
+
+		response.Order = len(responses) + 1
+		responses = append(responses, *response)
+	}
+
+	if len(required) > 0 && failure == "" {
+		responses = append(responses, mock.Response{
+			Status: true,
+			Code:   400,
+			Name:   mock.DefaultResponse,
+			Arguments: []mock.Argument{
+				{Status: true, Key: CONTENT_TYPE_ARGUMENT, Value: domain.Text.ToHeader()},
+			},
+			Body: mock.Body{
+				ContentType: domain.Text,
+				Payload:     fmt.Sprintf("%s: %s", MISSING_PARAMETERS, strings.Join(required, ", ")),
+			},
+		})
+	}
+
+	return &mock.EndPoint{
+		Status:    true,
+		Timestamp: now,
+		Modified:  now,
+		Name:      name,
+		Method:    method,
+		Path:      path,
+		Responses: mock.FixResponses(responses),
+		Safe:      false,
+		Owner:     b.owner,
+	}
+}
+
+func (b *FactoryMock) MakeFromResponse(code string, response Response) *mock.Response {
+	if response.Ref != "" {
+		if reference, ok := b.findResponseReference(response.Ref); ok {
+			response = *reference
+		}
+	}
+
+	name := code
+	if response.Description != "" {
+		name = fmt.Sprintf("%s %s", code, response.Description)
+	}
+
+	result := &mock.Response{
+		Status:    true,
+		Code:      responseStatus(code),
+		Name:      name,
+		Arguments: make([]mock.Argument, 0),
+		Body: mock.Body{
+			ContentType: domain.Text,
+			Payload:     "",
+		},
+	}
+
+	content := preferredContent(response.Content)
+	if content == "" {
+		result.Arguments = append(result.Arguments, mock.Argument{
+			Status: true,
+			Key:    CONTENT_TYPE_ARGUMENT,
+			Value:  domain.Text.ToHeader(),
+		})
+		return result
+	}
+
+	result.Arguments = append(result.Arguments, mock.Argument{
+		Status: true,
+		Key:    CONTENT_TYPE_ARGUMENT,
+		Value:  content,
+	})
+
+	if contentType, ok := domain.ContentTypeFromHeader(content); ok && contentType != domain.Form {
+		result.Body.ContentType = contentType
+	}
+
+	payload, ok := b.collection.MakeFromMediaType(content, response.Content[content])
+	if !ok {
+		return result
+	}
+
+	document, ok := payload.Parameters[body_strategy.DOCUMENT_PARAM][body_strategy.PAYLOAD_PARAM]
+	if ok && len(document) > 0 {
+		result.Body.Payload = document[0].Value
+	}
+
+	return result
+}
+
+func (b *FactoryMock) findResponseReference(ref string) (*Response, bool) {
+	if name, ok := strings.CutPrefix(ref, "#/components/responses/"); ok && !strings.Contains(name, "/") {
+		response, exists := b.openapi.Components.Responses[name]
+		return &response, exists
+	}
+
+	var response Response
+	if err := b.collection.findReference(ref, &response); err != nil {
+		return nil, false
+	}
+
+	return &response, true
+}
+
+func requiredArguments(parameters []Parameter) []string {
+	required := make([]string, 0)
+	for _, v := range parameters {
+		if !v.Required {
+			continue
+		}
+
+		switch v.In {
+		case "query", "cookie":
+			required = append(required, v.Name)
+		case "header":
+			required = append(required, strings.ToLower(v.Name))
+		}
+	}
+	return required
+}
+
+// makeRequiredCondition builds the SWR expression that holds when every
+// required argument is present and not empty.
+func makeRequiredCondition(required []string) string {
+	steps := make([]swr.Step, 0)
+	for i, v := range required {
+		if i > 0 {
+			steps = append(steps, *swr.NewConditionStep(swr.StepTypeOperator, "and"))
+		}
+		steps = append(steps,
+			*swr.NewConditionStep(swr.StepTypeInput, string(swr.StepInputArgument)),
+			*swr.NewConditionStep(swr.StepTypeField, v),
+			*swr.NewConditionStep(swr.StepTypeOperator, "ne"),
+			*swr.NewConditionStep(swr.StepTypeValue, ""),
+		)
+	}
+
+	condition, _ := swr.Marshal(swr.FixStepsOrder(steps))
+	return condition
+}
+
+func preferredContent(content map[string]MediaType) string {
+	if _, ok := content[domain.Json.ToHeader()]; ok {
+		return domain.Json.ToHeader()
+	}
+
+	keys := sortedKeys(content)
+	if len(keys) == 0 {
+		return ""
+	}
+
+	return keys[0]
+}
+
+func findResponseCode(codes []string, class byte) string {
+	for _, v := range codes {
+		if len(v) == 3 && v[0] == class {
+			return v
+		}
+	}
+	return ""
+}
+
+// findFailureCode returns the documented status that best describes a request
+// missing its required arguments.
+func findFailureCode(codes []string) string {
+	for _, v := range []string{"400", "422"} {
+		if hasCode(codes, v) {
+			return v
+		}
+	}
+	return ""
+}
+
+func hasCode(codes []string, code string) bool {
+	for _, v := range codes {
+		if v == code {
+			return true
+		}
+	}
+	return false
+}
+
+func responseStatus(code string) int {
+	if status, err := strconv.Atoi(code); err == nil {
+		return status
+	}
+
+	if len(code) == 3 && strings.HasSuffix(strings.ToUpper(code), "XX") {
+		if class, err := strconv.Atoi(code[:1]); err == nil {
+			return class * 100
+		}
+	}
+
+	return 500
+}
@@ -0,0 +1,683 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"mime"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	"github.com/Rafael24595/go-api-core/src/domain/action/auth"
+	auth_strategy "github.com/Rafael24595/go-api-core/src/domain/action/auth/strategy"
+	body_strategy "github.com/Rafael24595/go-api-core/src/domain/action/body/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/action/param"
+	"github.com/Rafael24595/go-api-core/src/domain/action/query"
+	"github.com/Rafael24595/go-api-core/src/domain/collection"
+	"github.com/Rafael24595/go-api-core/src/domain/context"
+)
+
+const (
+	OPENAPI_VERSION   = "3.0.3"
+	DEFAULT_VERSION   = "1.0.0"
+	BASIC_SCHEME_KEY  = "basicAuth"
+	BEARER_SCHEME_KEY = "bearerAuth"
+)
+
+var (
+	variablePattern = regexp.MustCompile(`\$\{([^}]+)\}`)
+	uuidPattern     = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	ignoredHeaders  = []string{"content-type", "authorization", "cookie", "content-length"}
+)
+
+type FactoryOpenApi struct {
+	collection *collection.Collection
+	context    *context.Context
+	requests   []action.Request
+	responses  map[string]*action.Response
+}
+
+func NewFactoryOpenApi(collection *collection.Collection, context *context.Context) *FactoryOpenApi {
+	return &FactoryOpenApi{
+		collection: collection,
+		context:    context,
+		requests:   make([]action.Request, 0),
+		responses:  make(map[string]*action.Response),
+	}
+}
+
+func (b *FactoryOpenApi) AddRequest(request action.Request, response *action.Response) *FactoryOpenApi {
+	b.requests = append(b.requests, request)
+	if response != nil {
+		b.responses[request.Id] = response
+	}
+	return b
+}
+
+func (b *FactoryOpenApi) Make() *OpenAPI {
+	oapi := &OpenAPI{
+		OpenAPI: OPENAPI_VERSION,
+		Info: Info{
+			Title:   b.collection.Name,
+			Version: DEFAULT_VERSION,
+		},
+		Servers: make([]Server, 0),
+		Paths:   make(map[string]PathItem),
+		Components: Components{
+			SecuritySchemes: make(map[string]SecurityScheme),
+		},
+	}
+
+	for _, v := range b.requests {
+		if server, _ := b.splitUri(v.Uri, oapi.Servers); server != "" && strings.HasPrefix(v.Uri, "${") && !hasServer(oapi.Servers, server) {
+			oapi.Servers = append(oapi.Servers, Server{URL: server})
+		}
+	}
+
+	operations := make(map[string]int)
+	for _, v := range b.requests {
+		server, path := b.splitUri(v.Uri, oapi.Servers)
+		if server != "" && !hasServer(oapi.Servers, server) {
+			oapi.Servers = append(oapi.Servers, Server{URL: server})
+		}
+
+		path, keys := b.makePath(path)
+
+		operation := b.MakeOperation(v, b.responses[v.Id], keys)
+		operation.Security = b.makeSecurity(v.Auth, oapi.Components.SecuritySchemes)
+
+		item := oapi.Paths[path]
+		target := operationTarget(&item, v.Method)
+		if target == nil {
+			continue
+		}
+
+		// A second request with the same method and path is kept as an
+		// example of the operation already exported.
+		if *target != nil {
+			mergeExample(*target, operation, operationName(v.Name, v.Method))
+			continue
+		}
+
+		operation.OperationId = uniqueOperationId(operations, v.Name, v.Method)
+		*target = operation
+		oapi.Paths[path] = item
+	}
+
+	return oapi
+}
+
+func (b *FactoryOpenApi) splitUri(uri string, servers []Server) (string, string) {
+	uri, _, _ = strings.Cut(uri, "?")
+	uri, _, _ = strings.Cut(uri, "#")
+
+	server := ""
+	path := uri
+
+	switch {
+	case strings.HasPrefix(uri, "${"):
+		end := strings.Index(uri, "}")
+		if end == -1 {
+			break
+		}
+		if b.context != nil {
+			server = strings.TrimSuffix(b.context.Apply(context.URI.String(), uri[:end+1]), "/")
+		}
+		path = uri[end+1:]
+	case strings.Contains(uri, "://"):
+		for _, s := range servers {
+			if rest, ok := strings.CutPrefix(uri, s.URL); ok && (rest == "" || strings.HasPrefix(rest, "/")) {
+				server = s.URL
+				path = rest
+				break
+			}
+		}
+		if server != "" {
+			break
+		}
+		protocol, rest, _ := strings.Cut(uri, "://")
+		host, rest, _ := strings.Cut(rest, "/")
+		server = fmt.Sprintf("%s://%s", protocol, host)
+		path = "/" + rest
+	}
+
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	return server, path
+}
+
+func (b *FactoryOpenApi) makePath(path string) (string, []string) {
+	for _, s := range param.FindSegments(path) {
+		if strings.HasPrefix(s.Raw, ":") {
+			path = strings.Replace(path, s.Raw, "{"+s.Key+"}", 1)
+		}
+	}
+
+	path = variablePattern.ReplaceAllStringFunc(path, func(match string) string {
+		name := variablePattern.FindStringSubmatch(match)[1]
+		if _, key, ok := strings.Cut(name, "."); ok {
+			name = key
+		}
+		return "{" + name + "}"
+	})
+
+	return path, param.FindKeys(path)
+}
+
+func (b *FactoryOpenApi) MakeOperation(request action.Request, response *action.Response, keys []string) *Operation {
+	parameters := b.makePathParameters(request, keys)
+	parameters = append(parameters, b.makeQueryParameters(request)...)
+	parameters = append(parameters, b.makeHeaderParameters(request)...)
+	parameters = append(parameters, b.makeCookieParameters(request)...)
+
+	return &Operation{
+		Summary:     request.Name,
+		Parameters:  parameters,
+		RequestBody: b.MakeRequestBody(request),
+		Responses:   b.MakeResponses(response),
+	}
+}
+
+func (b *FactoryOpenApi) makePathParameters(request action.Request, keys []string) []Parameter {
+	parameters := make([]Parameter, 0)
+	for _, k := range keys {
+		parameter := Parameter{
+			Name:     k,
+			In:       "path",
+			Required: true,
+			Schema:   Schema{Type: "string"},
+		}
+
+		if p, ok := request.Param.Find(k); ok {
+			parameter.Description = p.Description
+			parameter.Schema = inferPrimitive(p.Value)
+			parameter.Example = makeExample(p.Value)
+		}
+
+		parameters = append(parameters, parameter)
+	}
+	return parameters
+}
+
+func (b *FactoryOpenApi) makeQueryParameters(request action.Request) []Parameter {
+	parameters := make([]Parameter, 0)
+	for _, k := range sortedKeys(request.Query.Queries) {
+		values := request.Query.Queries[k]
+		if len(values) == 0 {
+			continue
+		}
+
+		parameter := Parameter{
+			Name:   k,
+			In:     "query",
+			Schema: inferPrimitive(values[0].Value),
+		}
+
+		serialization := request.Query.FindSerialization(k)
+		if !serialization.IsDefault() {
+			explode := serialization.Explode
+			parameter.Style = serialization.Style.String()
+			parameter.Explode = &explode
+			if serialization.Style == query.BRACKETS {
+				parameter.Style = query.FORM.String()
+			}
+		}
+
+		switch {
+		case values[0].Property != "":
+			properties := make(map[string]Schema)
+			example := make(map[string]any)
+			for _, v := range values {
+				properties[v.Property] = inferPrimitive(v.Value)
+				if value := makeExample(v.Value); value != nil {
+					example[v.Property] = value
+				}
+			}
+			parameter.Schema = Schema{Type: "object", Properties: properties}
+			if len(example) > 0 {
+				parameter.Example = example
+			}
+		case len(values) > 1 || serialization.Style == query.BRACKETS:
+			items := inferPrimitive(values[0].Value)
+			example := make([]any, 0)
+			for _, v := range values {
+				if value := makeExample(v.Value); value != nil {
+					example = append(example, value)
+				}
+			}
+			parameter.Schema = Schema{Type: "array", Items: &items}
+			if len(example) > 0 {
+				parameter.Example = example
+			}
+		default:
+			parameter.Example = makeExample(values[0].Value)
+		}
+
+		parameters = append(parameters, parameter)
+	}
+	return parameters
+}
+
+func (b *FactoryOpenApi) makeHeaderParameters(request action.Request) []Parameter {
+	parameters := make([]Parameter, 0)
+	for _, k := range sortedKeys(request.Header.Headers) {
+		values := request.Header.Headers[k]
+		if len(values) == 0 || isIgnoredHeader(k) {
+			continue
+		}
+
+		parameters = append(parameters, Parameter{
+			Name:    k,
+			In:      "header",
+			Schema:  inferPrimitive(values[0].Value),
+			Example: makeExample(values[0].Value),
+		})
+	}
+	return parameters
+}
+
+func (b *FactoryOpenApi) makeCookieParameters(request action.Request) []Parameter {
+	parameters := make([]Parameter, 0)
+	for _, k := range sortedKeys(request.Cookie.Cookies) {
+		value := request.Cookie.Cookies[k].Value
+		parameters = append(parameters, Parameter{
+			Name:    k,
+			In:      "cookie",
+			Schema:  inferPrimitive(value),
+			Example: makeExample(value),
+		})
+	}
+	return parameters
+}
+
+func (b *FactoryOpenApi) MakeRequestBody(request action.Request) *RequestBody {
+	payload := request.Body
+	if payload.Empty() || !payload.Status {
+		return nil
+	}
+
+	if payload.ContentType == domain.Form {
+		return b.makeFormBody(request)
+	}
+
+	document, ok := payload.Parameters[body_strategy.DOCUMENT_PARAM][body_strategy.PAYLOAD_PARAM]
+	if !ok || len(document) == 0 || document[0].IsFile {
+		return nil
+	}
+
+	mimeType := payload.ContentType.ToHeader()
+	if values, ok := request.Header.Find("Content-Type"); ok && len(values) > 0 {
+		if mediaType, _, err := mime.ParseMediaType(values[0].Value); err == nil {
+			mimeType = mediaType
+		}
+	}
+
+	return &RequestBody{
+		Required: true,
+		Content: map[string]MediaType{
+			mimeType: makeMediaType(payload.ContentType, document[0].Value),
+		},
+	}
+}
+
+func (b *FactoryOpenApi) makeFormBody(request action.Request) *RequestBody {
+	form := request.Body.Parameters[body_strategy.FORM_DATA_PARAM]
+
+	properties := make(map[string]Schema)
+	for _, k := range sortedKeys(form) {
+		if len(form[k]) == 0 {
+			continue
+		}
+
+		value := form[k][0]
+		if value.IsFile {
+			properties[k] = Schema{Type: "string", Format: "binary"}
+			continue
+		}
+
+		schema := inferPrimitive(value.Value)
+		schema.Example = makeExample(value.Value)
+		properties[k] = schema
+	}
+
+	return &RequestBody{
+		Required: true,
+		Content: map[string]MediaType{
+			domain.Form.ToHeader(): {
+				Schema: Schema{
+					Type:       "object",
+					Properties: properties,
+				},
+			},
+		},
+	}
+}
+
+func (b *FactoryOpenApi) MakeResponses(response *action.Response) map[string]Response {
+	if response == nil || response.Status == 0 {
+		return map[string]Response{
+			"default": {
+				Description: "Default response",
+			},
+		}
+	}
+
+	code := strconv.Itoa(int(response.Status))
+
+	description := http.StatusText(int(response.Status))
+	if description == "" {
+		description = "Response"
+	}
+
+	result := Response{
+		Description: description,
+	}
+
+	if response.Body.Payload != "" {
+		mimeType := response.Body.ContentType.ToHeader()
+		if values, ok := response.Headers.Find("Content-Type"); ok && len(values) > 0 {
+			if mediaType, _, err := mime.ParseMediaType(values[0].Value); err == nil {
+				mimeType = mediaType
+			}
+		}
+
+		result.Content = map[string]MediaType{
+			mimeType: makeMediaType(response.Body.ContentType, response.Body.Payload),
+		}
+	}
+
+	return map[string]Response{
+		code: result,
+	}
+}
+
+func (b *FactoryOpenApi) makeSecurity(auths auth.Auths, schemes map[string]SecurityScheme) []SecurityRequirement {
+	if !auths.Status {
+		return nil
+	}
+
+	security := make([]SecurityRequirement, 0)
+
+	if basic, ok := auths.Auths[auth.Basic.String()]; ok && basic.Status {
+		schemes[BASIC_SCHEME_KEY] = SecurityScheme{
+			Type:   "http",
+			Scheme: "basic",
+		}
+		security = append(security, SecurityRequirement{BASIC_SCHEME_KEY: []string{}})
+	}
+
+	if bearer, ok := auths.Auths[auth.Bearer.String()]; ok && bearer.Status {
+		if _, exists := schemes[BEARER_SCHEME_KEY]; !exists {
+			scheme := SecurityScheme{
+				Type:   "http",
+				Scheme: "bearer",
+			}
+			if prefix := bearer.Parameters[auth_strategy.BEARER_PARAM_PREFIX]; prefix != auth_strategy.DEFAULT_BEARER_PREFIX {
+				scheme.BearerFormat = prefix
+			}
+			schemes[BEARER_SCHEME_KEY] = scheme
+		}
+		security = append(security, SecurityRequirement{BEARER_SCHEME_KEY: []string{}})
+	}
+
+	if len(security) == 0 {
+		return nil
+	}
+
+	return security
+}
+
+func makeMediaType(contentType domain.ContentType, payload string) MediaType {
+	if contentType == domain.Json {
+		var value any
+		if err := json.Unmarshal([]byte(payload), &value); err == nil {
+			return MediaType{
+				Schema:  InferSchema(value),
+				Example: value,
+			}
+		}
+	}
+
+	return MediaType{
+		Schema:  Schema{Type: "string"},
+		Example: payload,
+	}
+}
+
+// InferSchema builds the schema that describes the given decoded JSON value.
+func InferSchema(value any) Schema {
+	switch v := value.(type) {
+	case map[string]any:
+		properties := make(map[string]Schema)
+		for k, p := range v {
+			properties[k] = InferSchema(p)
+		}
+		return Schema{Type: "object", Properties: properties}
+	case []any:
+		items := Schema{}
+		if len(v) > 0 {
+			items = InferSchema(v[0])
+		}
+		return Schema{Type: "array", Items: &items}
+	case float64:
+		if v == math.Trunc(v) {
+			return Schema{Type: "integer"}
+		}
+		return Schema{Type: "number"}
+	case bool:
+		return Schema{Type: "boolean"}
+	case string:
+		return inferString(v)
+	default:
+		return Schema{}
+	}
+}
+
+func inferPrimitive(value string) Schema {
+	if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return Schema{Type: "integer"}
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return Schema{Type: "number"}
+	}
+	if value == "true" || value == "false" {
+		return Schema{Type: "boolean"}
+	}
+	return inferString(value)
+}
+
+func inferString(value string) Schema {
+	if _, err := time.Parse(time.RFC3339, value); err == nil {
+		return Schema{Type: "string", Format: "date-time"}
+	}
+	if _, err := time.Parse(time.DateOnly, value); err == nil {
+		return Schema{Type: "string", Format: "date"}
+	}
+	if uuidPattern.MatchString(value) {
+		return Schema{Type: "string", Format: "uuid"}
+	}
+	return Schema{Type: "string"}
+}
+
+func makeExample(value string) any {
+	if value == "" || variablePattern.MatchString(value) {
+		return nil
+	}
+	if number, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return number
+	}
+	if number, err := strconv.ParseFloat(value, 64); err == nil {
+		return number
+	}
+	if boolean, err := strconv.ParseBool(value); err == nil && (value == "true" || value == "false") {
+		return boolean
+	}
+	return value
+}
+
+func operationTarget(item *PathItem, method domain.HttpMethod) **Operation {
+	switch method {
+	case domain.GET:
+		return &item.Get
+	case domain.POST:
+		return &item.Post
+	case domain.PUT:
+		return &item.Put
+	case domain.DELETE:
+		return &item.Delete
+	case domain.PATCH:
+		return &item.Patch
+	case domain.HEAD:
+		return &item.Head
+	case domain.OPTIONS:
+		return &item.Options
+	default:
+		return nil
+	}
+}
+
+// mergeExample adds the parameters, bodies and responses of the source to
+// the target operation, the payloads are added as named examples.
+func mergeExample(target, source *Operation, name string) {
+	for _, v := range source.Parameters {
+		if !hasParameter(target.Parameters, v) {
+			v.Required = v.In == "path"
+			target.Parameters = append(target.Parameters, v)
+		}
+	}
+
+	if source.RequestBody != nil {
+		if target.RequestBody == nil {
+			target.RequestBody = &RequestBody{Content: make(map[string]MediaType)}
+		}
+		target.RequestBody.Content = mergeContent(target.RequestBody.Content, source.RequestBody.Content, target, name, source.Summary)
+	}
+
+	for code, response := range source.Responses {
+		current, exists := target.Responses[code]
+		if !exists {
+			if code == "default" && len(target.Responses) > 0 {
+				continue
+			}
+			target.Responses[code] = response
+			continue
+		}
+		current.Content = mergeContent(current.Content, response.Content, target, name, source.Summary)
+		target.Responses[code] = current
+	}
+}
+
+func mergeContent(target, source map[string]MediaType, operation *Operation, name, summary string) map[string]MediaType {
+	if len(source) > 0 && target == nil {
+		target = make(map[string]MediaType)
+	}
+
+	for mimeType, media := range source {
+		current, exists := target[mimeType]
+		if !exists {
+			target[mimeType] = media
+			continue
+		}
+		if media.Example == nil {
+			continue
+		}
+		if current.Examples == nil {
+			current.Examples = make(map[string]Example)
+		}
+		if current.Example != nil {
+			current.Examples[operation.OperationId] = Example{Summary: operation.Summary, Value: current.Example}
+			current.Example = nil
+		}
+		current.Examples[uniqueExampleKey(current.Examples, name)] = Example{Summary: summary, Value: media.Example}
+		target[mimeType] = current
+	}
+
+	return target
+}
+
+func uniqueExampleKey(examples map[string]Example, name string) string {
+	key := name
+	for i := 2; ; i++ {
+		if _, exists := examples[key]; !exists {
+			return key
+		}
+		key = fmt.Sprintf("%s%d", name, i)
+	}
+}
+
+func hasParameter(parameters []Parameter, parameter Parameter) bool {
+	for _, v := range parameters {
+		if v.Name == parameter.Name && v.In == parameter.In {
+			return true
+		}
+	}
+	return false
+}
+
+func uniqueOperationId(cache map[string]int, name string, method domain.HttpMethod) string {
+	id := operationName(name, method)
+
+	cache[id]++
+	if count := cache[id]; count > 1 {
+		return fmt.Sprintf("%s%d", id, count)
+	}
+
+	return id
+}
+
+// operationName joins the words of the request name in camel case, the
+// method is used when the name has no words.
+func operationName(name string, method domain.HttpMethod) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	id := strings.ToLower(method.String())
+	if len(words) > 0 {
+		id = strings.ToLower(words[0])
+		for _, w := range words[1:] {
+			runes := []rune(strings.ToLower(w))
+			runes[0] = unicode.ToUpper(runes[0])
+			id += string(runes)
+		}
+	}
+
+	return id
+}
+
+func hasServer(servers []Server, url string) bool {
+	for _, v := range servers {
+		if v.URL == url {
+			return true
+		}
+	}
+	return false
+}
+
+func isIgnoredHeader(key string) bool {
+	key = strings.ToLower(key)
+	for _, v := range ignoredHeaders {
+		if v == key {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys[T any](items map[string]T) []string {
+	keys := make([]string, 0, len(items))
+	for k := range items {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
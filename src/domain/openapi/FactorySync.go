@@ -0,0 +1,506 @@
+package openapi
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	"github.com/Rafael24595/go-api-core/src/domain/action/cookie"
+	"github.com/Rafael24595/go-api-core/src/domain/action/header"
+	"github.com/Rafael24595/go-api-core/src/domain/action/param"
+	"github.com/Rafael24595/go-api-core/src/domain/action/query"
+	"github.com/Rafael24595/go-api-core/src/domain/context"
+)
+
+type SyncReport struct {
+	Added     []SyncChange `json:"added"`
+	Updated   []SyncChange `json:"updated"`
+	Removed   []SyncChange `json:"removed"`
+	Context   []string     `json:"context"`
+	Unchanged int          `json:"unchanged"`
+}
+
+type SyncChange struct {
+	Request string            `json:"request"`
+	Name    string            `json:"name"`
+	Method  domain.HttpMethod `json:"method"`
+	Path    string            `json:"path"`
+	Changes []string          `json:"changes,omitempty"`
+}
+
+type SyncResult struct {
+	Context *context.Context
+	Updated []action.Request
+	Added   []action.Request
+	Report  SyncReport
+}
+
+type FactorySync struct {
+	collection *FactoryCollection
+	previous   *FactoryCollection
+}
+
+func NewFactorySync(owner string, openapi *OpenAPI) *FactorySync {
+	return &FactorySync{
+		collection: NewFactoryCollection(owner, openapi),
+	}
+}
+
+func (f *FactorySync) SetRaw(raw map[string]any) *FactorySync {
+	f.collection.SetRaw(raw)
+	return f
+}
+
+func (f *FactorySync) SetBundle(bundle *Bundle) *FactorySync {
+	f.collection.SetBundle(bundle)
+	return f
+}
+
+// SetPrevious sets the version of the specification the requests were built
+// from. The parameter definition changes are only reported when it is known,
+// and the values the user did not edit follow the new definitions.
+func (f *FactorySync) SetPrevious(openapi *OpenAPI, raw map[string]any, bundle *Bundle) *FactorySync {
+	f.previous = NewFactoryCollection(f.collection.owner, openapi).
+		SetRaw(raw).
+		SetBundle(bundle)
+	return f
+}
+
+// Sync compares the requests of an imported collection with the operations of
+// the specification. Requests are matched by method and path template, and
+// then by operationId or summary against the request name, since that is the
+// name the import gives them. Matched requests keep the values edited by the
+// user and only receive the missing or changed definitions; requests and
+// parameters without a definition are reported as removed but never deleted.
+func (f *FactorySync) Sync(current []action.Request) *SyncResult {
+	ctx, operations := f.collection.makeOperations()
+
+	result := &SyncResult{
+		Context: ctx,
+		Updated: make([]action.Request, 0),
+		Added:   make([]action.Request, 0),
+		Report: SyncReport{
+			Added:   make([]SyncChange, 0),
+			Updated: make([]SyncChange, 0),
+			Removed: make([]SyncChange, 0),
+			Context: make([]string, 0),
+		},
+	}
+
+	matches := matchOperations(current, operations)
+	previous := f.previousOperations(current)
+
+	for i, operation := range operations {
+		index, ok := matches[i]
+		if !ok {
+			result.Added = append(result.Added, operation.request)
+			result.Report.Added = append(result.Report.Added, makeSyncChange(operation.request, operation.path, nil))
+			continue
+		}
+
+		request, changes := mergeOperation(current[index], operation, previous[index])
+		if len(changes) == 0 {
+			result.Report.Unchanged++
+			continue
+		}
+
+		result.Updated = append(result.Updated, request)
+		result.Report.Updated = append(result.Report.Updated, makeSyncChange(request, operation.path, changes))
+	}
+
+	matched := make(map[int]bool)
+	for _, index := range matches {
+		matched[index] = true
+	}
+
+	for i, request := range current {
+		if !matched[i] {
+			result.Report.Removed = append(result.Report.Removed, makeSyncChange(request, templatePath(request.Uri), nil))
+		}
+	}
+
+	return result
+}
+
+// previousOperations returns the operations of the previous specification by
+// the index of the request built from them.
+func (f *FactorySync) previousOperations(current []action.Request) map[int]*operationRequest {
+	result := make(map[int]*operationRequest)
+	if f.previous == nil {
+		return result
+	}
+
+	_, operations := f.previous.makeOperations()
+	for i, index := range matchOperations(current, operations) {
+		result[index] = &operations[i]
+	}
+
+	return result
+}
+
+func matchOperations(current []action.Request, operations []operationRequest) map[int]int {
+	matches := make(map[int]int)
+	taken := make(map[int]bool)
+
+	for i, operation := range operations {
+		key := normalizeTemplate(operation.path)
+		for j, request := range current {
+			if taken[j] || request.Method != operation.request.Method {
+				continue
+			}
+			if normalizeTemplate(templatePath(request.Uri)) == key {
+				matches[i] = j
+				taken[j] = true
+				break
+			}
+		}
+	}
+
+	for i, operation := range operations {
+		if _, ok := matches[i]; ok {
+			continue
+		}
+
+		names := []string{operation.operation.OperationId, operation.operation.Summary}
+		for j, request := range current {
+			if taken[j] || !containsName(names, request.Name) {
+				continue
+			}
+			matches[i] = j
+			taken[j] = true
+			break
+		}
+	}
+
+	return matches
+}
+
+func mergeOperation(request action.Request, operation operationRequest, previous *operationRequest) (action.Request, []string) {
+	incoming := operation.request
+	changes := make([]string, 0)
+
+	request.Query.Queries = cloneMap(request.Query.Queries)
+	request.Query.Styles = cloneMap(request.Query.Styles)
+	request.Header.Headers = cloneMap(request.Header.Headers)
+	request.Cookie.Cookies = cloneMap(request.Cookie.Cookies)
+
+	if request.Method != incoming.Method {
+		changes = append(changes, fmt.Sprintf("method changed from %s to %s", request.Method, incoming.Method))
+		request.Method = incoming.Method
+	}
+
+	if path := templatePath(request.Uri); normalizeTemplate(path) != normalizeTemplate(operation.path) {
+		changes = append(changes, fmt.Sprintf("path changed from %s to %s", path, operation.path))
+		if index := strings.Index(request.Uri, path); index != -1 {
+			request.Uri = request.Uri[:index] + operation.path + request.Uri[index+len(path):]
+		} else {
+			request.Uri = incoming.Uri
+		}
+	}
+
+	source := action.Request{}
+	definitions := make(map[string][]string)
+	if previous != nil {
+		source = previous.request
+		definitions = parameterChanges(previous.operation.Parameters, operation.operation.Parameters)
+	}
+
+	params := param.NewParams()
+	for _, v := range incoming.Param.Params {
+		existing, ok := request.Param.Find(v.Key)
+		if !ok {
+			changes = append(changes, fmt.Sprintf("path parameter '%s' added", v.Key))
+			params.AddParam(v)
+			continue
+		}
+
+		merged := *existing
+		attributes := definitions[parameterKey("path", v.Key)]
+		if merged.Description != v.Description {
+			merged.Description = v.Description
+			attributes = appendAttribute(attributes, "description")
+		}
+
+		old, found := source.Param.Find(v.Key)
+		edited := found && !sameParam(*old, *existing)
+		if found && !edited && !sameParam(*old, v) {
+			merged.Value, merged.Status = v.Value, v.Status
+		}
+
+		changes = appendDefinition(changes, "path parameter", v.Key, attributes, edited)
+		params.AddParam(merged)
+	}
+	for _, v := range request.Param.Params {
+		if !params.Exists(v.Key) {
+			changes = append(changes, fmt.Sprintf("path parameter '%s' removed", v.Key))
+		}
+	}
+	request.Param = *params.Sort()
+
+	for _, key := range sortedKeys(incoming.Query.Queries) {
+		queries, ok := request.Query.Queries[key]
+		if !ok {
+			changes = append(changes, fmt.Sprintf("query parameter '%s' added", key))
+			for _, v := range incoming.Query.Queries[key] {
+				request.Query.AddQuery(key, v)
+			}
+		} else {
+			old, found := source.Query.Queries[key]
+			edited := found && !sameValues(old, queries, sameQuery)
+			if found && !edited {
+				request.Query.Queries[key] = incoming.Query.Queries[key]
+			}
+			changes = appendDefinition(changes, "query parameter", key, definitions[parameterKey("query", key)], edited)
+		}
+
+		serialization := incoming.Query.FindSerialization(key)
+		if request.Query.FindSerialization(key) != serialization {
+			changes = append(changes, fmt.Sprintf("query parameter '%s' serialization changed", key))
+			request.Query.PutSerialization(key, serialization)
+		}
+	}
+	for _, key := range sortedKeys(request.Query.Queries) {
+		if _, ok := incoming.Query.Queries[key]; !ok {
+			_, found := source.Query.Queries[key]
+			changes = appendMissing(changes, "query parameter", key, previous != nil, found)
+		}
+	}
+
+	for _, key := range sortedKeys(incoming.Header.Headers) {
+		name, ok := findHeaderKey(request, key)
+		if !ok {
+			changes = append(changes, fmt.Sprintf("header '%s' added", key))
+			for _, v := range incoming.Header.Headers[key] {
+				request.Header.AddHeader(key, v)
+			}
+			continue
+		}
+
+		old, found := source.Header.Headers[key]
+		edited := found && !sameValues(old, request.Header.Headers[name], sameHeader)
+		if found && !edited {
+			request.Header.Headers[name] = incoming.Header.Headers[key]
+		}
+		changes = appendDefinition(changes, "header", key, definitions[parameterKey("header", key)], edited)
+	}
+	for _, key := range sortedKeys(request.Header.Headers) {
+		if _, ok := findHeaderKey(incoming, key); !ok {
+			_, found := findHeaderKey(source, key)
+			changes = appendMissing(changes, "header", key, previous != nil, found)
+		}
+	}
+
+	for _, key := range sortedKeys(incoming.Cookie.Cookies) {
+		cookie, ok := request.Cookie.Cookies[key]
+		if !ok {
+			changes = append(changes, fmt.Sprintf("cookie '%s' added", key))
+			request.Cookie.Cookies[key] = incoming.Cookie.Cookies[key]
+			continue
+		}
+
+		old, found := source.Cookie.Cookies[key]
+		edited := found && !sameCookie(old, cookie)
+		if found && !edited {
+			cookie.Value, cookie.Status = incoming.Cookie.Cookies[key].Value, incoming.Cookie.Cookies[key].Status
+			request.Cookie.Cookies[key] = cookie
+		}
+		changes = appendDefinition(changes, "cookie", key, definitions[parameterKey("cookie", key)], edited)
+	}
+	for _, key := range sortedKeys(request.Cookie.Cookies) {
+		if _, ok := incoming.Cookie.Cookies[key]; !ok {
+			_, found := source.Cookie.Cookies[key]
+			changes = appendMissing(changes, "cookie", key, previous != nil, found)
+		}
+	}
+
+	if request.Body.ContentType != incoming.Body.ContentType && !incoming.Body.Empty() {
+		changes = append(changes, fmt.Sprintf("body changed from %s to %s", request.Body.ContentType, incoming.Body.ContentType))
+		request.Body = incoming.Body
+	}
+
+	if len(request.Auth.Auths) == 0 && len(incoming.Auth.Auths) > 0 {
+		changes = append(changes, "authentication added")
+		request.Auth = incoming.Auth
+	}
+
+	return request, changes
+}
+
+// parameterChanges returns the changed attributes of the parameters defined
+// in both versions of the operation, by location and name.
+func parameterChanges(previous, incoming []Parameter) map[string][]string {
+	definitions := make(map[string]Parameter)
+	for _, v := range previous {
+		definitions[parameterKey(v.In, v.Name)] = v
+	}
+
+	result := make(map[string][]string)
+	for _, v := range incoming {
+		old, ok := definitions[parameterKey(v.In, v.Name)]
+		if !ok {
+			continue
+		}
+
+		attributes := make([]string, 0)
+		if old.Required != v.Required {
+			attributes = append(attributes, "required")
+		}
+		if old.Description != v.Description {
+			attributes = append(attributes, "description")
+		}
+		if !reflect.DeepEqual(old.Schema, v.Schema) || !reflect.DeepEqual(old.Example, v.Example) {
+			attributes = append(attributes, "schema")
+		}
+
+		if len(attributes) > 0 {
+			result[parameterKey(v.In, v.Name)] = attributes
+		}
+	}
+
+	return result
+}
+
+func parameterKey(in, name string) string {
+	if in == "header" {
+		name = strings.ToLower(name)
+	}
+	return in + ":" + name
+}
+
+func appendAttribute(attributes []string, attribute string) []string {
+	for _, v := range attributes {
+		if v == attribute {
+			return attributes
+		}
+	}
+	return append(attributes, attribute)
+}
+
+// appendDefinition reports the changed attributes of a parameter, the values
+// edited by the user are kept.
+func appendDefinition(changes []string, kind, name string, attributes []string, edited bool) []string {
+	if len(attributes) == 0 {
+		return changes
+	}
+
+	change := fmt.Sprintf("%s '%s' %s changed", kind, name, strings.Join(attributes, ", "))
+	if edited {
+		change += ", the edited values are kept"
+	}
+
+	return append(changes, change)
+}
+
+// appendMissing reports a parameter of the request that the specification
+// does not define. It is only known to be removed when the previous version
+// defined it, otherwise the user may have added it.
+func appendMissing(changes []string, kind, name string, known, found bool) []string {
+	switch {
+	case known && found:
+		return append(changes, fmt.Sprintf("%s '%s' removed", kind, name))
+	case !known:
+		return append(changes, fmt.Sprintf("%s '%s' not in the specification", kind, name))
+	}
+	return changes
+}
+
+func sameParam(a, b param.Param) bool {
+	return a.Value == b.Value && a.Status == b.Status
+}
+
+func sameQuery(a, b query.Query) bool {
+	return a.Value == b.Value && a.Status == b.Status && a.Property == b.Property
+}
+
+func sameHeader(a, b header.Header) bool {
+	return a.Value == b.Value && a.Status == b.Status
+}
+
+func sameCookie(a, b cookie.CookieClient) bool {
+	return a.Value == b.Value && a.Status == b.Status
+}
+
+func sameValues[T any](a, b []T, same func(T, T) bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !same(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func makeSyncChange(request action.Request, path string, changes []string) SyncChange {
+	return SyncChange{
+		Request: request.Id,
+		Name:    request.Name,
+		Method:  request.Method,
+		Path:    path,
+		Changes: changes,
+	}
+}
+
+// templatePath removes the server, query and fragment of the uri.
+func templatePath(uri string) string {
+	uri, _, _ = strings.Cut(uri, "?")
+	uri, _, _ = strings.Cut(uri, "#")
+
+	if strings.HasPrefix(uri, "${") {
+		if end := strings.Index(uri, "}"); end != -1 {
+			uri = uri[end+1:]
+		}
+	} else if _, rest, ok := strings.Cut(uri, "://"); ok {
+		_, rest, _ = strings.Cut(rest, "/")
+		uri = "/" + rest
+	}
+
+	if !strings.HasPrefix(uri, "/") {
+		uri = "/" + uri
+	}
+
+	return uri
+}
+
+// normalizeTemplate replaces the placeholders of the path, so renamed path
+// parameters keep matching the same template.
+func normalizeTemplate(path string) string {
+	var buffer strings.Builder
+	for _, s := range param.FindSegments(path) {
+		if s.Key != "" {
+			buffer.WriteString("{}")
+			continue
+		}
+		buffer.WriteString(s.Raw)
+	}
+	return strings.TrimSuffix(buffer.String(), "/")
+}
+
+func findHeaderKey(request action.Request, name string) (string, bool) {
+	for key := range request.Header.Headers {
+		if strings.EqualFold(key, name) {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+func cloneMap[K comparable, V any](items map[K]V) map[K]V {
+	if items == nil {
+		return make(map[K]V)
+	}
+	return maps.Clone(items)
+}
+
+func containsName(names []string, name string) bool {
+	for _, v := range names {
+		if v != "" && v == name {
+			return true
+		}
+	}
+	return false
+}
@@ -1,43 +1,50 @@
 package openapi
 
+import "encoding/json"
+
 type OpenAPI struct {
 	OpenAPI    string                `json:"openapi"`
 	Info       Info                  `json:"info"`
-	Servers    []Server              `json:"servers"`
+	Servers    []Server              `json:"servers,omitempty"`
 	Paths      map[string]PathItem   `json:"paths"`
+	Webhooks   map[string]PathItem   `json:"webhooks,omitempty"`
 	Components Components            `json:"components"`
-	Security   []SecurityRequirement `json:"security"`
-	Tags       []Tag                 `json:"tags"`
+	Security   []SecurityRequirement `json:"security,omitempty"`
+	Tags       []Tag                 `json:"tags,omitempty"`
 }
 
 type Info struct {
-	Title       string  `json:"title"`
-	Description string  `json:"description"`
-	Version     string  `json:"version"`
-	Contact     Contact `json:"contact"`
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	Version     string   `json:"version"`
+	Contact     *Contact `json:"contact,omitempty"`
 }
 
 type Contact struct {
-	Name  string `json:"name"`
-	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
 }
 
 type Server struct {
 	URL         string `json:"url"`
-	Description string `json:"description"`
+	Description string `json:"description,omitempty"`
 }
 
 type PathItem struct {
-	Get    *Operation `json:"get,omitempty"`
-	Post   *Operation `json:"post,omitempty"`
-	Put    *Operation `json:"put,omitempty"`
-	Delete *Operation `json:"delete,omitempty"`
+	Get     *Operation `json:"get,omitempty"`
+	Post    *Operation `json:"post,omitempty"`
+	Put     *Operation `json:"put,omitempty"`
+	Delete  *Operation `json:"delete,omitempty"`
+	Patch   *Operation `json:"patch,omitempty"`
+	Head    *Operation `json:"head,omitempty"`
+	Options *Operation `json:"options,omitempty"`
 }
 
 type Operation struct {
-	Tags        []string              `json:"tags"`
-	Summary     string                `json:"summary"`
-	Description string                `json:"description"`
+	Tags        []string              `json:"tags,omitempty"`
+	OperationId string                `json:"operationId,omitempty"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
 	Parameters  []Parameter           `json:"parameters,omitempty"`
 	RequestBody *RequestBody          `json:"requestBody,omitempty"`
 	Responses   map[string]Response   `json:"responses"`
@@ -47,52 +54,186 @@ type Operation struct {
 type Parameter struct {
 	Name        string `json:"name"`
 	In          string `json:"in"`
-	Required    bool   `json:"required"`
-	Description string `json:"description"`
+	Required    bool   `json:"required,omitempty"`
+	Description string `json:"description,omitempty"`
 	Schema      Schema `json:"schema"`
+	Example     any    `json:"example,omitempty"`
+	Style       string `json:"style,omitempty"`
+	Explode     *bool  `json:"explode,omitempty"`
 }
 
 type RequestBody struct {
-	Required bool                 `json:"required"`
-	Content  map[string]MediaType `json:"content"`
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content,omitempty"`
 	Ref      string               `json:"$ref,omitempty"`
 }
 
 type MediaType struct {
-	Schema Schema `json:"schema"`
+	Schema   Schema             `json:"schema"`
+	Example  any                `json:"example,omitempty"`
+	Examples map[string]Example `json:"examples,omitempty"`
+}
+
+type Example struct {
+	Summary string `json:"summary,omitempty"`
+	Value   any    `json:"value,omitempty"`
 }
 
 type Response struct {
 	Description string               `json:"description"`
+	Headers     map[string]Header    `json:"headers,omitempty"`
 	Content     map[string]MediaType `json:"content,omitempty"`
+	Ref         string               `json:"$ref,omitempty"`
+}
+
+type Header struct {
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+	Schema      Schema `json:"schema"`
 }
 
 type Components struct {
-	Schemas         map[string]Schema         `json:"schemas"`
-	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes"`
-	RequestBodies   map[string]RequestBody    `json:"requestBodies"`
+	Schemas         map[string]Schema         `json:"schemas,omitempty"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+	RequestBodies   map[string]RequestBody    `json:"requestBodies,omitempty"`
+	Responses       map[string]Response       `json:"responses,omitempty"`
 }
 
 type SecurityRequirement map[string][]string
 
 type SecurityScheme struct {
 	Type         string `json:"type"`
-	In           string `json:"in"`
-	Name         string `json:"name"`
+	In           string `json:"in,omitempty"`
+	Name         string `json:"name,omitempty"`
 	Scheme       string `json:"scheme,omitempty"`
 	BearerFormat string `json:"bearerFormat,omitempty"`
 }
 
 type Schema struct {
-	Ref        string            `json:"$ref,omitempty"`
-	Type       string            `json:"type"`
-	Format     string            `json:"format"`
-	Properties map[string]Schema `json:"properties,omitempty"`
-	Items      *Schema           `json:"items,omitempty"`
-	Example    any               `json:"example,omitempty"`
+	Ref                  string                `json:"$ref,omitempty"`
+	Type                 string                `json:"type,omitempty"`
+	Types                []string              `json:"-"`
+	Nullable             bool                  `json:"nullable,omitempty"`
+	Format               string                `json:"format,omitempty"`
+	Properties           map[string]Schema     `json:"properties,omitempty"`
+	Required             []string              `json:"required,omitempty"`
+	AdditionalProperties *AdditionalProperties `json:"additionalProperties,omitempty"`
+	Items                *Schema               `json:"items,omitempty"`
+	AllOf                []Schema              `json:"allOf,omitempty"`
+	OneOf                []Schema              `json:"oneOf,omitempty"`
+	AnyOf                []Schema              `json:"anyOf,omitempty"`
+	Enum                 []any                 `json:"enum,omitempty"`
+	Const                any                   `json:"const,omitempty"`
+	Default              any                   `json:"default,omitempty"`
+	Example              any                   `json:"example,omitempty"`
+	Examples             any                   `json:"examples,omitempty"`
+	Defs                 map[string]Schema     `json:"$defs,omitempty"`
+}
+
+// TypeList returns every type the schema accepts besides null.
+func (s Schema) TypeList() []string {
+	if len(s.Types) > 0 {
+		return s.Types
+	}
+	if s.Type != "" {
+		return []string{s.Type}
+	}
+	return nil
+}
+
+// MarshalJSON writes the 3.1 type array back when the schema has several types.
+func (s Schema) MarshalJSON() ([]byte, error) {
+	type alias Schema
+	if len(s.Types) < 2 {
+		return json.Marshal(alias(s))
+	}
+
+	return json.Marshal(struct {
+		alias
+		Type []string `json:"type"`
+	}{
+		alias: alias(s),
+		Type:  s.Types,
+	})
+}
+
+// UnmarshalJSON accepts both the single type keyword and the 3.1 type arrays,
+// where a "null" entry marks the schema as nullable. Type keeps the first type
+// of the array and Types every one of them.
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	type alias Schema
+	aux := struct {
+		*alias
+		Type any `json:"type,omitempty"`
+	}{
+		alias: (*alias)(s),
+	}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	s.Type = ""
+	s.Types = nil
+
+	switch value := aux.Type.(type) {
+	case string:
+		s.Type = value
+	case []any:
+		for _, v := range value {
+			typ, ok := v.(string)
+			if !ok {
+				continue
+			}
+			if typ == "null" {
+				s.Nullable = true
+				continue
+			}
+			if s.Type == "" {
+				s.Type = typ
+			}
+			s.Types = append(s.Types, typ)
+		}
+		if s.Type == "" && s.Nullable {
+			s.Type = "null"
+		}
+	}
+
+	return nil
+}
+
+// AdditionalProperties holds either the boolean or the schema form of the keyword.
+type AdditionalProperties struct {
+	Allowed bool
+	Schema  *Schema
+}
+
+func (a AdditionalProperties) MarshalJSON() ([]byte, error) {
+	if a.Schema != nil {
+		return json.Marshal(a.Schema)
+	}
+	return json.Marshal(a.Allowed)
+}
+
+func (a *AdditionalProperties) UnmarshalJSON(data []byte) error {
+	var allowed bool
+	if err := json.Unmarshal(data, &allowed); err == nil {
+		a.Allowed = allowed
+		a.Schema = nil
+		return nil
+	}
+
+	var schema Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return err
+	}
+
+	a.Allowed = true
+	a.Schema = &schema
+	return nil
 }
 
 type Tag struct {
 	Name        string `json:"name"`
-	Description string `json:"description"`
+	Description string `json:"description,omitempty"`
 }
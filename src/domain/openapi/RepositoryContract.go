@@ -0,0 +1,7 @@
+package openapi
+
+type RepositoryContract interface {
+	Find(id string) (*Contract, bool)
+	Insert(owner string, contract *Contract) *Contract
+	Delete(contract Contract) *Contract
+}
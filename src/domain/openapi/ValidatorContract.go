@@ -0,0 +1,552 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"mime"
+	"net/mail"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+)
+
+const (
+	LOCATION_STATUS = "status"
+	LOCATION_HEADER = "header"
+	LOCATION_BODY   = "body"
+)
+
+// Violation describes a single mismatch between a response and its contract.
+// Pointer is a JSON pointer relative to the location it refers to.
+type Violation struct {
+	Location string `json:"location"`
+	Pointer  string `json:"pointer"`
+	Rule     string `json:"rule"`
+	Message  string `json:"message"`
+}
+
+type ValidatorContract struct {
+	openapi    OpenAPI
+	collection *FactoryCollection
+}
+
+func NewValidatorContract(openapi *OpenAPI) *ValidatorContract {
+	return &ValidatorContract{
+		openapi:    *openapi,
+		collection: NewFactoryCollection("", openapi),
+	}
+}
+
+func (v *ValidatorContract) SetRaw(raw map[string]any) *ValidatorContract {
+	v.collection.SetRaw(raw)
+	return v
+}
+
+func (v *ValidatorContract) SetBundle(bundle *Bundle) *ValidatorContract {
+	v.collection.SetBundle(bundle)
+	return v
+}
+
+// FindOperation returns the path template and operation that describe the request.
+func (v *ValidatorContract) FindOperation(request action.Request) (string, *Operation, bool) {
+	path := v.requestPath(request.Uri)
+
+	best := ""
+	bestScore := -1
+	var operation *Operation
+
+	for template, item := range v.openapi.Paths {
+		score, ok := matchTemplate(template, path)
+		if !ok {
+			continue
+		}
+
+		for _, o := range pathOperations(item) {
+			if o.method != request.Method {
+				continue
+			}
+			if score > bestScore || (score == bestScore && template < best) {
+				best = template
+				bestScore = score
+				operation = o.operation
+			}
+		}
+	}
+
+	return best, operation, operation != nil
+}
+
+func (v *ValidatorContract) Validate(request action.Request, response action.Response) ([]Violation, error) {
+	path, operation, ok := v.FindOperation(request)
+	if !ok {
+		return nil, fmt.Errorf("no operation matches %s %s", request.Method, request.Uri)
+	}
+
+	return v.ValidateOperation(path, operation, response), nil
+}
+
+func (v *ValidatorContract) ValidateOperation(path string, operation *Operation, response action.Response) []Violation {
+	violations := make([]Violation, 0)
+
+	contract, ok := findContractResponse(operation.Responses, int(response.Status))
+	if !ok {
+		return append(violations, Violation{
+			Location: LOCATION_STATUS,
+			Pointer:  "",
+			Rule:     "status",
+			Message:  fmt.Sprintf("status %d is not documented for %s", response.Status, path),
+		})
+	}
+
+	if contract.Ref != "" {
+		var reference Response
+		if err := v.collection.findReference(contract.Ref, &reference); err == nil {
+			contract = reference
+		}
+	}
+
+	violations = append(violations, v.validateHeaders(contract, response)...)
+
+	if len(contract.Content) == 0 {
+		return violations
+	}
+
+	mediaType, hasType := findResponseHeader(response, "Content-Type")
+	if !hasType {
+		if response.Body.Payload == "" {
+			return violations
+		}
+		return append(violations, Violation{
+			Location: LOCATION_HEADER,
+			Pointer:  "/Content-Type",
+			Rule:     "content-type",
+			Message:  "the response does not declare its content type",
+		})
+	}
+
+	if parsed, _, err := mime.ParseMediaType(mediaType); err == nil {
+		mediaType = parsed
+	}
+
+	media, ok := findMediaType(contract.Content, mediaType)
+	if !ok {
+		return append(violations, Violation{
+			Location: LOCATION_HEADER,
+			Pointer:  "/Content-Type",
+			Rule:     "content-type",
+			Message:  fmt.Sprintf("content type '%s' is not documented", mediaType),
+		})
+	}
+
+	if !strings.Contains(mediaType, "json") {
+		return violations
+	}
+
+	var payload any
+	if err := json.Unmarshal([]byte(response.Body.Payload), &payload); err != nil {
+		return append(violations, Violation{
+			Location: LOCATION_BODY,
+			Pointer:  "",
+			Rule:     "json",
+			Message:  fmt.Sprintf("the body is not valid JSON: %s", err.Error()),
+		})
+	}
+
+	return append(violations, v.ValidateSchema(&media.Schema, payload, "")...)
+}
+
+func (v *ValidatorContract) validateHeaders(contract Response, response action.Response) []Violation {
+	violations := make([]Violation, 0)
+	for _, name := range sortedKeys(contract.Headers) {
+		definition := contract.Headers[name]
+
+		value, ok := findResponseHeader(response, name)
+		if !ok {
+			if definition.Required {
+				violations = append(violations, Violation{
+					Location: LOCATION_HEADER,
+					Pointer:  "/" + escapePointer(name),
+					Rule:     "required",
+					Message:  fmt.Sprintf("required header '%s' is missing", name),
+				})
+			}
+			continue
+		}
+
+		for _, violation := range v.ValidateSchema(&definition.Schema, parameterValue(value, &definition.Schema), "/"+escapePointer(name)) {
+			violation.Location = LOCATION_HEADER
+			violations = append(violations, violation)
+		}
+	}
+	return violations
+}
+
+// ValidateSchema checks a decoded JSON value against the schema and reports
+// every violation found, located by its JSON pointer.
+func (v *ValidatorContract) ValidateSchema(schema *Schema, value any, pointer string) []Violation {
+	return v.validateSchema(schema, value, pointer, make(map[string]bool))
+}
+
+func (v *ValidatorContract) validateSchema(schema *Schema, value any, pointer string, seen map[string]bool) []Violation {
+	if schema.Ref != "" {
+		// A reference is only a cycle when it comes back to the same value,
+		// the nested values of recursive schemas are still validated.
+		key := pointer + " " + schema.Ref
+		if seen[key] {
+			return nil
+		}
+
+		reference, err := v.collection.findSchemaReference(schema.Ref)
+		if err != nil || reference == nil {
+			return []Violation{bodyViolation(pointer, "$ref", fmt.Sprintf("reference '%s' cannot be resolved", schema.Ref))}
+		}
+
+		seen[key] = true
+		defer delete(seen, key)
+
+		return v.validateSchema(reference, value, pointer, seen)
+	}
+
+	if value == nil && schema.Nullable {
+		return nil
+	}
+
+	violations := make([]Violation, 0)
+
+	for i := range schema.AllOf {
+		violations = append(violations, v.validateSchema(&schema.AllOf[i], value, pointer, seen)...)
+	}
+
+	if len(schema.OneOf) > 0 {
+		matches := 0
+		for i := range schema.OneOf {
+			if len(v.validateSchema(&schema.OneOf[i], value, pointer, seen)) == 0 {
+				matches++
+			}
+		}
+		if matches != 1 {
+			violations = append(violations, bodyViolation(pointer, "oneOf", fmt.Sprintf("value matches %d schemas but exactly one is expected", matches)))
+		}
+	}
+
+	if len(schema.AnyOf) > 0 {
+		matched := false
+		for i := range schema.AnyOf {
+			if len(v.validateSchema(&schema.AnyOf[i], value, pointer, seen)) == 0 {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			violations = append(violations, bodyViolation(pointer, "anyOf", "value does not match any schema"))
+		}
+	}
+
+	if value == nil {
+		if schema.Type != "" && schema.Type != "null" {
+			violations = append(violations, bodyViolation(pointer, "type", fmt.Sprintf("null is not a valid %s", strings.Join(schema.TypeList(), " or "))))
+		}
+		return violations
+	}
+
+	if types := schema.TypeList(); len(types) > 0 && !matchesAnyType(types, value) {
+		return append(violations, bodyViolation(pointer, "type", fmt.Sprintf("expected %s but found %s", strings.Join(types, " or "), jsonType(value))))
+	}
+
+	if schema.Const != nil && !reflect.DeepEqual(normalizeNumber(schema.Const), normalizeNumber(value)) {
+		violations = append(violations, bodyViolation(pointer, "const", fmt.Sprintf("expected constant %v", schema.Const)))
+	}
+
+	if len(schema.Enum) > 0 && !containsValue(schema.Enum, value) {
+		violations = append(violations, bodyViolation(pointer, "enum", fmt.Sprintf("value %v is not one of %v", value, schema.Enum)))
+	}
+
+	if text, ok := value.(string); ok && schema.Format != "" && !matchesFormat(schema.Format, text) {
+		violations = append(violations, bodyViolation(pointer, "format", fmt.Sprintf("value '%s' is not a valid %s", text, schema.Format)))
+	}
+
+	switch data := value.(type) {
+	case map[string]any:
+		violations = append(violations, v.validateObject(schema, data, pointer, seen)...)
+	case []any:
+		if schema.Items != nil {
+			for i, item := range data {
+				violations = append(violations, v.validateSchema(schema.Items, item, fmt.Sprintf("%s/%d", pointer, i), seen)...)
+			}
+		}
+	}
+
+	return violations
+}
+
+func (v *ValidatorContract) validateObject(schema *Schema, data map[string]any, pointer string, seen map[string]bool) []Violation {
+	violations := make([]Violation, 0)
+
+	for _, name := range schema.Required {
+		if _, ok := data[name]; !ok {
+			violations = append(violations, bodyViolation(pointer+"/"+escapePointer(name), "required", fmt.Sprintf("required property '%s' is missing", name)))
+		}
+	}
+
+	for _, key := range sortedKeys(data) {
+		child := pointer + "/" + escapePointer(key)
+
+		if property, ok := schema.Properties[key]; ok {
+			violations = append(violations, v.validateSchema(&property, data[key], child, seen)...)
+			continue
+		}
+
+		additional := schema.AdditionalProperties
+		if additional == nil {
+			continue
+		}
+
+		if additional.Schema != nil {
+			violations = append(violations, v.validateSchema(additional.Schema, data[key], child, seen)...)
+		} else if !additional.Allowed {
+			violations = append(violations, bodyViolation(child, "additionalProperties", fmt.Sprintf("property '%s' is not allowed", key)))
+		}
+	}
+
+	return violations
+}
+
+func (v *ValidatorContract) requestPath(uri string) string {
+	uri, _, _ = strings.Cut(uri, "?")
+	uri, _, _ = strings.Cut(uri, "#")
+
+	if strings.HasPrefix(uri, "${") {
+		if end := strings.Index(uri, "}"); end != -1 {
+			uri = uri[end+1:]
+		}
+	}
+
+	for _, server := range v.openapi.Servers {
+		if rest, ok := strings.CutPrefix(uri, strings.TrimSuffix(server.URL, "/")); ok && server.URL != "" {
+			uri = rest
+			break
+		}
+	}
+
+	if strings.Contains(uri, "://") {
+		_, rest, _ := strings.Cut(uri, "://")
+		_, rest, _ = strings.Cut(rest, "/")
+		uri = "/" + rest
+
+		for _, server := range v.openapi.Servers {
+			base := serverBasePath(server.URL)
+			if rest, ok := strings.CutPrefix(uri, base); ok && base != "" && (rest == "" || strings.HasPrefix(rest, "/")) {
+				uri = rest
+				break
+			}
+		}
+	}
+
+	if !strings.HasPrefix(uri, "/") {
+		uri = "/" + uri
+	}
+
+	return uri
+}
+
+// matchTemplate reports whether the path fits the template, scoring how many
+// segments matched literally so the most specific template wins.
+func matchTemplate(template, path string) (int, bool) {
+	templateSegments := strings.Split(strings.Trim(template, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+
+	if len(templateSegments) != len(pathSegments) {
+		return 0, false
+	}
+
+	score := 0
+	for i, segment := range templateSegments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			if pathSegments[i] == "" {
+				return 0, false
+			}
+			continue
+		}
+		if segment != pathSegments[i] {
+			return 0, false
+		}
+		score++
+	}
+
+	return score, true
+}
+
+func findContractResponse(responses map[string]Response, status int) (Response, bool) {
+	if response, ok := responses[strconv.Itoa(status)]; ok {
+		return response, true
+	}
+	for code, response := range responses {
+		if strings.EqualFold(code, fmt.Sprintf("%dXX", status/100)) {
+			return response, true
+		}
+	}
+	if response, ok := responses["default"]; ok {
+		return response, true
+	}
+	return Response{}, false
+}
+
+func findMediaType(content map[string]MediaType, mediaType string) (MediaType, bool) {
+	if media, ok := content[mediaType]; ok {
+		return media, true
+	}
+
+	kind, _, _ := strings.Cut(mediaType, "/")
+	if media, ok := content[kind+"/*"]; ok {
+		return media, true
+	}
+
+	media, ok := content["*/*"]
+	return media, ok
+}
+
+func findResponseHeader(response action.Response, name string) (string, bool) {
+	for key, values := range response.Headers.Headers {
+		if strings.EqualFold(key, name) && len(values) > 0 {
+			return values[0].Value, true
+		}
+	}
+	return "", false
+}
+
+// parameterValue converts a textual value into the first JSON type of the
+// schema it can represent.
+func parameterValue(value string, schema *Schema) any {
+	for _, typ := range schema.TypeList() {
+		switch typ {
+		case "string":
+			return value
+		case "integer", "number":
+			if number, err := strconv.ParseFloat(value, 64); err == nil {
+				return number
+			}
+		case "boolean":
+			if boolean, err := strconv.ParseBool(value); err == nil {
+				return boolean
+			}
+		}
+	}
+	return value
+}
+
+func matchesAnyType(types []string, value any) bool {
+	for _, v := range types {
+		if matchesType(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesType(typ string, value any) bool {
+	switch typ {
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		number, ok := value.(float64)
+		return ok && number == math.Trunc(number)
+	default:
+		return true
+	}
+}
+
+func jsonType(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	default:
+		return "null"
+	}
+}
+
+func matchesFormat(format, value string) bool {
+	switch format {
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, value)
+		return err == nil
+	case "date":
+		_, err := time.Parse(time.DateOnly, value)
+		return err == nil
+	case "uuid":
+		return uuidPattern.MatchString(value)
+	case "email":
+		_, err := mail.ParseAddress(value)
+		return err == nil
+	default:
+		return true
+	}
+}
+
+func containsValue(values []any, value any) bool {
+	for _, v := range values {
+		if reflect.DeepEqual(normalizeNumber(v), normalizeNumber(value)) {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeNumber(value any) any {
+	switch number := value.(type) {
+	case int:
+		return float64(number)
+	case int64:
+		return float64(number)
+	case uint64:
+		return float64(number)
+	default:
+		return value
+	}
+}
+
+func serverBasePath(url string) string {
+	if _, rest, ok := strings.Cut(url, "://"); ok {
+		_, path, _ := strings.Cut(rest, "/")
+		return strings.TrimSuffix("/"+path, "/")
+	}
+	return strings.TrimSuffix(url, "/")
+}
+
+func escapePointer(key string) string {
+	key = strings.ReplaceAll(key, "~", "~0")
+	return strings.ReplaceAll(key, "/", "~1")
+}
+
+func bodyViolation(pointer, rule, message string) Violation {
+	return Violation{
+		Location: LOCATION_BODY,
+		Pointer:  pointer,
+		Rule:     rule,
+		Message:  message,
+	}
+}
@@ -0,0 +1,318 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/url"
+	"strings"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	"github.com/Rafael24595/go-api-core/src/domain/action/body"
+	body_strategy "github.com/Rafael24595/go-api-core/src/domain/action/body/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/action/param"
+	"github.com/Rafael24595/go-api-core/src/domain/action/query"
+)
+
+const (
+	LOCATION_PATH   = "path"
+	LOCATION_QUERY  = "query"
+	LOCATION_COOKIE = "cookie"
+)
+
+// ContractError reports the violations found before sending a request.
+type ContractError struct {
+	Violations []Violation
+}
+
+func (e *ContractError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = fmt.Sprintf("%s%s: %s", v.Location, v.Pointer, v.Message)
+	}
+	return fmt.Sprintf("the request breaks the contract: %s", strings.Join(messages, "; "))
+}
+
+// ValidateRequest checks a resolved request against the operation it belongs
+// to: required parameters, parameter schemas and the request body.
+func (v *ValidatorContract) ValidateRequest(request action.Request) ([]Violation, error) {
+	resolved := request
+	resolved.Uri = request.Param.Apply(request.Uri)
+
+	path, operation, ok := v.FindOperation(resolved)
+	if !ok {
+		return nil, fmt.Errorf("no operation matches %s %s", request.Method, request.Uri)
+	}
+
+	violations := make([]Violation, 0)
+	for _, parameter := range operation.Parameters {
+		violations = append(violations, v.validateParameter(path, resolved, parameter)...)
+	}
+
+	return append(violations, v.validateRequestBody(operation.RequestBody, request)...), nil
+}
+
+func (v *ValidatorContract) validateParameter(path string, request action.Request, parameter Parameter) []Violation {
+	values, found := parameterValues(path, request, parameter)
+
+	pointer := "/" + escapePointer(parameter.Name)
+	if !found {
+		if !parameter.Required {
+			return nil
+		}
+		return []Violation{{
+			Location: parameter.In,
+			Pointer:  pointer,
+			Rule:     "required",
+			Message:  fmt.Sprintf("required %s parameter '%s' is missing", parameter.In, parameter.Name),
+		}}
+	}
+
+	schema := v.collection.resolveSchema(&parameter.Schema, make(map[string]bool))
+	if schema == nil {
+		return nil
+	}
+
+	if schema.Type == "object" {
+		return nil
+	}
+
+	if schema.Type == "array" && schema.Items != nil {
+		schema, values = schema.Items, v.arrayItems(parameter, values)
+	}
+
+	violations := make([]Violation, 0)
+	for _, value := range values {
+		for _, violation := range v.ValidateSchema(schema, parameterValue(value, schema), pointer) {
+			violation.Location = parameter.In
+			violations = append(violations, violation)
+		}
+	}
+
+	return violations
+}
+
+func (v *ValidatorContract) validateRequestBody(requestBody *RequestBody, request action.Request) []Violation {
+	if requestBody == nil {
+		return nil
+	}
+
+	if requestBody.Ref != "" {
+		reference, err := v.collection.findRequestBodyReference(requestBody.Ref)
+		if err != nil || reference == nil {
+			return []Violation{bodyViolation("", "$ref", fmt.Sprintf("reference '%s' cannot be resolved", requestBody.Ref))}
+		}
+		requestBody = reference
+	}
+
+	if request.Body.Empty() || !request.Body.Status {
+		if !requestBody.Required {
+			return nil
+		}
+		return []Violation{bodyViolation("", "required", "the request body is required")}
+	}
+
+	if len(requestBody.Content) == 0 {
+		return nil
+	}
+
+	mediaType := request.Body.ContentType.ToHeader()
+	if value, ok := findRequestHeader(request, "Content-Type"); ok {
+		if parsed, _, err := mime.ParseMediaType(value); err == nil {
+			mediaType = parsed
+		}
+	}
+
+	media, ok := findRequestMediaType(requestBody.Content, mediaType, request.Body.ContentType)
+	if !ok {
+		return []Violation{{
+			Location: LOCATION_HEADER,
+			Pointer:  "/Content-Type",
+			Rule:     "content-type",
+			Message:  fmt.Sprintf("content type '%s' is not accepted", mediaType),
+		}}
+	}
+
+	switch request.Body.ContentType {
+	case domain.Json:
+		return v.validateJsonBody(&media.Schema, request.Body)
+	case domain.Form:
+		return v.validateFormBody(&media.Schema, request.Body)
+	}
+
+	return nil
+}
+
+func (v *ValidatorContract) validateJsonBody(schema *Schema, requestBody body.BodyRequest) []Violation {
+	document, ok := requestBody.Parameters[body_strategy.DOCUMENT_PARAM][body_strategy.PAYLOAD_PARAM]
+	if !ok || len(document) == 0 {
+		return nil
+	}
+
+	var payload any
+	if err := json.Unmarshal([]byte(document[0].Value), &payload); err != nil {
+		return []Violation{bodyViolation("", "json", fmt.Sprintf("the body is not valid JSON: %s", err.Error()))}
+	}
+
+	return v.ValidateSchema(schema, payload, "")
+}
+
+func (v *ValidatorContract) validateFormBody(schema *Schema, requestBody body.BodyRequest) []Violation {
+	resolved := v.collection.resolveSchema(schema, make(map[string]bool))
+	if resolved == nil {
+		return nil
+	}
+
+	form := requestBody.Parameters[body_strategy.FORM_DATA_PARAM]
+
+	violations := make([]Violation, 0)
+	for _, name := range resolved.Required {
+		if !hasActiveParameter(form[name]) {
+			violations = append(violations, bodyViolation("/"+escapePointer(name), "required", fmt.Sprintf("required field '%s' is missing", name)))
+		}
+	}
+
+	for _, name := range sortedKeys(form) {
+		property, ok := resolved.Properties[name]
+		if !ok {
+			continue
+		}
+		for _, parameter := range form[name] {
+			if !parameter.Status || parameter.IsFile {
+				continue
+			}
+			violations = append(violations, v.ValidateSchema(&property, parameterValue(parameter.Value, &property), "/"+escapePointer(name))...)
+		}
+	}
+
+	return violations
+}
+
+// arrayItems splits the array values as the style of the parameter serializes
+// them, the exploded query and cookie values hold one item each.
+func (v *ValidatorContract) arrayItems(parameter Parameter, values []string) []string {
+	delimiter := ","
+	if parameter.In == LOCATION_QUERY || parameter.In == LOCATION_COOKIE {
+		serialization := v.collection.querySerialization(parameter)
+		if serialization.Explode {
+			return values
+		}
+
+		switch serialization.Style {
+		case query.PIPE_DELIMITED:
+			delimiter = "|"
+		case query.SPACE_DELIMITED:
+			delimiter = " "
+		}
+	}
+
+	items := make([]string, 0, len(values))
+	for _, value := range values {
+		items = append(items, strings.Split(value, delimiter)...)
+	}
+	return items
+}
+
+func parameterValues(path string, request action.Request, parameter Parameter) ([]string, bool) {
+	values := make([]string, 0)
+	found := false
+
+	switch parameter.In {
+	case "path":
+		if value, ok := pathValue(path, request.Uri, parameter.Name); ok {
+			values = append(values, value)
+		}
+	case "query":
+		queries, _ := request.Query.Find(parameter.Name)
+		for _, q := range queries {
+			if !q.Status {
+				continue
+			}
+			found = true
+			if q.Property == "" {
+				values = append(values, q.Value)
+			}
+		}
+	case "header":
+		if value, ok := findRequestHeader(request, parameter.Name); ok {
+			values = append(values, value)
+		}
+	case "cookie":
+		if cookie, ok := request.Cookie.Cookies[parameter.Name]; ok && cookie.Status {
+			values = append(values, cookie.Value)
+		}
+	}
+
+	return values, found || len(values) > 0
+}
+
+func pathValue(template, uri, name string) (string, bool) {
+	uri, _, _ = strings.Cut(uri, "?")
+
+	templateSegments := strings.Split(strings.Trim(template, "/"), "/")
+	uriSegments := strings.Split(strings.Trim(uri, "/"), "/")
+
+	offset := len(uriSegments) - len(templateSegments)
+	if offset < 0 {
+		return "", false
+	}
+
+	for i, segment := range templateSegments {
+		if segment != "{"+name+"}" {
+			continue
+		}
+
+		value := uriSegments[offset+i]
+		for _, s := range param.FindSegments(value) {
+			if s.Key != "" {
+				return "", false
+			}
+		}
+
+		if unescaped, err := url.PathUnescape(value); err == nil {
+			value = unescaped
+		}
+
+		return value, value != ""
+	}
+
+	return "", false
+}
+
+func findRequestHeader(request action.Request, name string) (string, bool) {
+	for key, values := range request.Header.Headers {
+		if !strings.EqualFold(key, name) {
+			continue
+		}
+		for _, value := range values {
+			if value.Status {
+				return value.Value, true
+			}
+		}
+	}
+	return "", false
+}
+
+func findRequestMediaType(content map[string]MediaType, mediaType string, contentType domain.ContentType) (MediaType, bool) {
+	if media, ok := findMediaType(content, mediaType); ok {
+		return media, true
+	}
+
+	for _, key := range sortedKeys(content) {
+		if kind, ok := domain.ContentTypeFromHeader(key); ok && kind == contentType {
+			return content[key], true
+		}
+	}
+
+	return MediaType{}, false
+}
+
+func hasActiveParameter(parameters []body.BodyParameter) bool {
+	for _, parameter := range parameters {
+		if parameter.Status {
+			return true
+		}
+	}
+	return false
+}
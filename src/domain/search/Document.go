@@ -0,0 +1,219 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	"github.com/Rafael24595/go-api-core/src/domain/collection"
+)
+
+type Kind string
+
+const (
+	REQUEST    Kind = "request"
+	RESPONSE   Kind = "response"
+	COLLECTION Kind = "collection"
+)
+
+type Field string
+
+const (
+	NAME   Field = "name"
+	URI    Field = "uri"
+	QUERY  Field = "query"
+	HEADER Field = "header"
+	BODY   Field = "body"
+	TAG    Field = "tag"
+)
+
+// MAX_INDEXED_BODY is the number of bytes of a body that are indexed, the
+// rest of the payload is not searchable.
+const MAX_INDEXED_BODY = 64 * 1024
+
+// sensitiveHeaders are the headers indexed by name only, their values are
+// credentials.
+var sensitiveHeaders = []string{
+	"authorization", "proxy-authorization", "cookie", "set-cookie",
+}
+
+type document struct {
+	kind    Kind
+	id      string
+	owner   string
+	name    string
+	request string
+	method  string
+	status  int16
+	tags    []string
+	nodes   []string
+	fields  map[Field][]string
+}
+
+func (d document) key() string {
+	return makeKey(d.kind, d.id)
+}
+
+func makeKey(kind Kind, id string) string {
+	return string(kind) + ":" + id
+}
+
+func requestDocument(request action.Request) *document {
+	text := newTextBuilder()
+
+	text.add(NAME, request.Name)
+	text.add(URI, request.Uri)
+
+	tags := domain.NormalizeTags(request.Tags...)
+	for _, v := range tags {
+		text.add(TAG, v)
+	}
+
+	for k, vs := range request.Query.Queries {
+		text.add(QUERY, k)
+		for _, v := range vs {
+			text.add(QUERY, v.Value)
+		}
+	}
+
+	for k, vs := range request.Header.Headers {
+		text.add(HEADER, k)
+		if isSensitiveHeader(k) {
+			continue
+		}
+		for _, v := range vs {
+			text.add(HEADER, v.Value)
+		}
+	}
+
+	for _, parameters := range request.Body.Parameters {
+		for k, vs := range parameters {
+			text.add(BODY, k)
+			for _, v := range vs {
+				if v.IsFile {
+					text.add(BODY, v.FileName)
+					continue
+				}
+				text.add(BODY, v.Value)
+			}
+		}
+	}
+
+	return &document{
+		kind:   REQUEST,
+		id:     request.Id,
+		owner:  request.Owner,
+		name:   request.Name,
+		method: string(request.Method),
+		tags:   tags,
+		fields: text.tokens(),
+	}
+}
+
+// responseDocument indexes the metadata of the response, the payload is only
+// indexed when the body is requested.
+func responseDocument(response action.Response, body bool) *document {
+	text := newTextBuilder()
+	if body {
+		for k, vs := range response.Headers.Headers {
+			text.add(HEADER, k)
+			if isSensitiveHeader(k) {
+				continue
+			}
+			for _, v := range vs {
+				text.add(HEADER, v.Value)
+			}
+		}
+		text.add(BODY, response.Body.Payload)
+	}
+
+	return &document{
+		kind:    RESPONSE,
+		id:      response.Id,
+		owner:   response.Owner,
+		request: response.RequestId(),
+		status:  response.Status,
+		fields:  text.tokens(),
+	}
+}
+
+func collectionDocument(coll collection.Collection) *document {
+	text := newTextBuilder()
+	text.add(NAME, coll.Name)
+
+	tags := domain.NormalizeTags(coll.Tags...)
+	for _, v := range tags {
+		text.add(TAG, v)
+	}
+
+	nodes := make([]string, len(coll.Nodes))
+	for i, v := range coll.Nodes {
+		nodes[i] = v.Item
+	}
+
+	return &document{
+		kind:   COLLECTION,
+		id:     coll.Id,
+		owner:  coll.Owner,
+		name:   coll.Name,
+		nodes:  nodes,
+		tags:   tags,
+		fields: text.tokens(),
+	}
+}
+
+func isSensitiveHeader(key string) bool {
+	key = strings.ToLower(key)
+	for _, v := range sensitiveHeaders {
+		if key == v {
+			return true
+		}
+	}
+	return false
+}
+
+type textBuilder struct {
+	fields map[Field]map[string]bool
+}
+
+func newTextBuilder() *textBuilder {
+	return &textBuilder{
+		fields: make(map[Field]map[string]bool),
+	}
+}
+
+func (b *textBuilder) add(field Field, text string) {
+	if len(text) > MAX_INDEXED_BODY {
+		text = text[:MAX_INDEXED_BODY]
+	}
+
+	for _, v := range tokenize(text) {
+		if b.fields[field] == nil {
+			b.fields[field] = make(map[string]bool)
+		}
+		b.fields[field][v] = true
+	}
+}
+
+func (b *textBuilder) tokens() map[Field][]string {
+	tokens := make(map[Field][]string, len(b.fields))
+	for field, terms := range b.fields {
+		values := make([]string, 0, len(terms))
+		for k := range terms {
+			values = append(values, k)
+		}
+		sort.Strings(values)
+		tokens[field] = values
+	}
+	return tokens
+}
+
+// tokenize splits the text into lower case words and numbers, so
+// "/invoices?tenant_id=1" becomes "invoices", "tenant", "id" and "1".
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
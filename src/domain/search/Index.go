@@ -0,0 +1,402 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	"github.com/Rafael24595/go-api-core/src/domain/collection"
+)
+
+const DEFAULT_LIMIT = 50
+
+// Query searches the documents that contain every term of the text, a term
+// matches the words starting with it. The filters are combined, a document
+// without the filtered field is left out. The responses are filtered by the
+// method and tags of their request.
+type Query struct {
+	Text       string   `json:"text"`
+	Kinds      []Kind   `json:"kinds"`
+	Methods    []string `json:"methods"`
+	Statuses   []int16  `json:"statuses"`
+	Collection string   `json:"collection"`
+	Tags       []string `json:"tags"`
+	Limit      int      `json:"limit"`
+}
+
+type Hit struct {
+	Kind    Kind    `json:"kind"`
+	Id      string  `json:"_id"`
+	Name    string  `json:"name"`
+	Request string  `json:"request,omitempty"`
+	Fields  []Field `json:"fields"`
+	Score   int     `json:"score"`
+}
+
+// Index is an owner scoped full-text index of requests, responses and
+// collections. The responses are always indexed with their status, their
+// headers and body only when the index is built with response bodies. The
+// index follows the writes of the indexed repositories and is reloaded with
+// them when a snapshot is restored.
+type Index struct {
+	mu          sync.RWMutex
+	bodies      bool
+	documents   map[string]map[string]*document
+	owners      map[string]string
+	responses   map[string]map[string]bool
+	collections map[string]map[string]bool
+}
+
+func NewIndex(bodies bool) *Index {
+	return &Index{
+		bodies:      bodies,
+		documents:   make(map[string]map[string]*document),
+		owners:      make(map[string]string),
+		responses:   make(map[string]map[string]bool),
+		collections: make(map[string]map[string]bool),
+	}
+}
+
+func (i *Index) PutRequest(requests ...action.Request) *Index {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, v := range requests {
+		i.put(requestDocument(v))
+	}
+	return i
+}
+
+func (i *Index) PutResponse(responses ...action.Response) *Index {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, v := range responses {
+		i.put(responseDocument(v, i.bodies))
+	}
+	return i
+}
+
+func (i *Index) PutCollection(collections ...collection.Collection) *Index {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, v := range collections {
+		i.put(collectionDocument(v))
+	}
+	return i
+}
+
+// ReloadRequest replaces every indexed request with the given ones.
+func (i *Index) ReloadRequest(requests ...action.Request) *Index {
+	documents := make([]*document, len(requests))
+	for n, v := range requests {
+		documents[n] = requestDocument(v)
+	}
+	return i.reload(REQUEST, documents)
+}
+
+// ReloadResponse replaces every indexed response with the given ones.
+func (i *Index) ReloadResponse(responses ...action.Response) *Index {
+	documents := make([]*document, len(responses))
+	for n, v := range responses {
+		documents[n] = responseDocument(v, i.bodies)
+	}
+	return i.reload(RESPONSE, documents)
+}
+
+// ReloadCollection replaces every indexed collection with the given ones.
+func (i *Index) ReloadCollection(collections ...collection.Collection) *Index {
+	documents := make([]*document, len(collections))
+	for n, v := range collections {
+		documents[n] = collectionDocument(v)
+	}
+	return i.reload(COLLECTION, documents)
+}
+
+func (i *Index) reload(kind Kind, documents []*document) *Index {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for key, owner := range i.owners {
+		if i.documents[owner][key].kind == kind {
+			i.delete(key)
+		}
+	}
+
+	for _, v := range documents {
+		i.put(v)
+	}
+
+	return i
+}
+
+func (i *Index) RemoveRequest(ids ...string) *Index {
+	return i.remove(REQUEST, ids...)
+}
+
+func (i *Index) RemoveResponse(ids ...string) *Index {
+	return i.remove(RESPONSE, ids...)
+}
+
+func (i *Index) RemoveCollection(ids ...string) *Index {
+	return i.remove(COLLECTION, ids...)
+}
+
+func (i *Index) remove(kind Kind, ids ...string) *Index {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, v := range ids {
+		i.delete(makeKey(kind, v))
+	}
+	return i
+}
+
+func (i *Index) put(doc *document) {
+	if doc.id == "" {
+		return
+	}
+
+	key := doc.key()
+	i.delete(key)
+
+	owned, ok := i.documents[doc.owner]
+	if !ok {
+		owned = make(map[string]*document)
+		i.documents[doc.owner] = owned
+	}
+
+	owned[key] = doc
+	i.owners[key] = doc.owner
+
+	switch doc.kind {
+	case RESPONSE:
+		link(i.responses, doc.request, doc.id)
+	case COLLECTION:
+		for _, v := range doc.nodes {
+			link(i.collections, v, doc.id)
+		}
+	}
+}
+
+func (i *Index) delete(key string) {
+	owner, ok := i.owners[key]
+	if !ok {
+		return
+	}
+
+	doc := i.documents[owner][key]
+	delete(i.documents[owner], key)
+	delete(i.owners, key)
+
+	switch doc.kind {
+	case RESPONSE:
+		unlink(i.responses, doc.request, doc.id)
+	case COLLECTION:
+		for _, v := range doc.nodes {
+			unlink(i.collections, v, doc.id)
+		}
+	}
+}
+
+func link(links map[string]map[string]bool, parent, child string) {
+	if links[parent] == nil {
+		links[parent] = make(map[string]bool)
+	}
+	links[parent][child] = true
+}
+
+func unlink(links map[string]map[string]bool, parent, child string) {
+	delete(links[parent], child)
+	if len(links[parent]) == 0 {
+		delete(links, parent)
+	}
+}
+
+// Search returns the documents of the owner that match the query, the best
+// scored first.
+func (i *Index) Search(owner string, query Query) []Hit {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	terms := tokenize(query.Text)
+
+	hits := make([]Hit, 0)
+	for _, doc := range i.documents[owner] {
+		if !i.filter(owner, doc, query) {
+			continue
+		}
+
+		fields, score, ok := i.match(doc, terms)
+		if !ok {
+			continue
+		}
+
+		hits = append(hits, Hit{
+			Kind:    doc.kind,
+			Id:      doc.id,
+			Name:    i.name(owner, doc),
+			Request: doc.request,
+			Fields:  fields,
+			Score:   score,
+		})
+	}
+
+	sort.SliceStable(hits, func(a, b int) bool {
+		if hits[a].Score != hits[b].Score {
+			return hits[a].Score > hits[b].Score
+		}
+		if hits[a].Name != hits[b].Name {
+			return hits[a].Name < hits[b].Name
+		}
+		return hits[a].Id < hits[b].Id
+	})
+
+	limit := query.Limit
+	if limit < 1 {
+		limit = DEFAULT_LIMIT
+	}
+
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	return hits
+}
+
+// match looks for every term in the fields of the document, the score is the
+// number of words matched.
+func (i *Index) match(doc *document, terms []string) ([]Field, int, bool) {
+	matched := make(map[Field]bool)
+	score := 0
+	for _, term := range terms {
+		found := false
+		for field, tokens := range doc.fields {
+			if count := countPrefix(tokens, term); count > 0 {
+				matched[field] = true
+				score += count
+				found = true
+			}
+		}
+		if !found {
+			return nil, 0, false
+		}
+	}
+
+	fields := make([]Field, 0, len(matched))
+	for k := range matched {
+		fields = append(fields, k)
+	}
+
+	sort.Slice(fields, func(a, b int) bool {
+		return fields[a] < fields[b]
+	})
+
+	return fields, score, true
+}
+
+func (i *Index) filter(owner string, doc *document, query Query) bool {
+	if len(query.Kinds) > 0 && !contains(query.Kinds, doc.kind) {
+		return false
+	}
+
+	request, isRequest := doc, doc.kind == REQUEST
+	if parent, ok := i.parent(owner, doc); ok {
+		request, isRequest = parent, true
+	}
+
+	if len(query.Methods) > 0 {
+		if !isRequest || !containsFold(query.Methods, request.method) {
+			return false
+		}
+	}
+
+	if len(query.Statuses) > 0 && !i.matchStatus(owner, doc, query.Statuses) {
+		return false
+	}
+
+	if len(query.Tags) > 0 && !domain.MatchTags(request.tags, query.Tags...) {
+		return false
+	}
+
+	if query.Collection != "" {
+		switch doc.kind {
+		case COLLECTION:
+			if doc.id != query.Collection {
+				return false
+			}
+		case REQUEST:
+			if !i.collections[doc.id][query.Collection] {
+				return false
+			}
+		case RESPONSE:
+			if !i.collections[doc.request][query.Collection] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// matchStatus checks the status of the responses, the requests match when
+// any response of their history has the status.
+func (i *Index) matchStatus(owner string, doc *document, statuses []int16) bool {
+	switch doc.kind {
+	case RESPONSE:
+		return contains(statuses, doc.status)
+	case REQUEST:
+		for id := range i.responses[doc.id] {
+			response, ok := i.documents[owner][makeKey(RESPONSE, id)]
+			if ok && contains(statuses, response.status) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (i *Index) parent(owner string, doc *document) (*document, bool) {
+	if doc.kind != RESPONSE {
+		return nil, false
+	}
+	parent, ok := i.documents[owner][makeKey(REQUEST, doc.request)]
+	return parent, ok
+}
+
+func (i *Index) name(owner string, doc *document) string {
+	if parent, ok := i.parent(owner, doc); ok {
+		return parent.name
+	}
+	return doc.name
+}
+
+// countPrefix counts the sorted tokens that start with the term.
+func countPrefix(tokens []string, term string) int {
+	start := sort.SearchStrings(tokens, term)
+	count := 0
+	for _, v := range tokens[start:] {
+		if !strings.HasPrefix(v, term) {
+			break
+		}
+		count++
+	}
+	return count
+}
+
+func contains[T comparable](values []T, value T) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -2,9 +2,11 @@ package infrastructure
 
 import (
 	"bytes"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"strings"
@@ -21,6 +23,7 @@ import (
 	"github.com/Rafael24595/go-api-core/src/domain/action/header"
 	"github.com/Rafael24595/go-api-core/src/domain/action/query"
 	"github.com/Rafael24595/go-api-core/src/domain/context"
+	"github.com/Rafael24595/go-api-core/src/domain/openapi"
 	"golang.org/x/net/html/charset"
 )
 
@@ -31,12 +34,20 @@ func wrap(kind error, err error) error {
 }
 
 type HttpClient struct {
+	contract *openapi.ValidatorContract
 }
 
 func Client() *HttpClient {
 	return &HttpClient{}
 }
 
+// SetContract enables the pre-send validation of the requests against the
+// OpenAPI contract they were imported from.
+func (c *HttpClient) SetContract(contract *openapi.ValidatorContract) *HttpClient {
+	c.contract = contract
+	return c
+}
+
 func WarmUp() (*action.Response, error) {
 	log.Message("Warming up the HTTP client...")
 
@@ -57,9 +68,31 @@ func WarmUp() (*action.Response, error) {
 
 func (c *HttpClient) FetchWithContext(ctx *context.Context, request *action.Request) (*action.Response, error) {
 	request = context.ProcessRequest(request, ctx)
+	if err := c.validateContract(request); err != nil {
+		return nil, err
+	}
 	return c.Fetch(request)
 }
 
+func (c *HttpClient) validateContract(request *action.Request) error {
+	if c.contract == nil {
+		return nil
+	}
+
+	violations, err := c.contract.ValidateRequest(*request)
+	if err != nil {
+		return wrap(ErrValidation, err)
+	}
+
+	if len(violations) > 0 {
+		return wrap(ErrValidation, &openapi.ContractError{
+			Violations: violations,
+		})
+	}
+
+	return nil
+}
+
 func (c *HttpClient) Fetch(request *action.Request) (*action.Response, error) {
 	err := valideRequest(request)
 	if err != nil {
@@ -71,7 +104,10 @@ func (c *HttpClient) Fetch(request *action.Request) (*action.Response, error) {
 		return nil, err
 	}
 
-	client := &http.Client{}
+	client, err := c.makeClient(request.Options)
+	if err != nil {
+		return nil, err
+	}
 
 	start := time.Now().UnixMilli()
 	resp, respErr := client.Do(req)
@@ -88,9 +124,57 @@ func (c *HttpClient) Fetch(request *action.Request) (*action.Response, error) {
 	return response, nil
 }
 
+// makeClient builds the client of the request, the default client is used
+// unless the request options are enabled.
+func (c *HttpClient) makeClient(options action.ClientOptions) (*http.Client, error) {
+	if !options.Status {
+		return &http.Client{}, nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DisableCompression = !options.Compressed
+
+	if options.Insecure {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+
+	if options.Proxy != "" {
+		proxy := options.Proxy
+		if !strings.Contains(proxy, "://") {
+			proxy = "http://" + proxy
+		}
+		uri, err := url.Parse(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy '%s': %s", options.Proxy, err.Error())
+		}
+		transport.Proxy = http.ProxyURL(uri)
+	}
+
+	if options.ConnectTimeout > 0 {
+		dialer := &net.Dialer{
+			Timeout:   time.Duration(options.ConnectTimeout) * time.Millisecond,
+			KeepAlive: 30 * time.Second,
+		}
+		transport.DialContext = dialer.DialContext
+	}
+
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   time.Duration(options.Timeout) * time.Millisecond,
+	}
+
+	if !options.FollowRedirects {
+		client.CheckRedirect = func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	}
+
+	return client, nil
+}
+
 func (c *HttpClient) makeRequest(operation *action.Request) (*http.Request, error) {
 	method := operation.Method.String()
-	uri := strings.TrimSpace(operation.Uri)
+	uri := strings.TrimSpace(operation.Param.Apply(operation.Uri))
 
 	payload := new(bytes.Buffer)
 	if !operation.Body.Empty() && operation.Body.Status && method != "GET" && method != "HEAD" {
@@ -117,16 +201,7 @@ func (c *HttpClient) makeRequest(operation *action.Request) (*http.Request, erro
 }
 
 func (c *HttpClient) applyQuery(operation *action.Request, req *http.Request) *http.Request {
-	query := req.URL.Query()
-	for k, q := range operation.Query.Queries {
-		for _, v := range q {
-			if !v.Status {
-				continue
-			}
-			query.Add(k, v.Value)
-		}
-	}
-	req.URL.RawQuery = query.Encode()
+	req.URL.RawQuery = operation.Query.Encode(req.URL.Query())
 	return req
 }
 
@@ -179,7 +254,7 @@ func (c *HttpClient) makeResponse(owner string, start int64, end int64, req *act
 	}
 
 	return &action.Response{
-		Id:        req.Id,
+		Id:        "",
 		Timestamp: end,
 		Request:   req.Id,
 		Date:      start,
@@ -264,7 +339,7 @@ func (c *HttpClient) makeBody(resp *http.Response) (*body.BodyResponse, int, err
 }
 
 func valideRequest(request *action.Request) error {
-	uri := strings.TrimSpace(request.Uri)
+	uri := strings.TrimSpace(request.Param.Apply(request.Uri))
 	if _, err := url.ParseRequestURI(uri); err != nil {
 		return wrap(ErrValidation, err)
 	}
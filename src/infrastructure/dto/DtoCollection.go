@@ -14,6 +14,7 @@ type DtoCollection struct {
 	Owner     string                      `json:"owner"`
 	Modified  int64                       `json:"modified"`
 	Status    collection.StatusCollection `json:"status"`
+	Tags      []string                    `json:"tags"`
 }
 
 func FromCollection(collection *collection.Collection, ctx *DtoContext, nodes []action.NodeRequest) *DtoCollection {
@@ -25,6 +26,7 @@ func FromCollection(collection *collection.Collection, ctx *DtoContext, nodes []
 		Nodes:     FromNodeRequest(nodes),
 		Owner:     collection.Owner,
 		Modified:  collection.Modified,
+		Tags:      collection.Tags,
 	}
 }
 
@@ -38,5 +40,6 @@ func ToCollection(dto *DtoCollection) *collection.Collection {
 		Owner:     dto.Owner,
 		Modified:  dto.Modified,
 		Status:    dto.Status,
+		Tags:      dto.Tags,
 	}
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/Rafael24595/go-api-core/src/domain/action/auth"
 	"github.com/Rafael24595/go-api-core/src/domain/action/cookie"
 	"github.com/Rafael24595/go-api-core/src/domain/action/header"
+	"github.com/Rafael24595/go-api-core/src/domain/action/param"
 	"github.com/Rafael24595/go-api-core/src/domain/action/query"
 )
 
@@ -17,6 +18,7 @@ type DtoRequest struct {
 	Name      string               `json:"name"`
 	Method    domain.HttpMethod    `json:"method"`
 	Uri       string               `json:"uri"`
+	Param     param.Params         `json:"param"`
 	Query     query.Queries        `json:"query"`
 	Header    header.Headers       `json:"header"`
 	Cookie    cookie.CookiesClient `json:"cookie"`
@@ -25,6 +27,8 @@ type DtoRequest struct {
 	Owner     string               `json:"owner"`
 	Modified  int64                `json:"modified"`
 	Status    action.StatusRequest `json:"status"`
+	Tags      []string             `json:"tags"`
+	Options   action.ClientOptions `json:"options"`
 }
 
 func ToRequests(dtos ...DtoRequest) []action.Request {
@@ -42,6 +46,7 @@ func ToRequest(dto *DtoRequest) *action.Request {
 		Name:      dto.Name,
 		Method:    dto.Method,
 		Uri:       dto.Uri,
+		Param:     dto.Param,
 		Query:     dto.Query,
 		Header:    dto.Header,
 		Cookie:    dto.Cookie,
@@ -50,6 +55,8 @@ func ToRequest(dto *DtoRequest) *action.Request {
 		Owner:     dto.Owner,
 		Modified:  dto.Modified,
 		Status:    dto.Status,
+		Tags:      dto.Tags,
+		Options:   dto.Options,
 	}
 }
 
@@ -68,6 +75,7 @@ func FromRequest(request *action.Request) *DtoRequest {
 		Name:      request.Name,
 		Method:    request.Method,
 		Uri:       request.Uri,
+		Param:     request.Param,
 		Query:     request.Query,
 		Header:    request.Header,
 		Cookie:    request.Cookie,
@@ -76,5 +84,7 @@ func FromRequest(request *action.Request) *DtoRequest {
 		Owner:     request.Owner,
 		Modified:  request.Modified,
 		Status:    request.Status,
+		Tags:      request.Tags,
+		Options:   request.Options,
 	}
 }
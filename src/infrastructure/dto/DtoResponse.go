@@ -18,6 +18,7 @@ type DtoResponse struct {
 	Cookies   cookie.CookiesServer `json:"cookies"`
 	Body      body.BodyResponse    `json:"body"`
 	Size      int                  `json:"size"`
+	Pinned    bool                 `json:"pinned"`
 	Owner     string               `json:"owner"`
 }
 
@@ -33,6 +34,7 @@ func ToResponse(dto *DtoResponse) *action.Response {
 		Cookies:   dto.Cookies,
 		Body:      dto.Body,
 		Size:      dto.Size,
+		Pinned:    dto.Pinned,
 		Owner:     dto.Owner,
 	}
 }
@@ -49,6 +51,7 @@ func FromResponse(request *action.Response) *DtoResponse {
 		Cookies:   request.Cookies,
 		Body:      request.Body,
 		Size:      request.Size,
+		Pinned:    request.Pinned,
 		Owner:     request.Owner,
 	}
 }
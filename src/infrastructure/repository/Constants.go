@@ -11,4 +11,6 @@ const (
 	CSVT_FILE_PATH_TOKEN       string = "./db/table_token.csvt"
 	CSVT_FILE_PATH_SESSION     string = "./db/table_session.csvt"
 	CSVT_FILE_PATH_CLIENT_DATA string = "./db/table_client_data.csvt"
+	CSVT_FILE_PATH_REVISION    string = "./db/table_revision.csvt"
+	CSVT_FILE_PATH_CONTRACT    string = "./db/table_contract.csvt"
 )
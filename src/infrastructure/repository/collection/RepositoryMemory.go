@@ -74,6 +74,7 @@ func (r *RepositoryMemory) watch() {
 					return
 				}
 				log.Customf(repository.RepositoryCategory, "The repository %q has been reloaded.", NameMemory)
+				conf.EventHub.Publish(topic_repository.TOPIC_COLLECTION.ActionReloaded().Code, "true")
 			case <-conf.Signal.Done():
 				log.Customf(repository.RepositoryCategory, "Watcher stopped: global shutdown signal received.")
 				return
@@ -92,6 +93,12 @@ func (r *RepositoryMemory) read() error {
 	return nil
 }
 
+func (r *RepositoryMemory) FindAll() []collection.Collection {
+	r.muMemory.RLock()
+	defer r.muMemory.RUnlock()
+	return r.collection.Values()
+}
+
 func (r *RepositoryMemory) Find(id string) (*collection.Collection, bool) {
 	r.muMemory.RLock()
 	defer r.muMemory.RUnlock()
@@ -149,6 +156,7 @@ func (r *RepositoryMemory) insert(owner string, coll *collection.Collection) *co
 	}
 
 	coll.Modified = time.Now().UnixMilli()
+	coll.Tags = domain.NormalizeTags(coll.Tags...)
 
 	if coll.Name == "" {
 		coll.Name = fmt.Sprintf("%s-%d", coll.Owner, coll.Timestamp)
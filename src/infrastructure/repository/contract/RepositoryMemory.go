@@ -0,0 +1,130 @@
+package contract
+
+import (
+	"sync"
+
+	topic_repository "github.com/Rafael24595/go-api-core/src/commons/system/topic/repository"
+
+	"github.com/Rafael24595/go-api-core/src/commons/configuration"
+	"github.com/Rafael24595/go-api-core/src/commons/system"
+	"github.com/Rafael24595/go-api-core/src/commons/system/topic"
+	"github.com/Rafael24595/go-api-core/src/domain/openapi"
+	"github.com/Rafael24595/go-api-core/src/infrastructure/repository"
+	"github.com/Rafael24595/go-collections/collection"
+	"github.com/Rafael24595/go-log/log"
+)
+
+const NameMemory = "contract_memory"
+
+type RepositoryMemory struct {
+	once       sync.Once
+	muMemory   sync.RWMutex
+	muFile     sync.RWMutex
+	collection collection.IDictionary[string, openapi.Contract]
+	file       repository.IFileManager[openapi.Contract]
+	close      chan bool
+}
+
+func InitializeRepositoryMemory(impl collection.IDictionary[string, openapi.Contract], file repository.IFileManager[openapi.Contract]) (*RepositoryMemory, error) {
+	contracts, err := file.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	instance := &RepositoryMemory{
+		collection: impl.Merge(collection.DictionaryFromMap(contracts)),
+		file:       file,
+	}
+
+	go instance.watch()
+
+	return instance, nil
+}
+
+func (r *RepositoryMemory) watch() {
+	r.once.Do(func() {
+		conf := configuration.Instance()
+		if !conf.Snapshot().Enable {
+			return
+		}
+
+		hub := make(chan system.SystemEvent, 1)
+		defer close(hub)
+
+		topics := []topic.TopicAction{
+			topic_repository.TOPIC_CONTRACT.ActionReload(),
+		}
+
+		conf.EventHub.Subcribe(repository.RepositoryListener, hub, topics...)
+		defer conf.EventHub.Unsubcribe(repository.RepositoryListener, topics...)
+
+		for {
+			select {
+			case <-r.close:
+				log.Customf(repository.RepositoryCategory, "Watcher stopped: local close signal received.")
+				return
+			case <-hub:
+				if err := r.read(); err != nil {
+					log.Custome(repository.RepositoryCategory, err)
+					return
+				}
+				log.Customf(repository.RepositoryCategory, "The repository %q has been reloaded.", NameMemory)
+			case <-conf.Signal.Done():
+				log.Customf(repository.RepositoryCategory, "Watcher stopped: global shutdown signal received.")
+				return
+			}
+		}
+	})
+}
+
+func (r *RepositoryMemory) read() error {
+	contracts, err := r.file.Read()
+	if err != nil {
+		return err
+	}
+
+	r.collection = collection.DictionaryFromMap(contracts)
+	return nil
+}
+
+func (r *RepositoryMemory) Find(id string) (*openapi.Contract, bool) {
+	r.muMemory.RLock()
+	defer r.muMemory.RUnlock()
+	contract, ok := r.collection.Get(id)
+	return &contract, ok
+}
+
+// Insert stores the contract by the id of its collection, so a collection
+// keeps only its last contract.
+func (r *RepositoryMemory) Insert(owner string, contract *openapi.Contract) *openapi.Contract {
+	r.muMemory.Lock()
+	defer r.muMemory.Unlock()
+
+	contract.Owner = owner
+	r.collection.Put(contract.Id, *contract)
+
+	go r.write(r.collection)
+
+	return contract
+}
+
+func (r *RepositoryMemory) Delete(contract openapi.Contract) *openapi.Contract {
+	r.muMemory.Lock()
+	defer r.muMemory.Unlock()
+
+	cursor, _ := r.collection.Remove(contract.Id)
+
+	go r.write(r.collection)
+
+	return &cursor
+}
+
+func (r *RepositoryMemory) write(snapshot collection.IDictionary[string, openapi.Contract]) {
+	r.muFile.Lock()
+	defer r.muFile.Unlock()
+
+	err := r.file.Write(snapshot.Values())
+	if err != nil {
+		log.Error(err)
+	}
+}
@@ -129,16 +129,25 @@ func (r *EndPointRepositoryMemory) Find(id string) (*mock_domain.EndPoint, bool)
 	return &endpoint, ok
 }
 
+// FindByRequest matches the templated end point paths too, the end points
+// with the exact request path come first.
 func (r *EndPointRepositoryMemory) FindByRequest(owner string, method domain.HttpMethod, path string) (*mock_domain.EndPoint, bool) {
 	r.muMemory.RLock()
 	defer r.muMemory.RUnlock()
 
 	endpoint, ok := r.collection.
 		Filter(func(s string, ep mock_domain.EndPoint) bool {
-			return ep.Owner == owner && ep.Method == method && ep.Path == path
+			if ep.Owner != owner || ep.Method != method {
+				return false
+			}
+			_, ok := mock_domain.MatchPath(ep.Path, path)
+			return ok
 		}).
 		ValuesVector().
 		Sort(func(i, j mock_domain.EndPoint) bool {
+			if (i.Path == path) != (j.Path == path) {
+				return i.Path == path
+			}
 			return i.Order < j.Order
 		}).
 		First()
@@ -73,6 +73,7 @@ func (r *RepositoryMemory) watch() {
 					return
 				}
 				log.Customf(repository.RepositoryCategory, "The repository %q has been reloaded.", NameMemory)
+				conf.EventHub.Publish(topic_repository.TOPIC_REQUEST.ActionReloaded().Code, "true")
 			case <-conf.Signal.Done():
 				log.Customf(repository.RepositoryCategory, "Watcher stopped: global shutdown signal received.")
 				return
@@ -91,6 +92,12 @@ func (r *RepositoryMemory) read() error {
 	return nil
 }
 
+func (r *RepositoryMemory) FindAll() []action.Request {
+	r.muMemory.RLock()
+	defer r.muMemory.RUnlock()
+	return r.collection.Values()
+}
+
 func (r *RepositoryMemory) Find(key string) (*action.Request, bool) {
 	r.muMemory.RLock()
 	defer r.muMemory.RUnlock()
@@ -157,6 +164,7 @@ func (r *RepositoryMemory) Insert(owner string, request *action.Request) *action
 	}
 
 	request.Modified = time.Now().UnixMilli()
+	request.Tags = domain.NormalizeTags(request.Tags...)
 
 	if request.Name == "" {
 		request.Name = fmt.Sprintf("%s-%s-%d", request.Owner, request.Method, request.Timestamp)
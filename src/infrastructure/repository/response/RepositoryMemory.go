@@ -70,6 +70,7 @@ func (r *RepositoryMemory) watch() {
 					return
 				}
 				log.Customf(repository.RepositoryCategory, "The repository %q has been reloaded.", NameMemory)
+				conf.EventHub.Publish(topic_repository.TOPIC_RESPONSE.ActionReloaded().Code, "true")
 			case <-conf.Signal.Done():
 				log.Customf(repository.RepositoryCategory, "Watcher stopped: global shutdown signal received.")
 				return
@@ -88,6 +89,12 @@ func (r *RepositoryMemory) read() error {
 	return nil
 }
 
+func (r *RepositoryMemory) FindAll() []action.Response {
+	r.muMemory.RLock()
+	defer r.muMemory.RUnlock()
+	return r.collection.Values()
+}
+
 func (r *RepositoryMemory) Find(key string) (*action.Response, bool) {
 	r.muMemory.RLock()
 	defer r.muMemory.RUnlock()
@@ -109,6 +116,25 @@ func (r *RepositoryMemory) FindMany(ids []string) []action.Response {
 	return responses
 }
 
+func (r *RepositoryMemory) FindByRequest(requests ...string) []action.Response {
+	r.muMemory.RLock()
+	defer r.muMemory.RUnlock()
+
+	keys := make(map[string]bool)
+	for _, v := range requests {
+		keys[v] = true
+	}
+
+	responses := make([]action.Response, 0)
+	for _, v := range r.collection.Values() {
+		if keys[v.RequestId()] {
+			responses = append(responses, v)
+		}
+	}
+
+	return action.SortResponses(responses)
+}
+
 func (r *RepositoryMemory) Insert(owner string, response *action.Response) *action.Response {
 	r.muMemory.Lock()
 	defer r.muMemory.Unlock()
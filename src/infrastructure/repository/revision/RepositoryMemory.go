@@ -0,0 +1,165 @@
+package revision
+
+import (
+	"sync"
+
+	topic_repository "github.com/Rafael24595/go-api-core/src/commons/system/topic/repository"
+
+	"github.com/Rafael24595/go-api-core/src/commons/configuration"
+	"github.com/Rafael24595/go-api-core/src/commons/system"
+	"github.com/Rafael24595/go-api-core/src/commons/system/topic"
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	"github.com/Rafael24595/go-api-core/src/infrastructure/repository"
+	"github.com/Rafael24595/go-collections/collection"
+	"github.com/Rafael24595/go-log/log"
+	"github.com/google/uuid"
+)
+
+const NameMemory = "revision_memory"
+
+type RepositoryMemory struct {
+	once       sync.Once
+	muMemory   sync.RWMutex
+	muFile     sync.RWMutex
+	collection collection.IDictionary[string, action.Revision]
+	file       repository.IFileManager[action.Revision]
+	close      chan bool
+}
+
+func InitializeRepositoryMemory(impl collection.IDictionary[string, action.Revision], file repository.IFileManager[action.Revision]) (*RepositoryMemory, error) {
+	revisions, err := file.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	instance := &RepositoryMemory{
+		collection: impl.Merge(collection.DictionaryFromMap(revisions)),
+		file:       file,
+	}
+
+	go instance.watch()
+
+	return instance, nil
+}
+
+func (r *RepositoryMemory) watch() {
+	r.once.Do(func() {
+		conf := configuration.Instance()
+		if !conf.Snapshot().Enable {
+			return
+		}
+
+		hub := make(chan system.SystemEvent, 1)
+		defer close(hub)
+
+		topics := []topic.TopicAction{
+			topic_repository.TOPIC_REVISION.ActionReload(),
+		}
+
+		conf.EventHub.Subcribe(repository.RepositoryListener, hub, topics...)
+		defer conf.EventHub.Unsubcribe(repository.RepositoryListener, topics...)
+
+		for {
+			select {
+			case <-r.close:
+				log.Customf(repository.RepositoryCategory, "Watcher stopped: local close signal received.")
+				return
+			case <-hub:
+				if err := r.read(); err != nil {
+					log.Custome(repository.RepositoryCategory, err)
+					return
+				}
+				log.Customf(repository.RepositoryCategory, "The repository %q has been reloaded.", NameMemory)
+			case <-conf.Signal.Done():
+				log.Customf(repository.RepositoryCategory, "Watcher stopped: global shutdown signal received.")
+				return
+			}
+		}
+	})
+}
+
+func (r *RepositoryMemory) read() error {
+	revisions, err := r.file.Read()
+	if err != nil {
+		return err
+	}
+
+	r.collection = collection.DictionaryFromMap(revisions)
+	return nil
+}
+
+func (r *RepositoryMemory) Find(id string) (*action.Revision, bool) {
+	r.muMemory.RLock()
+	defer r.muMemory.RUnlock()
+	revision, ok := r.collection.Get(id)
+	return &revision, ok
+}
+
+func (r *RepositoryMemory) FindByRequest(requests ...string) []action.Revision {
+	r.muMemory.RLock()
+	defer r.muMemory.RUnlock()
+
+	keys := make(map[string]bool)
+	for _, v := range requests {
+		keys[v] = true
+	}
+
+	revisions := make([]action.Revision, 0)
+	for _, v := range r.collection.Values() {
+		if keys[v.Request] {
+			revisions = append(revisions, v)
+		}
+	}
+
+	return action.SortRevisions(revisions)
+}
+
+func (r *RepositoryMemory) Insert(owner string, revision *action.Revision) *action.Revision {
+	r.muMemory.Lock()
+	defer r.muMemory.Unlock()
+
+	revision.Owner = owner
+
+	if revision.Id != "" {
+		r.collection.Put(revision.Id, *revision)
+		go r.write(r.collection)
+		return revision
+	}
+
+	key := uuid.New().String()
+	if r.collection.Exists(key) {
+		return r.Insert(owner, revision)
+	}
+
+	revision.Id = key
+	r.collection.Put(key, *revision)
+
+	go r.write(r.collection)
+
+	return revision
+}
+
+func (r *RepositoryMemory) DeleteMany(revisions ...action.Revision) []action.Revision {
+	r.muMemory.Lock()
+	defer r.muMemory.Unlock()
+
+	deleted := make([]action.Revision, 0)
+	for _, v := range revisions {
+		cursor, _ := r.collection.Remove(v.Id)
+		deleted = append(deleted, cursor)
+	}
+
+	go r.write(r.collection)
+
+	return deleted
+}
+
+func (r *RepositoryMemory) write(snapshot collection.IDictionary[string, action.Revision]) {
+	r.muFile.Lock()
+	defer r.muFile.Unlock()
+
+	err := r.file.Write(snapshot.Values())
+	if err != nil {
+		log.Error(err)
+	}
+}
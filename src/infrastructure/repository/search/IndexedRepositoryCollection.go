@@ -0,0 +1,41 @@
+package search
+
+import (
+	topic_repository "github.com/Rafael24595/go-api-core/src/commons/system/topic/repository"
+
+	"github.com/Rafael24595/go-api-core/src/domain/collection"
+	"github.com/Rafael24595/go-api-core/src/domain/search"
+)
+
+// IndexedRepositoryCollection keeps the search index up to date with the
+// collections written through the repository, the index is rebuilt when the
+// repository is reloaded.
+type IndexedRepositoryCollection struct {
+	collection.Repository
+	index *search.Index
+}
+
+func NewIndexedRepositoryCollection(repository collection.Repository, index *search.Index) *IndexedRepositoryCollection {
+	index.PutCollection(repository.FindAll()...)
+	instance := &IndexedRepositoryCollection{
+		Repository: repository,
+		index:      index,
+	}
+
+	go watch(topic_repository.TOPIC_COLLECTION, func() {
+		index.ReloadCollection(repository.FindAll()...)
+	})
+
+	return instance
+}
+
+func (r *IndexedRepositoryCollection) Insert(owner string, coll *collection.Collection) *collection.Collection {
+	coll = r.Repository.Insert(owner, coll)
+	r.index.PutCollection(*coll)
+	return coll
+}
+
+func (r *IndexedRepositoryCollection) Delete(coll *collection.Collection) *collection.Collection {
+	r.index.RemoveCollection(coll.Id)
+	return r.Repository.Delete(coll)
+}
@@ -0,0 +1,54 @@
+package search
+
+import (
+	topic_repository "github.com/Rafael24595/go-api-core/src/commons/system/topic/repository"
+
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	"github.com/Rafael24595/go-api-core/src/domain/search"
+)
+
+// IndexedRepositoryRequest keeps the search index up to date with the
+// requests written through the repository, the index is rebuilt when the
+// repository is reloaded.
+type IndexedRepositoryRequest struct {
+	action.RepositoryRequest
+	index *search.Index
+}
+
+func NewIndexedRepositoryRequest(repository action.RepositoryRequest, index *search.Index) *IndexedRepositoryRequest {
+	index.PutRequest(repository.FindAll()...)
+	instance := &IndexedRepositoryRequest{
+		RepositoryRequest: repository,
+		index:             index,
+	}
+
+	go watch(topic_repository.TOPIC_REQUEST, func() {
+		index.ReloadRequest(repository.FindAll()...)
+	})
+
+	return instance
+}
+
+func (r *IndexedRepositoryRequest) Insert(owner string, request *action.Request) *action.Request {
+	request = r.RepositoryRequest.Insert(owner, request)
+	r.index.PutRequest(*request)
+	return request
+}
+
+func (r *IndexedRepositoryRequest) InsertMany(owner string, requests []action.Request) []action.Request {
+	requests = r.RepositoryRequest.InsertMany(owner, requests)
+	r.index.PutRequest(requests...)
+	return requests
+}
+
+func (r *IndexedRepositoryRequest) Delete(request *action.Request) *action.Request {
+	r.index.RemoveRequest(request.Id)
+	return r.RepositoryRequest.Delete(request)
+}
+
+func (r *IndexedRepositoryRequest) DeleteMany(requests ...action.Request) []action.Request {
+	for _, v := range requests {
+		r.index.RemoveRequest(v.Id)
+	}
+	return r.RepositoryRequest.DeleteMany(requests...)
+}
@@ -0,0 +1,48 @@
+package search
+
+import (
+	topic_repository "github.com/Rafael24595/go-api-core/src/commons/system/topic/repository"
+
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	"github.com/Rafael24595/go-api-core/src/domain/search"
+)
+
+// IndexedRepositoryResponse keeps the search index up to date with the
+// responses written through the repository, the index is rebuilt when the
+// repository is reloaded.
+type IndexedRepositoryResponse struct {
+	action.RepositoryResponse
+	index *search.Index
+}
+
+func NewIndexedRepositoryResponse(repository action.RepositoryResponse, index *search.Index) *IndexedRepositoryResponse {
+	index.PutResponse(repository.FindAll()...)
+	instance := &IndexedRepositoryResponse{
+		RepositoryResponse: repository,
+		index:              index,
+	}
+
+	go watch(topic_repository.TOPIC_RESPONSE, func() {
+		index.ReloadResponse(repository.FindAll()...)
+	})
+
+	return instance
+}
+
+func (r *IndexedRepositoryResponse) Insert(owner string, response *action.Response) *action.Response {
+	response = r.RepositoryResponse.Insert(owner, response)
+	r.index.PutResponse(*response)
+	return response
+}
+
+func (r *IndexedRepositoryResponse) Delete(response *action.Response) *action.Response {
+	r.index.RemoveResponse(response.Id)
+	return r.RepositoryResponse.Delete(response)
+}
+
+func (r *IndexedRepositoryResponse) DeleteMany(responses ...action.Response) []action.Response {
+	for _, v := range responses {
+		r.index.RemoveResponse(v.Id)
+	}
+	return r.RepositoryResponse.DeleteMany(responses...)
+}
@@ -0,0 +1,40 @@
+package search
+
+import (
+	topic_repository "github.com/Rafael24595/go-api-core/src/commons/system/topic/repository"
+
+	"github.com/Rafael24595/go-api-core/src/commons/configuration"
+	"github.com/Rafael24595/go-api-core/src/commons/system"
+	"github.com/Rafael24595/go-api-core/src/infrastructure/repository"
+	"github.com/Rafael24595/go-log/log"
+)
+
+const SearchListener = "search"
+
+// watch rebuilds the indexed documents every time the repository of the
+// topic is reloaded from a snapshot.
+func watch(topic topic_repository.TopicRepository, rebuild func()) {
+	conf := configuration.Instance()
+	if !conf.Snapshot().Enable {
+		return
+	}
+
+	hub := make(chan system.SystemEvent, 1)
+	defer close(hub)
+
+	action := topic.ActionReloaded()
+
+	conf.EventHub.Subcribe(SearchListener, hub, action)
+	defer conf.EventHub.Unsubcribe(SearchListener, action)
+
+	for {
+		select {
+		case <-hub:
+			rebuild()
+			log.Customf(repository.RepositoryCategory, "The search index of %q has been rebuilt.", string(topic))
+		case <-conf.Signal.Done():
+			log.Customf(repository.RepositoryCategory, "Watcher stopped: global shutdown signal received.")
+			return
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package param_test
+
+import (
+	"testing"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	"github.com/Rafael24595/go-api-core/src/domain/action/param"
+	"github.com/Rafael24595/go-api-core/src/domain/context"
+	"github.com/Rafael24595/go-api-core/test/support/assert"
+)
+
+func TestApply_Braces(t *testing.T) {
+	params := param.NewParams().
+		Add("userId", "42").
+		Add("taskId", "a b")
+
+	result := params.Apply("https://api.example.com/users/{userId}/tasks/{taskId}")
+
+	assert.Equal(t, "https://api.example.com/users/42/tasks/a%20b", result)
+}
+
+func TestApply_Colon(t *testing.T) {
+	params := param.NewParams().
+		Add("userId", "42").
+		Add("port", "9999")
+
+	result := params.Apply("http://localhost:8080/users/:userId/profile")
+
+	assert.Equal(t, "http://localhost:8080/users/42/profile", result)
+}
+
+func TestApply_DisabledAndUnknown(t *testing.T) {
+	params := param.NewParams().
+		AddStatus("userId", "42", false)
+
+	result := params.Apply("/users/{userId}/tasks/:taskId")
+
+	assert.Equal(t, "/users/{userId}/tasks/:taskId", result)
+}
+
+func TestApply_IgnoreContextVariables(t *testing.T) {
+	params := param.NewParams().
+		Add("server", "https://example.com").
+		Add("id", "7")
+
+	result := params.Apply("${server}/items/{id}")
+
+	assert.Equal(t, "${server}/items/7", result)
+}
+
+func TestFindKeys(t *testing.T) {
+	keys := param.FindKeys("https://example.com:443/a/{first}/b/:second/{first}?q=:none")
+
+	assert.Len(t, 2, keys)
+	assert.Equal(t, "first", keys[0])
+	assert.Equal(t, "second", keys[1])
+}
+
+func TestAddParam_Replace(t *testing.T) {
+	params := param.NewParams().
+		Add("id", "1").
+		Add("id", "2")
+
+	assert.Len(t, 1, params.Params)
+	assert.Equal(t, "2", params.Params[0].Value)
+}
+
+func TestProcessRequest_Params(t *testing.T) {
+	ctx := context.NewContext("anonymous")
+	ctx.Put(context.URI, "user", "john", false)
+
+	req := action.NewRequest("_test_params", domain.GET, "https://example.com/users/{id}")
+	req.Param.Add("id", "${user}")
+
+	result := context.ProcessRequest(req, ctx)
+
+	assert.Equal(t, "https://example.com/users/john", result.Param.Apply(result.Uri))
+}
@@ -0,0 +1,98 @@
+package query_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/Rafael24595/go-api-core/src/domain/action/query"
+	"github.com/Rafael24595/go-api-core/test/support/assert"
+)
+
+func TestEncode_Default(t *testing.T) {
+	queries := query.NewQueries().
+		Add("id", "1").
+		Add("id", "2").
+		Add("name", "a b")
+
+	assert.Equal(t, "id=1&id=2&name=a+b", queries.Encode(nil))
+}
+
+func TestEncode_KeepsExistingValues(t *testing.T) {
+	queries := query.NewQueries().
+		Add("id", "1")
+
+	result := queries.Encode(url.Values{"page": []string{"3"}})
+
+	assert.Equal(t, "id=1&page=3", result)
+}
+
+func TestEncode_FormNoExplode(t *testing.T) {
+	queries := query.NewQueries().
+		Add("ids", "1").
+		Add("ids", "2").
+		PutStyle("ids", query.FORM, false)
+
+	assert.Equal(t, "ids=1,2", queries.Encode(nil))
+}
+
+func TestEncode_Delimited(t *testing.T) {
+	queries := query.NewQueries().
+		Add("space", "a").
+		Add("space", "b").
+		Add("pipe", "c").
+		Add("pipe", "d").
+		PutStyle("space", query.SPACE_DELIMITED, false).
+		PutStyle("pipe", query.PIPE_DELIMITED, false)
+
+	assert.Equal(t, "pipe=c|d&space=a%20b", queries.Encode(nil))
+}
+
+func TestEncode_Brackets(t *testing.T) {
+	queries := query.NewQueries().
+		Add("ids", "1").
+		Add("ids", "2").
+		Add("page", "1").
+		PutStyle("ids", query.BRACKETS, true)
+
+	assert.Equal(t, "page=1&ids[]=1&ids[]=2", queries.Encode(nil))
+}
+
+func TestEncode_DeepObject(t *testing.T) {
+	queries := query.NewQueries().
+		AddQuery("filter", query.NewPropertyQuery(0, true, "status", "open")).
+		AddQuery("filter", query.NewPropertyQuery(1, true, "owner", "john doe")).
+		AddQuery("filter", query.NewPropertyQuery(2, false, "tag", "x")).
+		PutStyle("filter", query.DEEP_OBJECT, true)
+
+	assert.Equal(t, "filter[status]=open&filter[owner]=john+doe", queries.Encode(nil))
+}
+
+func TestEncode_ObjectForm(t *testing.T) {
+	queries := query.NewQueries().
+		AddQuery("color", query.NewPropertyQuery(0, true, "R", "100")).
+		AddQuery("color", query.NewPropertyQuery(1, true, "G", "200"))
+
+	assert.Equal(t, "R=100&G=200", queries.Encode(nil))
+
+	queries.PutStyle("color", query.FORM, false)
+
+	assert.Equal(t, "color=R,100,G,200", queries.Encode(nil))
+}
+
+func TestPutSerialization_Default(t *testing.T) {
+	queries := query.NewQueries().
+		PutStyle("ids", query.PIPE_DELIMITED, false).
+		PutSerialization("ids", query.DefaultSerialization())
+
+	assert.Equal(t, 0, len(queries.Styles))
+	assert.Equal(t, query.DefaultSerialization(), queries.FindSerialization("ids"))
+}
+
+func TestStyleFromString(t *testing.T) {
+	style, err := query.StyleFromString("deepobject")
+	assert.NotError(t, err)
+	assert.Equal(t, query.DEEP_OBJECT, *style)
+
+	_, err = query.StyleFromString("matrix")
+	assert.Error(t, err)
+}
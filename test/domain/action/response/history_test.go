@@ -0,0 +1,71 @@
+package response_test
+
+import (
+	"testing"
+
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	"github.com/Rafael24595/go-api-core/test/support/assert"
+)
+
+func makeHistory() []action.Response {
+	return []action.Response{
+		{Id: "rsp-2", Request: "req-1", Timestamp: 200},
+		{Id: "rsp-4", Request: "req-1", Timestamp: 400},
+		{Id: "rsp-1", Request: "req-1", Timestamp: 100, Pinned: true},
+		{Id: "rsp-3", Request: "req-1", Timestamp: 300},
+	}
+}
+
+func TestSortResponses(t *testing.T) {
+	history := action.SortResponses(makeHistory())
+
+	assert.Equal(t, "rsp-4", history[0].Id)
+	assert.Equal(t, "rsp-3", history[1].Id)
+	assert.Equal(t, "rsp-2", history[2].Id)
+	assert.Equal(t, "rsp-1", history[3].Id)
+}
+
+func TestLastResponse(t *testing.T) {
+	last, ok := action.LastResponse(makeHistory())
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "rsp-4", last.Id)
+
+	_, ok = action.LastResponse(nil)
+	assert.Equal(t, false, ok)
+}
+
+func TestReferenceResponse(t *testing.T) {
+	reference, ok := action.ReferenceResponse(makeHistory())
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "rsp-1", reference.Id)
+
+	history := makeHistory()
+	history[2].Pinned = false
+
+	reference, ok = action.ReferenceResponse(history)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "rsp-4", reference.Id)
+}
+
+func TestExpiredResponses(t *testing.T) {
+	expired := action.ExpiredResponses(makeHistory(), 2)
+
+	assert.Len(t, 1, expired)
+	assert.Equal(t, "rsp-2", expired[0].Id)
+
+	expired = action.ExpiredResponses(makeHistory(), 1)
+	assert.Len(t, 2, expired)
+	assert.Equal(t, "rsp-3", expired[0].Id)
+	assert.Equal(t, "rsp-2", expired[1].Id)
+
+	assert.Len(t, 0, action.ExpiredResponses(makeHistory(), 0))
+	assert.Len(t, 0, action.ExpiredResponses(makeHistory(), 10))
+}
+
+func TestResponse_RequestId(t *testing.T) {
+	legacy := action.Response{Id: "req-1"}
+	assert.Equal(t, "req-1", legacy.RequestId())
+
+	response := action.Response{Id: "rsp-1", Request: "req-1"}
+	assert.Equal(t, "req-1", response.RequestId())
+}
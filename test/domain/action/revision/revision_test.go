@@ -0,0 +1,98 @@
+package revision_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	"github.com/Rafael24595/go-api-core/test/support/assert"
+)
+
+func makeRevisions() []action.Revision {
+	return []action.Revision{
+		{Id: "rev-2", Request: "req-1", Timestamp: 200},
+		{Id: "rev-3", Request: "req-1", Timestamp: 300},
+		{Id: "rev-1", Request: "req-1", Timestamp: 100},
+	}
+}
+
+func TestRequestChanges(t *testing.T) {
+	before := action.Request{Id: "req-1", Name: "users", Method: domain.GET, Uri: "http://localhost/users", Modified: 100}
+
+	after := before
+	after.Modified = 200
+	assert.Len(t, 0, action.RequestChanges(before, after))
+
+	after.Method = domain.POST
+	after.Name = "create user"
+	changes := action.RequestChanges(before, after)
+	assert.Equal(t, "method,name", strings.Join(changes, ","))
+}
+
+func TestNewRevision(t *testing.T) {
+	before := action.Request{Id: "req-1", Uri: "http://localhost/a", Modified: 100, Owner: "john"}
+	after := before
+	after.Uri = "http://localhost/b"
+	after.Modified = 200
+
+	revision := action.NewRevision("john", &before, after)
+	assert.Equal(t, "req-1", revision.Request)
+	assert.Equal(t, int64(200), revision.Timestamp)
+	assert.Equal(t, "john", revision.Author)
+	assert.Equal(t, "uri", strings.Join(revision.Changes, ","))
+
+	baseline := action.NewRevision("john", nil, before)
+	assert.Len(t, 0, baseline.Changes)
+}
+
+func TestSortRevisions(t *testing.T) {
+	revisions := action.SortRevisions(makeRevisions())
+
+	assert.Equal(t, "rev-3", revisions[0].Id)
+	assert.Equal(t, "rev-2", revisions[1].Id)
+	assert.Equal(t, "rev-1", revisions[2].Id)
+}
+
+func TestExpiredRevisions(t *testing.T) {
+	expired := action.ExpiredRevisions(makeRevisions(), 2)
+	assert.Len(t, 1, expired)
+	assert.Equal(t, "rev-1", expired[0].Id)
+
+	assert.Len(t, 0, action.ExpiredRevisions(makeRevisions(), 3))
+	assert.Len(t, 0, action.ExpiredRevisions(makeRevisions(), 0))
+}
+
+func TestRequestChanges_Tags(t *testing.T) {
+	before := action.Request{Id: "req-1", Tags: []string{"smoke"}}
+
+	after := before
+	after.Tags = []string{"Smoke"}
+	assert.Len(t, 0, action.RequestChanges(before, after))
+
+	after.Tags = []string{"smoke", "billing"}
+	assert.Equal(t, "tags", strings.Join(action.RequestChanges(before, after), ","))
+}
+
+func TestSortRevisions_Sequence(t *testing.T) {
+	revisions := []action.Revision{
+		{Id: "f", Request: "req-1", Sequence: 1, Timestamp: 100},
+		{Id: "a", Request: "req-1", Sequence: 3, Timestamp: 100},
+		{Id: "z", Request: "req-1", Sequence: 2, Timestamp: 100},
+		{Id: "b", Request: "req-1", Sequence: 4, Timestamp: 50},
+	}
+
+	sorted := action.SortRevisions(revisions)
+	assert.Equal(t, "b", sorted[0].Id)
+	assert.Equal(t, "a", sorted[1].Id)
+	assert.Equal(t, "z", sorted[2].Id)
+	assert.Equal(t, "f", sorted[3].Id)
+
+	expired := action.ExpiredRevisions(revisions, 2)
+	assert.Len(t, 2, expired)
+	assert.Equal(t, "z", expired[0].Id)
+	assert.Equal(t, "f", expired[1].Id)
+
+	assert.Equal(t, int64(5), action.NextSequence(revisions))
+	assert.Equal(t, int64(1), action.NextSequence(nil))
+}
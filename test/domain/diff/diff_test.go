@@ -0,0 +1,215 @@
+package diff_test
+
+import (
+	"testing"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	"github.com/Rafael24595/go-api-core/src/domain/action/body"
+	"github.com/Rafael24595/go-api-core/src/domain/action/cookie"
+	"github.com/Rafael24595/go-api-core/src/domain/action/header"
+	"github.com/Rafael24595/go-api-core/src/domain/diff"
+	"github.com/Rafael24595/go-api-core/test/support/assert"
+)
+
+func makeResponse(status int16, contentType domain.ContentType, payload string, headers map[string]string) action.Response {
+	response := action.Response{
+		Status: status,
+		Headers: header.Headers{
+			Headers: make(map[string][]header.Header),
+		},
+		Cookies: cookie.CookiesServer{
+			Cookies: make(map[string]cookie.CookieServer),
+		},
+		Body: *body.NewResponseBody(contentType, payload),
+	}
+	for k, v := range headers {
+		response.Headers.Add(k, v)
+	}
+	return response
+}
+
+func findChange(report diff.Report, section diff.Section, path string) (diff.Change, bool) {
+	for _, v := range report.Changes {
+		if v.Section == section && v.Path == path {
+			return v, true
+		}
+	}
+	return diff.Change{}, false
+}
+
+func TestCompare_Equal(t *testing.T) {
+	before := makeResponse(200, domain.Json, `{"a":1,"b":[1,2]}`, map[string]string{"Date": "Mon"})
+	after := makeResponse(200, domain.Json, `{ "b": [1, 2], "a": 1.0 }`, map[string]string{"date": "Tue"})
+
+	report := diff.NewDiffEngine().Compare(before, after)
+
+	assert.Equal(t, true, report.Equal)
+	assert.Len(t, 0, report.Changes)
+}
+
+func TestCompare_Json(t *testing.T) {
+	before := makeResponse(200, domain.Json, `{"id":1,"name":"John","tags":["a","b"],"meta":{"updated":1}}`, nil)
+	after := makeResponse(201, domain.Json, `{"id":1,"name":"Jane","tags":["a"],"email":"jane@example.com","meta":{"updated":2}}`, nil)
+
+	report := diff.NewDiffEngine().Compare(before, after)
+
+	assert.Equal(t, false, report.Equal)
+	assert.Equal(t, 1, report.Added)
+	assert.Equal(t, 1, report.Removed)
+	assert.Equal(t, 3, report.Changed)
+
+	status, ok := findChange(report, diff.STATUS, "status")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, any(int16(200)), status.Before)
+
+	name, ok := findChange(report, diff.BODY, "$.name")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, diff.CHANGED, name.Operation)
+	assert.Equal(t, any("John"), name.Before)
+	assert.Equal(t, any("Jane"), name.After)
+
+	email, ok := findChange(report, diff.BODY, "$.email")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, diff.ADDED, email.Operation)
+
+	tag, ok := findChange(report, diff.BODY, "$.tags[1]")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, diff.REMOVED, tag.Operation)
+
+	_, ok = findChange(report, diff.BODY, "$.meta.updated")
+	assert.Equal(t, true, ok)
+}
+
+func TestCompare_IgnoreVolatile(t *testing.T) {
+	before := makeResponse(200, domain.Json, `{"items":[{"id":1,"updatedAt":"x","etag":"1"}],"meta":{"took":3}}`, nil)
+	after := makeResponse(200, domain.Json, `{"items":[{"id":1,"updatedAt":"y","etag":"2"}],"meta":{"took":5}}`, nil)
+
+	report := diff.NewDiffEngine().
+		IgnoreFields("updatedAt").
+		IgnorePaths(diff.BODY, "$.items[*].etag", "$.meta.**").
+		Compare(before, after)
+
+	assert.Equal(t, true, report.Equal)
+
+	report = diff.NewDiffEngine().Compare(before, after)
+	assert.Equal(t, 3, report.Changed)
+}
+
+func TestCompare_Headers(t *testing.T) {
+	before := makeResponse(200, domain.Text, "ok", map[string]string{"Content-Type": "text/plain", "X-Old": "1", "Date": "Mon"})
+	after := makeResponse(200, domain.Text, "ok", map[string]string{"content-type": "text/html", "X-New": "1", "Date": "Tue"})
+
+	report := diff.NewDiffEngine().Compare(before, after)
+
+	assert.Len(t, 3, report.Changes)
+
+	change, ok := findChange(report, diff.HEADER, "Content-Type")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, diff.CHANGED, change.Operation)
+
+	change, ok = findChange(report, diff.HEADER, "X-New")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, diff.ADDED, change.Operation)
+
+	change, ok = findChange(report, diff.HEADER, "X-Old")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, diff.REMOVED, change.Operation)
+
+	report = diff.NewDiffEngineEmpty().Compare(before, after)
+	_, ok = findChange(report, diff.HEADER, "Date")
+	assert.Equal(t, true, ok)
+}
+
+func TestCompare_Cookies(t *testing.T) {
+	before := makeResponse(200, domain.None, "", nil)
+	before.Cookies.Cookies["session"] = cookie.CookieServer{Value: "a", Path: "/", Expiration: "Mon"}
+	before.Cookies.Cookies["theme"] = cookie.CookieServer{Value: "dark"}
+
+	after := makeResponse(200, domain.None, "", nil)
+	after.Cookies.Cookies["session"] = cookie.CookieServer{Value: "b", Path: "/", Expiration: "Tue"}
+
+	report := diff.NewDiffEngine().Compare(before, after)
+
+	assert.Len(t, 2, report.Changes)
+
+	change, ok := findChange(report, diff.COOKIE, "session.value")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, any("a"), change.Before)
+
+	change, ok = findChange(report, diff.COOKIE, "theme")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, diff.REMOVED, change.Operation)
+}
+
+func TestCompare_Xml(t *testing.T) {
+	before := makeResponse(200, domain.Xml, `<user id="1"><name>John</name><role>admin</role><role>dev</role></user>`, nil)
+	after := makeResponse(200, domain.Xml, `<user id="2">
+  <name>John</name>
+  <role>admin</role>
+  <role>ops</role>
+</user>`, nil)
+
+	report := diff.NewDiffEngine().Compare(before, after)
+
+	assert.Len(t, 2, report.Changes)
+
+	change, ok := findChange(report, diff.BODY, "$.user.@id")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, any("1"), change.Before)
+	assert.Equal(t, any("2"), change.After)
+
+	change, ok = findChange(report, diff.BODY, "$.user.role[1]")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, any("ops"), change.After)
+}
+
+func TestCompare_Text(t *testing.T) {
+	before := makeResponse(200, domain.Text, "hello", nil)
+	after := makeResponse(200, domain.Json, `{"message":"hello"}`, nil)
+
+	report := diff.NewDiffEngine().Compare(before, after)
+
+	assert.Len(t, 2, report.Changes)
+
+	_, ok := findChange(report, diff.BODY, "content_type")
+	assert.Equal(t, true, ok)
+
+	change, ok := findChange(report, diff.BODY, "$")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, any("hello"), change.Before)
+}
+
+func TestCompare_QuotedPath(t *testing.T) {
+	before := makeResponse(200, domain.Json, `{"a.b":1,"c d":{"x":1}}`, nil)
+	after := makeResponse(200, domain.Json, `{"a.b":2,"c d":{"x":2}}`, nil)
+
+	report := diff.NewDiffEngine().Compare(before, after)
+
+	_, ok := findChange(report, diff.BODY, `$["a.b"]`)
+	assert.Equal(t, true, ok)
+	_, ok = findChange(report, diff.BODY, `$["c d"].x`)
+	assert.Equal(t, true, ok)
+}
+
+func TestCompareRequests(t *testing.T) {
+	before := action.Request{Id: "req-1", Name: "users", Method: domain.GET, Uri: "http://localhost/users", Owner: "john", Modified: 100}
+	after := before
+	after.Modified = 200
+
+	report := diff.NewDiffEngineEmpty().CompareRequests(before, after)
+	assert.Equal(t, true, report.Equal)
+
+	after.Uri = "http://localhost/customers"
+	after.Method = domain.POST
+
+	report = diff.NewDiffEngineEmpty().CompareRequests(before, after)
+	assert.Equal(t, 2, report.Changed)
+
+	change, ok := findChange(report, diff.REQUEST, "$.uri")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, any("http://localhost/users"), change.Before)
+
+	_, ok = findChange(report, diff.REQUEST, "$.method")
+	assert.Equal(t, true, ok)
+}
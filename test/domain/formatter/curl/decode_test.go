@@ -4,9 +4,11 @@ import (
 	"testing"
 
 	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action"
 	"github.com/Rafael24595/go-api-core/src/domain/action/auth"
 	auth_strategy "github.com/Rafael24595/go-api-core/src/domain/action/auth/strategy"
 	body_strategy "github.com/Rafael24595/go-api-core/src/domain/action/body/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/action/query"
 	"github.com/Rafael24595/go-api-core/src/domain/formatter/curl"
 )
 
@@ -216,3 +218,379 @@ func TestCurlToRequest_BinaryData(t *testing.T) {
 		t.Errorf("Found %#v, but %#v expected", result, expected)
 	}
 }
+
+func TestUnmarshal_PathParams(t *testing.T) {
+	input := `curl https://api.example.com/users/{userId}/tasks/:taskId`
+
+	req, err := curl.Unmarshal([]byte(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(req.Param.Params) != 2 {
+		t.Fatalf("Found %d params, but %d expected", len(req.Param.Params), 2)
+	}
+
+	for _, key := range []string{"userId", "taskId"} {
+		param, ok := req.Param.Find(key)
+		if !ok {
+			t.Errorf("Param %#v not found", key)
+			continue
+		}
+		if param.Status {
+			t.Errorf("Param %#v should be disabled", key)
+		}
+	}
+
+	result, err := curl.Marshal(req, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "curl -X GET https://api.example.com/users/{userId}/tasks/:taskId"
+	if result != expected {
+		t.Errorf("Found %#v, but %#v expected", result, expected)
+	}
+}
+
+func TestUnmarshal_QueryStyles(t *testing.T) {
+	input := `curl "https://api.example.com/tasks?ids[]=1&ids[]=2&filter[status]=open"`
+
+	req, err := curl.Unmarshal([]byte(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ids, ok := req.Query.Find("ids")
+	if !ok || len(ids) != 2 {
+		t.Fatalf("Query 'ids' not found or with unexpected length: %#v", ids)
+	}
+
+	if style := req.Query.FindSerialization("ids").Style; style != query.BRACKETS {
+		t.Errorf("Found style %#v, but %#v expected", style, query.BRACKETS)
+	}
+
+	filter, ok := req.Query.Find("filter")
+	if !ok || len(filter) != 1 {
+		t.Fatalf("Query 'filter' not found or with unexpected length: %#v", filter)
+	}
+
+	if filter[0].Property != "status" || filter[0].Value != "open" {
+		t.Errorf("Found property %#v with value %#v", filter[0].Property, filter[0].Value)
+	}
+
+	if style := req.Query.FindSerialization("filter").Style; style != query.DEEP_OBJECT {
+		t.Errorf("Found style %#v, but %#v expected", style, query.DEEP_OBJECT)
+	}
+
+	req.Query.Queries = map[string][]query.Query{"ids": ids}
+
+	result, err := curl.Marshal(req, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "curl -X GET https://api.example.com/tasks?ids[]=1&ids[]=2"
+	if result != expected {
+		t.Errorf("Found %#v, but %#v expected", result, expected)
+	}
+}
+
+func TestUnmarshal_BooleanFlags(t *testing.T) {
+	input := `curl -sSL -k --compressed -v https://api.example.com/users`
+
+	command, err := curl.UnmarshalCommand([]byte(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if command.Request.Uri != "https://api.example.com/users" {
+		t.Errorf("Found %#v, but %#v expected", command.Request.Uri, "https://api.example.com/users")
+	}
+
+	if command.Request.Method != domain.GET {
+		t.Errorf("Found %#v, but %#v expected", command.Request.Method, domain.GET)
+	}
+
+	options := command.Request.Options
+	if !options.Status || !options.Insecure || !options.FollowRedirects || !options.Compressed {
+		t.Errorf("Expected insecure, follow redirects and compressed options, found %#v", options)
+	}
+}
+
+func TestUnmarshal_AttachedValues(t *testing.T) {
+	input := `curl -XPUT -m 30 -x http://proxy:8080 --url https://api.example.com/users/1`
+
+	command, err := curl.UnmarshalCommand([]byte(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if command.Request.Method != domain.PUT {
+		t.Errorf("Found %#v, but %#v expected", command.Request.Method, domain.PUT)
+	}
+
+	if command.Request.Uri != "https://api.example.com/users/1" {
+		t.Errorf("Found %#v, but %#v expected", command.Request.Uri, "https://api.example.com/users/1")
+	}
+
+	options := command.Request.Options
+	if options.Timeout != 30000 || options.Proxy != "http://proxy:8080" {
+		t.Errorf("Unexpected options %#v", options)
+	}
+}
+
+func TestUnmarshal_WithoutOptions(t *testing.T) {
+	req, err := curl.Unmarshal([]byte(`curl https://api.example.com/users`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if req.Options.Status {
+		t.Errorf("Expected disabled client options, found %#v", req.Options)
+	}
+}
+
+func TestUnmarshal_UploadFile(t *testing.T) {
+	req, err := curl.Unmarshal([]byte(`curl -T ./report.csv https://files.example.com/reports`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if req.Method != domain.PUT {
+		t.Errorf("Found %#v, but %#v expected", req.Method, domain.PUT)
+	}
+
+	if !req.Body.Empty() {
+		t.Errorf("Expected an empty body, found %#v", req.Body)
+	}
+}
+
+func TestUnmarshal_UnsupportedOption(t *testing.T) {
+	_, err := curl.Unmarshal([]byte(`curl --unknown-flag https://api.example.com`))
+	if err == nil {
+		t.Fatal("expected error for unsupported option")
+	}
+}
+
+func TestUnmarshal_Json(t *testing.T) {
+	input := `curl --json '{"name":"John"}' https://api.example.com/users`
+
+	req, err := curl.Unmarshal([]byte(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if req.Method != domain.POST {
+		t.Errorf("Found %#v, but %#v expected", req.Method, domain.POST)
+	}
+
+	if req.Body.ContentType != domain.Json {
+		t.Errorf("Found %#v, but %#v expected", req.Body.ContentType, domain.Json)
+	}
+
+	payload := req.Body.Parameters[body_strategy.DOCUMENT_PARAM][body_strategy.PAYLOAD_PARAM][0].Value
+	if payload != `{"name":"John"}` {
+		t.Errorf("Found %#v, but %#v expected", payload, `{"name":"John"}`)
+	}
+
+	for _, key := range []string{"Content-Type", "Accept"} {
+		header, ok := req.Header.Find(key)
+		if !ok || header[0].Value != "application/json" {
+			t.Errorf("Expected header %s with application/json", key)
+		}
+	}
+}
+
+func TestUnmarshal_RepeatedData(t *testing.T) {
+	input := `curl -d name=John -d 'age=30' --data-urlencode 'note=a b&c' https://api.example.com/users`
+
+	req, err := curl.Unmarshal([]byte(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "name=John&age=30&note=a+b%26c"
+	payload := req.Body.Parameters[body_strategy.DOCUMENT_PARAM][body_strategy.PAYLOAD_PARAM][0].Value
+	if payload != expected {
+		t.Errorf("Found %#v, but %#v expected", payload, expected)
+	}
+
+	if req.Method != domain.POST {
+		t.Errorf("Found %#v, but %#v expected", req.Method, domain.POST)
+	}
+}
+
+func TestUnmarshal_GetData(t *testing.T) {
+	input := `curl -G -d page=2 --data-urlencode 'q=hello world' https://api.example.com/search`
+
+	req, err := curl.Unmarshal([]byte(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if req.Method != domain.GET {
+		t.Errorf("Found %#v, but %#v expected", req.Method, domain.GET)
+	}
+
+	if !req.Body.Empty() {
+		t.Errorf("Expected empty body")
+	}
+
+	page, ok := req.Query.Find("page")
+	if !ok || page[0].Value != "2" {
+		t.Errorf("Expected query page=2")
+	}
+
+	search, ok := req.Query.Find("q")
+	if !ok || search[0].Value != "hello world" {
+		t.Errorf("Expected query q='hello world'")
+	}
+}
+
+func TestUnmarshal_AgentRefererAndCookieFile(t *testing.T) {
+	input := `curl -A 'agent/1.0' -e https://referer.example.com -b @cookies.txt -H 'Accept: text/plain; charset=utf-8' https://api.example.com`
+
+	command, err := curl.UnmarshalCommand([]byte(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	req := command.Request
+
+	agent, ok := req.Header.Find("User-Agent")
+	if !ok || agent[0].Value != "agent/1.0" {
+		t.Errorf("Expected User-Agent header")
+	}
+
+	referer, ok := req.Header.Find("Referer")
+	if !ok || referer[0].Value != "https://referer.example.com" {
+		t.Errorf("Expected Referer header")
+	}
+
+	accept, ok := req.Header.Find("Accept")
+	if !ok || accept[0].Value != "text/plain; charset=utf-8" {
+		t.Errorf("Expected Accept header with parameters")
+	}
+
+	if len(req.Cookie.Cookies) != 0 {
+		t.Errorf("Expected no cookies, found %d", len(req.Cookie.Cookies))
+	}
+}
+
+func TestUnmarshalMany(t *testing.T) {
+	input := `# users
+curl -s https://api.example.com/users
+
+curl -X POST https://api.example.com/users \
+  -H 'Content-Type: application/json' \
+  -d '{
+    "name": "John"
+  }'
+curl -I https://api.example.com/health
+`
+
+	commands, err := curl.UnmarshalMany([]byte(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(commands) != 3 {
+		t.Fatalf("Found %d commands, but 3 expected", len(commands))
+	}
+
+	methods := []domain.HttpMethod{domain.GET, domain.POST, domain.HEAD}
+	for i, v := range commands {
+		if v.Request.Method != methods[i] {
+			t.Errorf("Found %#v, but %#v expected", v.Request.Method, methods[i])
+		}
+	}
+
+	if commands[1].Request.Body.ContentType != domain.Json {
+		t.Errorf("Found %#v, but %#v expected", commands[1].Request.Body.ContentType, domain.Json)
+	}
+
+	if _, err := curl.UnmarshalMany([]byte("curl https://a.example.com\ncurl --bogus https://b.example.com")); err == nil {
+		t.Error("expected error for invalid command")
+	}
+}
+
+func TestUnmarshal_DeepObjectOrder(t *testing.T) {
+	input := `curl "https://api.example.com/tasks?filter[status]=open&page=1&filter[owner]=me&filter[tag]=a%20b"`
+
+	for range 10 {
+		req, err := curl.Unmarshal([]byte(input))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		filter, ok := req.Query.Find("filter")
+		if !ok || len(filter) != 3 {
+			t.Fatalf("Query 'filter' not found or with unexpected length: %#v", filter)
+		}
+
+		expected := []struct {
+			order    int64
+			property string
+			value    string
+		}{
+			{0, "status", "open"},
+			{2, "owner", "me"},
+			{3, "tag", "a b"},
+		}
+
+		for i, v := range expected {
+			if filter[i].Order != v.order || filter[i].Property != v.property || filter[i].Value != v.value {
+				t.Errorf("Found %#v, but %#v expected", filter[i], v)
+			}
+		}
+
+		page, ok := req.Query.Find("page")
+		if !ok || len(page) != 1 || page[0].Order != 1 {
+			t.Errorf("Found page %#v, but order 1 expected", page)
+		}
+	}
+}
+
+func TestUnmarshal_PathParamsRoundTrip(t *testing.T) {
+	req := action.NewRequest("params", domain.GET, "https://api.example.com/users/{userId}/tasks/:taskId/{draft}")
+	req.Param.Add("userId", "42")
+	req.Param.Add("taskId", "a b")
+	req.Param.AddStatus("draft", "", false)
+
+	result, err := curl.Marshal(req, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	decoded, err := curl.Unmarshal([]byte(result))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedUri := "https://api.example.com/users/{userId}/tasks/{taskId}/{draft}"
+	if decoded.Uri != expectedUri {
+		t.Errorf("Found uri %#v, but %#v expected", decoded.Uri, expectedUri)
+	}
+
+	expected := []struct {
+		key    string
+		value  string
+		status bool
+	}{
+		{"userId", "42", true},
+		{"taskId", "a b", true},
+		{"draft", "", false},
+	}
+
+	for _, v := range expected {
+		param, ok := decoded.Param.Find(v.key)
+		if !ok {
+			t.Errorf("Param %#v not found", v.key)
+			continue
+		}
+		if param.Value != v.value || param.Status != v.status {
+			t.Errorf("Found param %#v, but %#v expected", param, v)
+		}
+	}
+}
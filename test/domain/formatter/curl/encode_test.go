@@ -336,3 +336,51 @@ func TestMarshalContext_InlineVsMultiline(t *testing.T) {
 		t.Errorf("Expected '%s', but got '%s'", expectedLine, curlInline)
 	}
 }
+
+func TestMarshalContext_WithPathParams(t *testing.T) {
+	ctx := context.NewContext("tester")
+	ctx.Put(context.URI, "task", "99", false)
+
+	req := action.NewRequest("_test_params_001", domain.GET, "http://example.com/users/{userId}/tasks/:taskId")
+	req.Param.Add("userId", "42")
+	req.Param.Add("taskId", "${task}")
+
+	curl, err := curl.MarshalContext(ctx, req, true)
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := `curl -X GET --variable "userId=42" --variable "taskId=99" --expand-url http://example.com/users/{{userId:url}}/tasks/{{taskId:url}}`
+	if curl != expected {
+		t.Errorf("Expected '%s', but got '%s'", expected, curl)
+	}
+}
+
+func TestMarshal_ClientOptions(t *testing.T) {
+	input := `curl -k -L --compressed -x proxy:3128 -m 2.5 --connect-timeout 1 https://api.example.com/users`
+
+	req, err := curl.Unmarshal([]byte(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result, err := curl.Marshal(req, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "curl -X GET https://api.example.com/users -k -L --compressed -x 'proxy:3128' -m 2.5 --connect-timeout 1"
+	if result != expected {
+		t.Errorf("Found %#v, but %#v expected", result, expected)
+	}
+
+	decoded, err := curl.Unmarshal([]byte(result))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if decoded.Options != req.Options {
+		t.Errorf("Found %#v, but %#v expected", decoded.Options, req.Options)
+	}
+}
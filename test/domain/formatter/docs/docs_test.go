@@ -0,0 +1,148 @@
+package docs_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	auth_strategy "github.com/Rafael24595/go-api-core/src/domain/action/auth/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/action/body"
+	body_strategy "github.com/Rafael24595/go-api-core/src/domain/action/body/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/action/cookie"
+	"github.com/Rafael24595/go-api-core/src/domain/action/header"
+	"github.com/Rafael24595/go-api-core/src/domain/collection"
+	"github.com/Rafael24595/go-api-core/src/domain/formatter/docs"
+	"github.com/Rafael24595/go-api-core/test/support/assert"
+)
+
+func makeSource() docs.Source {
+	list := action.NewRequest("List users", domain.GET, "https://api.example.com/users/{id}")
+	list.Id = "req-1"
+	list.Param.Add("id", "7")
+	list.Query.Add("page", "2")
+	list.Query.Add("api_key", "query-secret")
+	list.Header.Add("Accept", "application/json")
+	list.Header.Add("X-Auth-Token", "${token}")
+	list.Cookie.Cookies["session"] = cookie.NewCookieClient(0, true, "cookie-secret")
+
+	create := action.NewRequest("Create user", domain.POST, "https://api.example.com/users")
+	create.Id = "req-2"
+	create.Auth.PutAuth(*auth_strategy.BasicAuth(true, "admin", "basic-secret"))
+	create.Auth.Status = true
+	create.Body = *body_strategy.DocumentBody(true, domain.Json, `{"name":"John"}`)
+
+	upload := action.NewRequest("Upload | avatar", domain.PUT, "https://api.example.com/avatar")
+	upload.Id = "req-3"
+	upload.Auth.PutAuth(*auth_strategy.BearerAuth(true, auth_strategy.DEFAULT_BEARER_PREFIX, "bearer-secret"))
+	upload.Auth.Status = true
+	upload.Body = *body_strategy.FormDataBody(true, domain.Form, body_strategy.NewBuilderFromDataBody().
+		Add("title", body.NewParameterActive("<b>Holiday</b>")).
+		Add("photo", body.NewFileParameterActive("png", "beach.png", "UE5H")))
+
+	response := action.Response{
+		Request: "req-1",
+		Status:  200,
+		Time:    35,
+		Headers: header.Headers{
+			Headers: map[string][]header.Header{
+				"Content-Type": {{Status: true, Value: "application/json"}},
+				"Set-Cookie":   {{Status: true, Value: "session=cookie-secret"}},
+			},
+		},
+		Body: *body.NewResponseBody(domain.Json, `[{"id":7}]`),
+	}
+
+	return docs.Source{
+		Collection: collection.Collection{Id: "coll-1", Name: "Users API"},
+		Requests:   []action.Request{*list, *create, *upload},
+		Responses: map[string]action.Response{
+			"req-1": response,
+		},
+	}
+}
+
+func TestMarshalMarkdown(t *testing.T) {
+	result := string(docs.MarshalMarkdown("API", makeSource()))
+
+	fragments := []string{
+		"# API\n",
+		"- [Users API](#users-api)\n",
+		"  - [GET List users](#users-api-list-users)\n",
+		"<a id=\"users-api-list-users\"></a>\n\n### List users\n",
+		"`GET https://api.example.com/users/{id}`",
+		"| `id` | `7` |  |",
+		"| `page` | `2` |",
+		"| `X-Auth-Token` | `${token}` |",
+		"Type: `BASIC`",
+		"| `User` | `admin` |",
+		"```json\n{\n  \"name\": \"John\"\n}\n```",
+		"| `photo` | `<file beach.png>` |",
+		"### Upload | avatar",
+		"Status: `200 OK` (35 ms)",
+		"[\n  {\n    \"id\": 7\n  }\n]",
+	}
+
+	for _, v := range fragments {
+		assert.Equal(t, true, strings.Contains(result, v), v)
+	}
+
+	assertMasked(t, result)
+}
+
+func TestMarshalHtml(t *testing.T) {
+	data, err := docs.MarshalHtml("API <docs>", makeSource())
+	assert.NotError(t, err)
+
+	result := string(data)
+
+	fragments := []string{
+		"<title>API &lt;docs&gt;</title>",
+		"<a href=\"#users-api-list-users\">GET List users</a>",
+		"<section class=\"request\" id=\"users-api-create-user\">",
+		"<td>title</td><td>&lt;b&gt;Holiday&lt;/b&gt;</td>",
+		"<code class=\"language-json\">",
+		"<code>200 OK</code> (35 ms)",
+	}
+
+	for _, v := range fragments {
+		assert.Equal(t, true, strings.Contains(result, v), v)
+	}
+
+	assertMasked(t, result)
+}
+
+func TestMarshal_Anchors(t *testing.T) {
+	source := makeSource()
+	source.Requests[1].Name = source.Requests[0].Name
+
+	result := string(docs.MarshalMarkdown("API", source))
+
+	assert.Equal(t, true, strings.Contains(result, "(#users-api-list-users)"))
+	assert.Equal(t, true, strings.Contains(result, "(#users-api-list-users-1)"))
+}
+
+func TestMarshal_Format(t *testing.T) {
+	format, err := docs.FormatFromString("md")
+	assert.NotError(t, err)
+	assert.Equal(t, docs.MARKDOWN, format)
+
+	format, err = docs.FormatFromString("HTML")
+	assert.NotError(t, err)
+	assert.Equal(t, docs.HTML, format)
+
+	_, err = docs.FormatFromString("pdf")
+	assert.Error(t, err)
+
+	_, err = docs.Marshal(docs.Format("pdf"), "API", makeSource())
+	assert.Error(t, err)
+}
+
+func assertMasked(t *testing.T, result string) {
+	t.Helper()
+
+	for _, v := range []string{"query-secret", "cookie-secret", "basic-secret", "bearer-secret"} {
+		assert.Equal(t, false, strings.Contains(result, v), v)
+	}
+	assert.Equal(t, true, strings.Contains(result, docs.SECRET_MASK))
+}
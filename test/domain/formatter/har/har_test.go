@@ -0,0 +1,121 @@
+package har_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	body_strategy "github.com/Rafael24595/go-api-core/src/domain/action/body/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/formatter/har"
+	"github.com/Rafael24595/go-api-core/test/support"
+	"github.com/Rafael24595/go-api-core/test/support/assert"
+)
+
+func TestUnmarshal_Capture(t *testing.T) {
+	file := support_test.ReadText(t, "sources/capture001.har")
+
+	source, exchanges, err := har.Unmarshal(file)
+	assert.NotError(t, err)
+	assert.Equal(t, "Firefox", source.Log.Creator.Name)
+	assert.Len(t, 3, exchanges)
+
+	get := exchanges[0]
+	assert.Equal(t, domain.GET, get.Request.Method)
+	assert.Equal(t, "https://api.example.com/tasks", get.Request.Uri)
+	assert.Equal(t, 2, len(get.Request.Query.Queries))
+
+	page, _ := get.Request.Query.Find("page")
+	assert.Equal(t, "2", page[0].Value)
+
+	_, ok := get.Request.Header.Find(":authority")
+	assert.Equal(t, false, ok)
+
+	_, ok = get.Request.Header.Find("Cookie")
+	assert.Equal(t, false, ok)
+
+	accept, _ := get.Request.Header.Find("Accept")
+	assert.Equal(t, "application/json", accept[0].Value)
+
+	session, ok := get.Request.Cookie.Find("session")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "abc123", session.Value)
+
+	assert.NotNil(t, get.Response)
+	assert.Equal(t, int16(200), get.Response.Status)
+	assert.Equal(t, int64(120), get.Response.Time)
+	assert.Equal(t, int64(1740823200000), get.Response.Date)
+	assert.Equal(t, `{"id": "1"}`, get.Response.Body.Payload)
+	assert.Equal(t, domain.Json, get.Response.Body.ContentType)
+
+	theme, ok := get.Response.Cookies.Cookies["theme"]
+	assert.Equal(t, true, ok)
+	assert.Equal(t, true, theme.HttpOnly)
+}
+
+func TestUnmarshal_PostData(t *testing.T) {
+	file := support_test.ReadText(t, "sources/capture001.har")
+
+	_, exchanges, err := har.Unmarshal(file)
+	assert.NotError(t, err)
+
+	login := exchanges[1]
+	assert.Equal(t, domain.POST, login.Request.Method)
+	assert.Equal(t, domain.Form, login.Request.Body.ContentType)
+	assert.Nil(t, login.Response)
+
+	user, ok := body_strategy.FindFormDataParameterIndex(&login.Request.Body, "user", 0)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "john", user.Value)
+
+	update := exchanges[2]
+	assert.Equal(t, domain.Json, update.Request.Body.ContentType)
+
+	payload := update.Request.Body.Parameters[body_strategy.DOCUMENT_PARAM][body_strategy.PAYLOAD_PARAM]
+	assert.Equal(t, `{"done":true}`, payload[0].Value)
+	assert.Equal(t, int16(204), update.Response.Status)
+}
+
+func TestUnmarshal_Invalid(t *testing.T) {
+	_, _, err := har.Unmarshal([]byte(`{"foo": "bar"}`))
+	assert.Error(t, err)
+
+	_, _, err = har.Unmarshal([]byte(`not a har`))
+	assert.Error(t, err)
+}
+
+func TestMarshal_RoundTrip(t *testing.T) {
+	file := support_test.ReadText(t, "sources/capture001.har")
+
+	_, exchanges, err := har.Unmarshal(file)
+	assert.NotError(t, err)
+
+	result, err := har.Marshal(exchanges...)
+	assert.NotError(t, err)
+
+	source, again, err := har.Unmarshal(result)
+	assert.NotError(t, err)
+	assert.Equal(t, har.VERSION, source.Log.Version)
+	assert.Len(t, len(exchanges), again)
+
+	entry := source.Log.Entries[0]
+	assert.Equal(t, "https://api.example.com/tasks?page=2&sort=desc", entry.Request.Url)
+	assert.Equal(t, "2025-03-01T10:00:00Z", entry.StartedDateTime)
+	assert.Equal(t, 200, entry.Response.Status)
+	assert.Equal(t, "OK", entry.Response.StatusText)
+
+	cookie := ""
+	for _, v := range entry.Request.Headers {
+		if v.Name == "Cookie" {
+			cookie = v.Value
+		}
+	}
+	assert.Equal(t, "session=abc123", cookie)
+
+	assert.Equal(t, 0, source.Log.Entries[1].Response.Status)
+	assert.Equal(t, 2, len(source.Log.Entries[1].Request.PostData.Params))
+
+	post := again[2]
+	payload := post.Request.Body.Parameters[body_strategy.DOCUMENT_PARAM][body_strategy.PAYLOAD_PARAM]
+	assert.Equal(t, `{"done":true}`, payload[0].Value)
+	assert.Equal(t, true, strings.HasPrefix(source.Log.Entries[2].Request.PostData.MimeType, "application/json"))
+}
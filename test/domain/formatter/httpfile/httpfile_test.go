@@ -0,0 +1,187 @@
+package httpfile_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action/auth"
+	auth_strategy "github.com/Rafael24595/go-api-core/src/domain/action/auth/strategy"
+	body_strategy "github.com/Rafael24595/go-api-core/src/domain/action/body/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/context"
+	"github.com/Rafael24595/go-api-core/src/domain/formatter/httpfile"
+	"github.com/Rafael24595/go-api-core/test/support"
+	"github.com/Rafael24595/go-api-core/test/support/assert"
+)
+
+const TEST_OWNER = "anonymous"
+
+func resolver(path string) ([]byte, error) {
+	switch path {
+	case "./avatar.png":
+		return []byte("png"), nil
+	case "./users.xml":
+		return []byte("<users/>"), nil
+	}
+	return nil, errors.New("not found")
+}
+
+func makeImport(t *testing.T, resolve httpfile.FileResolver) *httpfile.Import {
+	file := support_test.ReadText(t, "sources/requests001.http")
+
+	result, err := httpfile.Unmarshal(TEST_OWNER, file, resolve)
+	assert.NotError(t, err)
+
+	return result
+}
+
+func TestUnmarshal_Requests(t *testing.T) {
+	requests := makeImport(t, resolver).Requests
+
+	assert.Len(t, 5, requests)
+	assert.Equal(t, "List users", requests[0].Name)
+	assert.Equal(t, "createUser", requests[1].Name)
+	assert.Equal(t, "Upload avatar", requests[2].Name)
+	assert.Equal(t, "Import users", requests[3].Name)
+	assert.Equal(t, "GET https://api.example.com/health", requests[4].Name)
+
+	list := requests[0]
+	assert.Equal(t, domain.GET, list.Method)
+	assert.Equal(t, "${baseUrl}/users", list.Uri)
+	assert.Equal(t, TEST_OWNER, list.Owner)
+
+	active, _ := list.Query.Find("active")
+	assert.Equal(t, "true", active[0].Value)
+
+	size, _ := list.Query.Find("size")
+	assert.Equal(t, "${size}", size[0].Value)
+
+	accept, _ := list.Header.Find("Accept")
+	assert.Equal(t, "application/json", accept[0].Value)
+
+	bearer, ok := list.Auth.Auths[auth.Bearer.String()]
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "${token}", bearer.Parameters[auth_strategy.BEARER_PARAM_TOKEN])
+}
+
+func TestUnmarshal_Bodies(t *testing.T) {
+	requests := makeImport(t, resolver).Requests
+
+	create := requests[1]
+	assert.Equal(t, domain.Json, create.Body.ContentType)
+	payload := create.Body.Parameters[body_strategy.DOCUMENT_PARAM][body_strategy.PAYLOAD_PARAM][0]
+	assert.Equal(t, "{\n  \"name\": \"John\",\n  \"id\": \"{{$guid}}\"\n}", payload.Value)
+	assert.Equal(t, "abc", create.Cookie.Cookies["session"].Value)
+	assert.Equal(t, "dark", create.Cookie.Cookies["theme"].Value)
+
+	upload := requests[2]
+	assert.Equal(t, domain.Form, upload.Body.ContentType)
+	form := upload.Body.Parameters[body_strategy.FORM_DATA_PARAM]
+	assert.Equal(t, "Profile picture", form["description"][0].Value)
+	assert.Equal(t, true, form["file"][0].IsFile)
+	assert.Equal(t, "./avatar.png", form["file"][0].FileName)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("png")), form["file"][0].Value)
+
+	include := requests[3]
+	assert.Equal(t, domain.PUT, include.Method)
+	assert.Equal(t, domain.Xml, include.Body.ContentType)
+	document := include.Body.Parameters[body_strategy.DOCUMENT_PARAM][body_strategy.PAYLOAD_PARAM][0]
+	assert.Equal(t, "<users/>", document.Value)
+
+	basic, ok := include.Auth.Auths[auth.Basic.String()]
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "admin", basic.Parameters[auth_strategy.BASIC_PARAM_USER])
+	assert.Equal(t, "${token}", basic.Parameters[auth_strategy.BASIC_PARAM_PASSWORD])
+
+	assert.Equal(t, true, requests[4].Body.Empty())
+}
+
+func TestUnmarshal_IncludesWithoutResolver(t *testing.T) {
+	requests := makeImport(t, nil).Requests
+
+	document := requests[3].Body.Parameters[body_strategy.DOCUMENT_PARAM][body_strategy.PAYLOAD_PARAM][0]
+	assert.Equal(t, true, document.IsFile)
+	assert.Equal(t, "./users.xml", document.FileName)
+
+	file := requests[2].Body.Parameters[body_strategy.FORM_DATA_PARAM]["file"][0]
+	assert.Equal(t, true, file.IsFile)
+	assert.Equal(t, "", file.Value)
+}
+
+func TestUnmarshal_Context(t *testing.T) {
+	ctx := makeImport(t, resolver).Context
+
+	assert.Equal(t, "https://api.example.com", findVariable(t, ctx, context.URI, "baseUrl"))
+	assert.Equal(t, "20", findVariable(t, ctx, context.QUERY, "size"))
+	assert.Equal(t, "secret-token", findVariable(t, ctx, context.AUTH, "token"))
+}
+
+func TestUnmarshal_Invalid(t *testing.T) {
+	_, err := httpfile.Unmarshal(TEST_OWNER, []byte("# only comments\n@host = localhost\n"), nil)
+	assert.Error(t, err)
+
+	_, err = httpfile.Unmarshal(TEST_OWNER, []byte("GET http://localhost\ninvalid header\n"), nil)
+	assert.Error(t, err)
+
+	_, err = httpfile.Unmarshal(TEST_OWNER, []byte("POST http://localhost\n\n< ./missing.json\n"), resolver)
+	assert.Error(t, err)
+}
+
+func TestMarshal_RoundTrip(t *testing.T) {
+	source := makeImport(t, nil)
+
+	data := httpfile.Marshal(source.Context, source.Requests...)
+	text := string(data)
+
+	assert.Equal(t, true, strings.HasPrefix(text, "@baseUrl = https://api.example.com\n"))
+	assert.Equal(t, true, strings.Contains(text, "### List users\nGET {{baseUrl}}/users\n    ?active=true\n    &size={{size}}\n"))
+	assert.Equal(t, true, strings.Contains(text, "Authorization: Bearer {{token}}\n"))
+	assert.Equal(t, true, strings.Contains(text, "Cookie: session=abc; theme=dark\n"))
+	assert.Equal(t, true, strings.Contains(text, "< ./users.xml\n"))
+
+	result, err := httpfile.Unmarshal(TEST_OWNER, data, nil)
+	assert.NotError(t, err)
+	assert.Len(t, len(source.Requests), result.Requests)
+
+	for i, v := range source.Requests {
+		request := result.Requests[i]
+		assert.Equal(t, v.Name, request.Name)
+		assert.Equal(t, v.Method, request.Method)
+		assert.Equal(t, v.Uri, request.Uri)
+		assert.Equal(t, v.Body.ContentType, request.Body.ContentType)
+		assert.Equal(t, len(v.Query.Queries), len(request.Query.Queries))
+		assert.Equal(t, len(v.Auth.Auths), len(request.Auth.Auths))
+	}
+
+	form := result.Requests[2].Body.Parameters[body_strategy.FORM_DATA_PARAM]
+	assert.Equal(t, "Profile picture", form["description"][0].Value)
+	assert.Equal(t, "./avatar.png", form["file"][0].FileName)
+}
+
+func TestMarshal_PrivateVariables(t *testing.T) {
+	ctx := context.NewContext(TEST_OWNER)
+	ctx.Put(context.URI, "host", "localhost", false)
+	ctx.Put(context.AUTH, "password", "secret", true)
+
+	text := string(httpfile.Marshal(ctx))
+
+	assert.Equal(t, "@host = localhost\n@password = \n", text)
+}
+
+func findVariable(t *testing.T, ctx *context.Context, category context.ContextCategoy, key string) string {
+	t.Helper()
+
+	variables, ok := ctx.Dictionary.Get(category.String())
+	if !ok {
+		t.Fatalf("category '%s' not found", category)
+	}
+
+	item, ok := variables.Get(key)
+	if !ok {
+		t.Fatalf("variable '%s' not found in '%s'", key, category)
+	}
+
+	return item.Value
+}
@@ -0,0 +1,202 @@
+package insomnia_test
+
+import (
+	"testing"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action/auth"
+	auth_strategy "github.com/Rafael24595/go-api-core/src/domain/action/auth/strategy"
+	body_strategy "github.com/Rafael24595/go-api-core/src/domain/action/body/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/context"
+	"github.com/Rafael24595/go-api-core/src/domain/formatter/insomnia"
+	"github.com/Rafael24595/go-api-core/test/support"
+	"github.com/Rafael24595/go-api-core/test/support/assert"
+)
+
+const TEST_OWNER = "anonymous"
+
+func makeImport(t *testing.T) *insomnia.Import {
+	file := support_test.ReadText(t, "sources/export001.json")
+
+	result, err := insomnia.Unmarshal(TEST_OWNER, file)
+	assert.NotError(t, err)
+
+	return result
+}
+
+func TestUnmarshal_Workspaces(t *testing.T) {
+	result := makeImport(t)
+
+	assert.Len(t, 1, result.Workspaces)
+
+	workspace := result.Workspaces[0]
+	assert.Equal(t, "Task API", workspace.Name)
+	assert.Len(t, 2, workspace.Collections)
+
+	root := workspace.Collections[0]
+	assert.Equal(t, "Task API", root.Name)
+	assert.Len(t, 1, root.Requests)
+
+	tasks := workspace.Collections[1]
+	assert.Equal(t, "Tasks", tasks.Name)
+	assert.Len(t, 3, tasks.Requests)
+	assert.Equal(t, "List tasks", tasks.Requests[0].Name)
+	assert.Equal(t, "Upload", tasks.Requests[2].Name)
+}
+
+func TestUnmarshal_Request(t *testing.T) {
+	list := makeImport(t).Workspaces[0].Collections[1].Requests[0]
+
+	assert.Equal(t, domain.GET, list.Method)
+	assert.Equal(t, "${baseUrl}/users/{userId}/tasks", list.Uri)
+	assert.Equal(t, TEST_OWNER, list.Owner)
+
+	page, _ := list.Query.Find("page")
+	assert.Equal(t, "1", page[0].Value)
+
+	size, _ := list.Query.Find("size")
+	assert.Equal(t, "${size}", size[0].Value)
+
+	debug, _ := list.Query.Find("debug")
+	assert.Equal(t, false, debug[0].Status)
+
+	userId, ok := list.Param.Find("userId")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "42", userId.Value)
+
+	bearer, ok := list.Auth.Auths[auth.Bearer.String()]
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "${auth_token}", bearer.Parameters[auth_strategy.BEARER_PARAM_TOKEN])
+	assert.Equal(t, auth_strategy.DEFAULT_BEARER_PREFIX, bearer.Parameters[auth_strategy.BEARER_PARAM_PREFIX])
+}
+
+func TestUnmarshal_BodyAndAuth(t *testing.T) {
+	tasks := makeImport(t).Workspaces[0].Collections[1]
+
+	create := tasks.Requests[1]
+	assert.Equal(t, domain.Json, create.Body.ContentType)
+
+	payload := create.Body.Parameters[body_strategy.DOCUMENT_PARAM][body_strategy.PAYLOAD_PARAM]
+	assert.Equal(t, `{"title": "{% faker 'randomWord' %}"}`, payload[0].Value)
+
+	basic, ok := create.Auth.Auths[auth.Basic.String()]
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "${secret}", basic.Parameters[auth_strategy.BASIC_PARAM_PASSWORD])
+
+	upload := tasks.Requests[2]
+	assert.Equal(t, domain.Form, upload.Body.ContentType)
+	assert.Equal(t, false, upload.Auth.Status)
+
+	file, ok := body_strategy.FindFormDataParameterIndex(&upload.Body, "file", 0)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, true, file.IsFile)
+}
+
+func TestUnmarshal_Context(t *testing.T) {
+	workspace := makeImport(t).Workspaces[0]
+
+	root := workspace.Collections[0].Context
+	assert.Equal(t, "https://api.example.com", root.Apply(context.URI.String(), "${baseUrl}"))
+
+	tasks := workspace.Collections[1].Context
+	assert.Equal(t, "https://tasks.example.com", tasks.Apply(context.URI.String(), "${baseUrl}"))
+	assert.Equal(t, "20", tasks.Apply(context.QUERY.String(), "${size}"))
+	assert.Equal(t, "abc", tasks.Apply(context.AUTH.String(), "${auth_token}"))
+}
+
+func TestUnmarshal_Report(t *testing.T) {
+	report := makeImport(t).Report
+
+	reasons := make(map[string]string)
+	for _, v := range report.Items {
+		reasons[v.Id] += v.Reason + ";"
+	}
+
+	assert.Equal(t, "only the base environment is imported;", reasons["env_prod"])
+	assert.Equal(t, "template tags are not supported;", reasons["req_create"])
+	assert.Equal(t, "the content of the file '/tmp/report.pdf' is not included;unsupported authentication type 'oauth2';", reasons["req_upload"])
+	assert.Equal(t, "unsupported resource type 'cookie_jar';", reasons["jar_1"])
+	assert.Equal(t, "unsupported resource type 'grpc_request';", reasons["greq_1"])
+	assert.Equal(t, "the resource does not belong to any workspace;", reasons["req_orphan"])
+	assert.Len(t, 7, report.Items)
+}
+
+func TestUnmarshal_Invalid(t *testing.T) {
+	_, err := insomnia.Unmarshal(TEST_OWNER, []byte(`{"_type": "export", "__export_format": 3, "resources": []}`))
+	assert.Error(t, err)
+
+	_, err = insomnia.Unmarshal(TEST_OWNER, []byte(`{"info": {}}`))
+	assert.Error(t, err)
+}
+
+func TestUnmarshal_NestedEnvironment(t *testing.T) {
+	file := []byte(`{
+  "_type": "export",
+  "__export_format": 4,
+  "resources": [
+    { "_id": "wrk_1", "_type": "workspace", "parentId": null, "name": "Shop" },
+    { "_id": "fld_a", "_type": "request_group", "parentId": "wrk_1", "name": "A", "environment": { "host": "a.local" } },
+    { "_id": "fld_nested", "_type": "request_group", "parentId": "fld_a", "name": "Nested", "environment": { "host": "nested.local", "extra": "1" } },
+    { "_id": "req_nested", "_type": "request", "parentId": "fld_nested", "name": "Nested", "method": "GET", "url": "{{ _.host }}/nested" },
+    { "_id": "fld_b", "_type": "request_group", "parentId": "wrk_1", "name": "B", "environment": { "host": "b.local" } },
+    { "_id": "req_b", "_type": "request", "parentId": "fld_b", "name": "B", "method": "GET", "url": "{{ _.host }}/b" }
+  ]
+}`)
+
+	result, err := insomnia.Unmarshal(TEST_OWNER, file)
+	assert.NotError(t, err)
+
+	collections := result.Workspaces[0].Collections
+	assert.Len(t, 2, collections)
+
+	a := collections[0].Context
+	assert.Equal(t, "a.local", a.Apply(context.URI.String(), "${host}"))
+	category, ok := a.Dictionary.Get(context.URI.String())
+	assert.Equal(t, true, ok)
+	_, ok = category.Get("extra")
+	assert.Equal(t, false, ok)
+
+	b := collections[1].Context
+	assert.Equal(t, "b.local", b.Apply(context.URI.String(), "${host}"))
+
+	reasons := make(map[string]string)
+	for _, v := range result.Report.Items {
+		reasons[v.Id] += v.Reason + ";"
+	}
+	assert.Equal(t, "the environment of nested folders is not imported;", reasons["fld_nested"])
+}
+
+func TestImport_Collections(t *testing.T) {
+	file := []byte(`{
+  "_type": "export",
+  "__export_format": 4,
+  "resources": [
+    { "_id": "wrk_1", "_type": "workspace", "parentId": null, "name": "Shop" },
+    { "_id": "req_1", "_type": "request", "parentId": "wrk_1", "name": "Home", "method": "GET", "url": "/" },
+    { "_id": "fld_1", "_type": "request_group", "parentId": "wrk_1", "name": "Orders" },
+    { "_id": "req_2", "_type": "request", "parentId": "fld_1", "name": "List", "method": "GET", "url": "/orders" },
+    { "_id": "wrk_2", "_type": "workspace", "parentId": null, "name": "Billing" },
+    { "_id": "fld_2", "_type": "request_group", "parentId": "wrk_2", "name": "Invoices" },
+    { "_id": "req_3", "_type": "request", "parentId": "fld_2", "name": "List", "method": "GET", "url": "/invoices" }
+  ]
+}`)
+
+	result, err := insomnia.Unmarshal(TEST_OWNER, file)
+	assert.NotError(t, err)
+	assert.Len(t, 2, result.Workspaces)
+
+	names := make(map[string]bool)
+	for _, v := range result.Collections() {
+		names[v.Name] = true
+	}
+
+	assert.Equal(t, 3, len(names))
+	assert.Equal(t, true, names["Shop"])
+	assert.Equal(t, true, names["Shop - Orders"])
+	assert.Equal(t, true, names["Billing - Invoices"])
+
+	single := makeImport(t).Collections()
+	assert.Len(t, 2, single)
+	assert.Equal(t, "Task API", single[0].Name)
+	assert.Equal(t, "Tasks", single[1].Name)
+}
@@ -0,0 +1,205 @@
+package layout_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	body_strategy "github.com/Rafael24595/go-api-core/src/domain/action/body/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/collection"
+	"github.com/Rafael24595/go-api-core/src/domain/context"
+	"github.com/Rafael24595/go-api-core/src/domain/formatter/layout"
+	"github.com/Rafael24595/go-api-core/src/domain/group"
+	"github.com/Rafael24595/go-api-core/test/support/assert"
+)
+
+func makeSource() layout.Source {
+	first := action.NewRequest("List users", domain.GET, "https://api.example.com/users")
+	first.Id = "req-1"
+	first.Query.Add("page", "2")
+	first.Header.Add("Accept", "application/json")
+	first.Tags = []string{"smoke"}
+
+	second := action.NewRequest("Create user", domain.POST, "https://api.example.com/users")
+	second.Id = "req-2"
+	second.Body = *body_strategy.DocumentBody(true, domain.Json, "{\n  \"name\": \"John\"\n}")
+
+	ctx := context.NewContext("")
+	ctx.Id = "ctx-1"
+	ctx.Put(context.HEADER, "token", "abc", false)
+
+	coll := collection.Collection{
+		Id:     "coll-1",
+		Name:   "Users API",
+		Status: collection.FREE,
+		Tags:   []string{"users"},
+		Nodes: []domain.NodeReference{
+			{Order: 1, Item: "req-1"},
+			{Order: 0, Item: "req-2"},
+		},
+	}
+
+	return layout.Source{
+		Collection: coll,
+		Context:    ctx,
+		Requests:   []action.Request{*first, *second},
+	}
+}
+
+func TestMarshalCollection_Files(t *testing.T) {
+	tree, err := layout.MarshalCollection(layout.YAML, makeSource())
+	assert.NotError(t, err)
+
+	assert.Equal(t, strings.Join([]string{
+		"collection.yaml",
+		"context.yaml",
+		"requests/create-user.yaml",
+		"requests/list-users.yaml",
+	}, ","), strings.Join(tree.Paths(), ","))
+
+	index := string(tree["collection.yaml"])
+	assert.Equal(t, true, strings.Index(index, "create-user.yaml") < strings.Index(index, "list-users.yaml"))
+
+	request := string(tree["requests/create-user.yaml"])
+	assert.Equal(t, true, strings.Contains(request, "value: |-\n"))
+	assert.Equal(t, false, strings.Contains(request, "owner"))
+}
+
+func TestMarshalCollection_Stable(t *testing.T) {
+	source := makeSource()
+	for _, format := range []layout.Format{layout.YAML, layout.JSON} {
+		first, err := layout.MarshalCollection(format, source)
+		assert.NotError(t, err)
+		second, err := layout.MarshalCollection(format, source)
+		assert.NotError(t, err)
+
+		assert.Equal(t, strings.Join(first.Paths(), ","), strings.Join(second.Paths(), ","))
+		for _, k := range first.Paths() {
+			assert.Equal(t, string(first[k]), string(second[k]))
+		}
+	}
+}
+
+func TestMarshalCollection_DuplicatedNames(t *testing.T) {
+	source := makeSource()
+	source.Requests[1].Name = source.Requests[0].Name
+
+	tree, err := layout.MarshalCollection(layout.JSON, source)
+	assert.NotError(t, err)
+
+	_, ok := tree["requests/list-users.json"]
+	assert.Equal(t, true, ok)
+	_, ok = tree["requests/list-users-req-2.json"]
+	assert.Equal(t, true, ok)
+}
+
+func TestUnmarshalCollection_RoundTrip(t *testing.T) {
+	for _, format := range []layout.Format{layout.YAML, layout.JSON} {
+		source := makeSource()
+
+		tree, err := layout.MarshalCollection(format, source)
+		assert.NotError(t, err)
+
+		result, err := layout.UnmarshalCollection(tree)
+		assert.NotError(t, err)
+
+		assert.Equal(t, "coll-1", result.Collection.Id)
+		assert.Equal(t, "Users API", result.Collection.Name)
+		assert.Equal(t, collection.FREE, result.Collection.Status)
+		assert.Equal(t, "users", strings.Join(result.Collection.Tags, ","))
+
+		assert.Len(t, 2, result.Requests)
+		assert.Equal(t, "req-2", result.Requests[0].Id)
+		assert.Equal(t, "req-1", result.Requests[1].Id)
+		assert.Equal(t, "smoke", strings.Join(result.Requests[1].Tags, ","))
+		assert.Len(t, 0, result.Requests[0].Tags)
+		assert.Equal(t, "req-2", result.Collection.Nodes[0].Item)
+
+		document := result.Requests[0].Body.Parameters[body_strategy.DOCUMENT_PARAM][body_strategy.PAYLOAD_PARAM][0]
+		assert.Equal(t, "{\n  \"name\": \"John\"\n}", document.Value)
+
+		page, _ := result.Requests[1].Query.Find("page")
+		assert.Equal(t, "2", page[0].Value)
+
+		assert.Equal(t, "ctx-1", result.Context.Id)
+		category, ok := result.Context.Dictionary.Get(context.HEADER.String())
+		assert.Equal(t, true, ok)
+		token, ok := category.Get("token")
+		assert.Equal(t, true, ok)
+		assert.Equal(t, "abc", token.Value)
+	}
+}
+
+func TestUnmarshalCollection_Invalid(t *testing.T) {
+	_, err := layout.UnmarshalCollection(layout.Tree{})
+	assert.Error(t, err)
+
+	tree, err := layout.MarshalCollection(layout.YAML, makeSource())
+	assert.NotError(t, err)
+	delete(tree, "requests/list-users.yaml")
+
+	_, err = layout.UnmarshalCollection(tree)
+	assert.Error(t, err)
+}
+
+func TestGroup_RoundTrip(t *testing.T) {
+	first := makeSource()
+
+	second := makeSource()
+	second.Collection.Id = "coll-2"
+	second.Collection.Name = "Orders API"
+
+	grp := group.NewGroup("")
+	grp.Id = "group-1"
+	grp.Nodes = []domain.NodeReference{
+		{Order: 1, Item: "coll-1"},
+		{Order: 0, Item: "coll-2"},
+	}
+
+	tree, err := layout.MarshalGroup(layout.YAML, grp, first, second)
+	assert.NotError(t, err)
+
+	_, ok := tree["orders-api/collection.yaml"]
+	assert.Equal(t, true, ok)
+	_, ok = tree["users-api/requests/list-users.yaml"]
+	assert.Equal(t, true, ok)
+
+	result, sources, err := layout.UnmarshalGroup(tree)
+	assert.NotError(t, err)
+
+	assert.Equal(t, "group-1", result.Id)
+	assert.Len(t, 2, sources)
+	assert.Equal(t, "coll-2", sources[0].Collection.Id)
+	assert.Equal(t, "coll-1", sources[1].Collection.Id)
+	assert.Equal(t, "coll-2", result.Nodes[0].Item)
+}
+
+func TestTree_Dir(t *testing.T) {
+	tree, err := layout.MarshalCollection(layout.YAML, makeSource())
+	assert.NotError(t, err)
+
+	root := t.TempDir()
+	assert.NotError(t, tree.WriteDir(root))
+
+	result, err := layout.TreeFromDir(root)
+	assert.NotError(t, err)
+
+	assert.Equal(t, strings.Join(tree.Paths(), ","), strings.Join(result.Paths(), ","))
+	for _, k := range tree.Paths() {
+		assert.Equal(t, string(tree[k]), string(result[k]))
+	}
+}
+
+func TestFormatFromString(t *testing.T) {
+	format, err := layout.FormatFromString("")
+	assert.NotError(t, err)
+	assert.Equal(t, layout.YAML, format)
+
+	format, err = layout.FormatFromString("JSON")
+	assert.NotError(t, err)
+	assert.Equal(t, layout.JSON, format)
+
+	_, err = layout.FormatFromString("xml")
+	assert.Error(t, err)
+}
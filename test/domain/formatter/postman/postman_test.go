@@ -0,0 +1,167 @@
+package postman_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action/auth"
+	auth_strategy "github.com/Rafael24595/go-api-core/src/domain/action/auth/strategy"
+	body_strategy "github.com/Rafael24595/go-api-core/src/domain/action/body/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/action/query"
+	"github.com/Rafael24595/go-api-core/src/domain/context"
+	"github.com/Rafael24595/go-api-core/src/domain/formatter/postman"
+	"github.com/Rafael24595/go-api-core/test/support"
+	"github.com/Rafael24595/go-api-core/test/support/assert"
+)
+
+const TEST_OWNER = "anonymous"
+
+func makeImport(t *testing.T) *postman.Import {
+	file := support_test.ReadText(t, "sources/collection001.json")
+	env := support_test.ReadText(t, "sources/environment001.json")
+
+	result, err := postman.Unmarshal(TEST_OWNER, file, env)
+	assert.NotError(t, err)
+
+	return result
+}
+
+func TestUnmarshal_Folders(t *testing.T) {
+	result := makeImport(t)
+
+	assert.Equal(t, "Task API", result.Name)
+	assert.Len(t, 2, result.Folders)
+
+	root := result.Folders[0]
+	assert.Equal(t, "", root.Name)
+	assert.Len(t, 2, root.Requests)
+	assert.Equal(t, "Health", root.Requests[0].Name)
+	assert.Equal(t, "Upload", root.Requests[1].Name)
+
+	tasks := result.Folders[1]
+	assert.Equal(t, "Tasks", tasks.Name)
+	assert.Len(t, 2, tasks.Requests)
+	assert.Equal(t, "Admin / Create task", tasks.Requests[1].Name)
+
+	requests := result.Requests()
+	assert.Len(t, 4, requests)
+	assert.Equal(t, "Tasks / Admin / Create task", requests[3].Name)
+}
+
+func TestUnmarshal_Request(t *testing.T) {
+	list := makeImport(t).Folders[1].Requests[0]
+
+	assert.Equal(t, domain.GET, list.Method)
+	assert.Equal(t, "${baseUrl}/users/:userId/tasks", list.Uri)
+	assert.Equal(t, TEST_OWNER, list.Owner)
+
+	size, _ := list.Query.Find("size")
+	assert.Equal(t, "${size}", size[0].Value)
+
+	userId, ok := list.Param.Find("userId")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "42", userId.Value)
+	assert.Equal(t, "The user id", userId.Description)
+
+	debug, _ := list.Header.Find("X-Debug")
+	assert.Equal(t, false, debug[0].Status)
+
+	bearer, ok := list.Auth.Auths[auth.Bearer.String()]
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "${token}", bearer.Parameters[auth_strategy.BEARER_PARAM_TOKEN])
+}
+
+func TestUnmarshal_Auth(t *testing.T) {
+	result := makeImport(t)
+
+	health := result.Folders[0].Requests[0]
+	assert.Equal(t, false, health.Auth.Status)
+
+	create := result.Folders[1].Requests[1]
+	basic, ok := create.Auth.Auths[auth.Basic.String()]
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "admin", basic.Parameters[auth_strategy.BASIC_PARAM_USER])
+	assert.Equal(t, "secret", basic.Parameters[auth_strategy.BASIC_PARAM_PASSWORD])
+
+	upload := result.Folders[0].Requests[1]
+	apiKey, ok := upload.Header.Find("X-Api-Key")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "${apiKey}", apiKey[0].Value)
+}
+
+func TestUnmarshal_Body(t *testing.T) {
+	result := makeImport(t)
+
+	create := result.Folders[1].Requests[1]
+	assert.Equal(t, domain.Json, create.Body.ContentType)
+
+	payload := create.Body.Parameters[body_strategy.DOCUMENT_PARAM][body_strategy.PAYLOAD_PARAM]
+	assert.Equal(t, `{"title": "${title}"}`, payload[0].Value)
+
+	upload := result.Folders[0].Requests[1]
+	assert.Equal(t, domain.Form, upload.Body.ContentType)
+
+	file, ok := body_strategy.FindFormDataParameterIndex(&upload.Body, "file", 0)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, true, file.IsFile)
+	assert.Equal(t, "/tmp/report.pdf", file.FileName)
+}
+
+func TestUnmarshal_Context(t *testing.T) {
+	ctx := makeImport(t).Context
+
+	assert.Equal(t, "https://prod.example.com", ctx.Apply(context.URI.String(), "${baseUrl}"))
+	assert.Equal(t, "20", ctx.Apply(context.QUERY.String(), "${size}"))
+	assert.Equal(t, "prod-token", ctx.Apply(context.AUTH.String(), "${token}"))
+	assert.Equal(t, "", ctx.Apply(context.HEADER.String(), "${apiKey}"))
+	assert.Equal(t, "x", ctx.Apply(context.URI.String(), "${unused}"))
+}
+
+func TestUnmarshal_Invalid(t *testing.T) {
+	_, err := postman.Unmarshal(TEST_OWNER, []byte(`{"info": {"name": "x", "schema": "https://schema.getpostman.com/json/collection/v1.0.0/collection.json"}}`))
+	assert.Error(t, err)
+
+	_, err = postman.Unmarshal(TEST_OWNER, []byte(`[]`))
+	assert.Error(t, err)
+}
+
+func TestMarshal_RoundTrip(t *testing.T) {
+	source := makeImport(t)
+
+	data, err := postman.Marshal(source.Name, source.Context, source.Folders...)
+	assert.NotError(t, err)
+
+	exported := postman.Collection{}
+	assert.NotError(t, json.Unmarshal(data, &exported))
+	assert.Equal(t, postman.SCHEMA_V2_1, exported.Info.Schema)
+	assert.Len(t, 3, exported.Item)
+	assert.Equal(t, "Tasks", exported.Item[2].Name)
+
+	list := exported.Item[2].Item[0].Request
+	assert.Equal(t, "{{baseUrl}}/users/:userId/tasks?page=1&size={{size}}", list.Url.Raw)
+	assert.Len(t, 1, list.Url.Variable)
+
+	create := exported.Item[2].Item[1].Request
+	assert.Equal(t, "basic", create.Auth.Type)
+	assert.Equal(t, "json", create.Body.Options.Raw.Language)
+
+	again, err := postman.Unmarshal(TEST_OWNER, data)
+	assert.NotError(t, err)
+	assert.Len(t, 4, again.Requests())
+	assert.Equal(t, "https://prod.example.com", again.Context.Apply(context.URI.String(), "${baseUrl}"))
+}
+
+func TestMarshal_PathParams(t *testing.T) {
+	source := makeImport(t)
+
+	request := source.Folders[1].Requests[0]
+	request.Uri = "https://api.example.com/users/{userId}"
+	request.Query.Queries = map[string][]query.Query{}
+
+	item := postman.MarshalRequest(request)
+	assert.Equal(t, "https://api.example.com/users/:userId", item.Request.Url.Raw)
+	assert.Equal(t, "https", item.Request.Url.Protocol)
+	assert.Equal(t, 3, len(item.Request.Url.Host))
+	assert.Equal(t, 2, len(item.Request.Url.Path))
+}
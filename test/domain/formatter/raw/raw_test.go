@@ -0,0 +1,204 @@
+package raw_test
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	"github.com/Rafael24595/go-api-core/src/domain/action/auth"
+	auth_strategy "github.com/Rafael24595/go-api-core/src/domain/action/auth/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/action/body"
+	body_strategy "github.com/Rafael24595/go-api-core/src/domain/action/body/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/action/cookie"
+	"github.com/Rafael24595/go-api-core/src/domain/action/header"
+	"github.com/Rafael24595/go-api-core/src/domain/formatter/raw"
+	"github.com/Rafael24595/go-api-core/test/support"
+	"github.com/Rafael24595/go-api-core/test/support/assert"
+)
+
+func documentValue(request *action.Request) string {
+	return request.Body.Parameters[body_strategy.DOCUMENT_PARAM][body_strategy.PAYLOAD_PARAM][0].Value
+}
+
+func TestUnmarshal_OriginForm(t *testing.T) {
+	request, err := raw.Unmarshal(support_test.ReadText(t, "sources/request001.txt"))
+	assert.NotError(t, err)
+
+	assert.Equal(t, domain.POST, request.Method)
+	assert.Equal(t, "https://api.example.com/api/users", request.Uri)
+
+	page, _ := request.Query.Find("page")
+	assert.Equal(t, "2", page[0].Value)
+	search, _ := request.Query.Find("q")
+	assert.Equal(t, "hello world", search[0].Value)
+
+	agent, _ := request.Header.Find("User-Agent")
+	assert.Equal(t, "Mozilla/5.0", agent[0].Value)
+	trace, _ := request.Header.Find("X-Trace-Id")
+	assert.Equal(t, "abc def", trace[0].Value)
+
+	_, ok := request.Header.Find("Host")
+	assert.Equal(t, false, ok)
+	_, ok = request.Header.Find("Content-Length")
+	assert.Equal(t, false, ok)
+	_, ok = request.Header.Find("Authorization")
+	assert.Equal(t, false, ok)
+
+	assert.Equal(t, "xyz", request.Cookie.Cookies["session"].Value)
+	assert.Equal(t, "dark", request.Cookie.Cookies["theme"].Value)
+
+	basic := request.Auth.Auths[auth.Basic.String()]
+	assert.Equal(t, "admin", basic.Parameters[auth_strategy.BASIC_PARAM_USER])
+	assert.Equal(t, "secret", basic.Parameters[auth_strategy.BASIC_PARAM_PASSWORD])
+
+	assert.Equal(t, domain.Json, request.Body.ContentType)
+	assert.Equal(t, `{"name":"John"}`, documentValue(request))
+}
+
+func TestUnmarshal_Chunked(t *testing.T) {
+	request, err := raw.Unmarshal(support_test.ReadText(t, "sources/request002.txt"))
+	assert.NotError(t, err)
+
+	assert.Equal(t, domain.PUT, request.Method)
+	assert.Equal(t, "http://localhost:8080/upload", request.Uri)
+	assert.Equal(t, domain.Text, request.Body.ContentType)
+	assert.Equal(t, "hello world", documentValue(request))
+
+	_, ok := request.Header.Find("Transfer-Encoding")
+	assert.Equal(t, false, ok)
+}
+
+func TestUnmarshal_Multipart(t *testing.T) {
+	request, err := raw.Unmarshal(support_test.ReadText(t, "sources/request003.txt"))
+	assert.NotError(t, err)
+
+	assert.Equal(t, "http://example.com/form", request.Uri)
+	assert.Equal(t, domain.Form, request.Body.ContentType)
+
+	form := request.Body.Parameters[body_strategy.FORM_DATA_PARAM]
+	assert.Equal(t, "Holiday", form["title"][0].Value)
+	assert.Equal(t, true, form["photo"][0].IsFile)
+	assert.Equal(t, "beach.png", form["photo"][0].FileName)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("PNGDATA")), form["photo"][0].Value)
+}
+
+func TestUnmarshal_Invalid(t *testing.T) {
+	inputs := []string{
+		"",
+		"GET /users\r\nHost: example.com\r\n\r\n",
+		"FETCH /users HTTP/1.1\r\nHost: example.com\r\n\r\n",
+		"GET /users HTTP/1.1\r\nAccept: */*\r\n\r\n",
+		"GET /users HTTP/1.1\r\nHost: example.com\r\ninvalid\r\n\r\n",
+		"POST /users HTTP/1.1\r\nHost: example.com\r\nContent-Length: abc\r\n\r\nbody",
+	}
+
+	for _, v := range inputs {
+		_, err := raw.Unmarshal([]byte(v))
+		assert.Error(t, err)
+	}
+}
+
+func TestUnmarshalScheme(t *testing.T) {
+	request, err := raw.UnmarshalScheme("https", []byte("GET / HTTP/1.1\nHost: example.com\n\n"))
+	assert.NotError(t, err)
+	assert.Equal(t, "https://example.com/", request.Uri)
+	assert.Equal(t, true, request.Body.Empty())
+}
+
+func TestMarshal(t *testing.T) {
+	request := action.NewRequest("users", domain.POST, "https://api.example.com/users/{id}?lang=en")
+	request.Param.Add("id", "7")
+	request.Query.Add("page", "2")
+	request.Header.Add("Accept", "application/json")
+	request.Cookie.Cookies["session"] = cookie.NewCookieClient(0, true, "xyz")
+	request.Auth.PutAuth(*auth_strategy.BearerAuth(true, auth_strategy.DEFAULT_BEARER_PREFIX, "token"))
+	request.Auth.Status = true
+	request.Body = *body_strategy.DocumentBody(true, domain.Json, `{"name":"John"}`)
+
+	data, err := raw.Marshal(request)
+	assert.NotError(t, err)
+
+	expected := strings.Join([]string{
+		"POST /users/7?lang=en&page=2 HTTP/1.1",
+		"Host: api.example.com",
+		"Accept: application/json",
+		"Authorization: Bearer token",
+		"Cookie: session=xyz",
+		"Content-Type: application/json",
+		"Content-Length: 15",
+		"",
+		`{"name":"John"}`,
+	}, "\r\n")
+
+	assert.Equal(t, expected, string(data))
+
+	_, ok := request.Header.Find("Authorization")
+	assert.Equal(t, false, ok)
+}
+
+func TestMarshal_Form(t *testing.T) {
+	request := action.NewRequest("form", domain.POST, "http://example.com/form")
+	request.Body = *body_strategy.FormDataBody(true, domain.Form, body_strategy.NewBuilderFromDataBody().
+		Add("title", body.NewParameterActive("Holiday")).
+		Add("photo", body.NewFileParameterActive("png", "beach.png", base64.StdEncoding.EncodeToString([]byte("PNGDATA")))))
+
+	data, err := raw.Marshal(request)
+	assert.NotError(t, err)
+	assert.Equal(t, true, strings.Contains(string(data), "Content-Type: multipart/form-data; boundary="+raw.FORM_BOUNDARY))
+
+	result, err := raw.Unmarshal(data)
+	assert.NotError(t, err)
+
+	form := result.Body.Parameters[body_strategy.FORM_DATA_PARAM]
+	assert.Equal(t, "Holiday", form["title"][0].Value)
+	assert.Equal(t, "beach.png", form["photo"][0].FileName)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("PNGDATA")), form["photo"][0].Value)
+}
+
+func TestMarshal_RoundTrip(t *testing.T) {
+	source, err := raw.Unmarshal(support_test.ReadText(t, "sources/request001.txt"))
+	assert.NotError(t, err)
+
+	data, err := raw.Marshal(source)
+	assert.NotError(t, err)
+
+	result, err := raw.UnmarshalScheme("https", data)
+	assert.NotError(t, err)
+
+	assert.Equal(t, source.Method, result.Method)
+	assert.Equal(t, source.Uri, result.Uri)
+	assert.Equal(t, documentValue(source), documentValue(result))
+	assert.Equal(t, len(source.Cookie.Cookies), len(result.Cookie.Cookies))
+	assert.Equal(t, len(source.Query.Queries), len(result.Query.Queries))
+	assert.Equal(t, len(source.Header.Headers), len(result.Header.Headers))
+}
+
+func TestMarshal_Invalid(t *testing.T) {
+	_, err := raw.Marshal(action.NewRequest("relative", domain.GET, "/users"))
+	assert.Error(t, err)
+}
+
+func TestMarshalResponse(t *testing.T) {
+	response := &action.Response{
+		Status: 404,
+		Headers: header.Headers{
+			Headers: map[string][]header.Header{
+				"Content-Type":      {{Status: true, Value: "application/json"}},
+				"Transfer-Encoding": {{Status: true, Value: "chunked"}},
+			},
+		},
+		Body: *body.NewResponseBody(domain.Json, `{"error":"not found"}`),
+	}
+
+	expected := strings.Join([]string{
+		"HTTP/1.1 404 Not Found",
+		"Content-Type: application/json",
+		"Content-Length: 21",
+		"",
+		`{"error":"not found"}`,
+	}, "\r\n")
+
+	assert.Equal(t, expected, string(raw.MarshalResponse(response)))
+}
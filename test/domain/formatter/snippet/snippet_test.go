@@ -0,0 +1,110 @@
+package snippet_test
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	auth_strategy "github.com/Rafael24595/go-api-core/src/domain/action/auth/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/action/body"
+	body_strategy "github.com/Rafael24595/go-api-core/src/domain/action/body/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/context"
+	"github.com/Rafael24595/go-api-core/src/domain/formatter/snippet"
+	"github.com/Rafael24595/go-api-core/test/support/assert"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+func makeJsonRequest() *action.Request {
+	req := action.NewRequest("json", domain.POST, "${host}/users/{id}")
+	req.Param.Add("id", "42")
+	req.Query.Add("lang", "en")
+	req.Query.Add("tags", "a")
+	req.Query.Add("tags", "b")
+	req.Header.Add("Accept", "application/json")
+	req.Cookie.Put("session", "it's-me")
+	req.Body = *body_strategy.DocumentBody(true, domain.Json, `{"name": "O'Brien", "note": "say \"hi\""}`)
+	req.Auth.Status = true
+	req.Auth.PutAuth(*auth_strategy.BearerAuth(true, auth_strategy.DEFAULT_BEARER_PREFIX, "${token}"))
+	return req
+}
+
+func makeFormRequest() *action.Request {
+	req := action.NewRequest("form", domain.PUT, "https://example.com/upload")
+	req.Header.Add("Content-Type", "multipart/form-data")
+	req.Body = *body_strategy.FormDataBody(true, domain.Form, body_strategy.NewBuilderFromDataBody().
+		Add("description", body.NewParameterActive("profile picture")).
+		Add("avatar", body.NewFileParameterActive("image/png", "avatar.png", "aGVsbG8=")))
+	return req
+}
+
+func makeContext() *context.Context {
+	ctx := context.NewContext("tester")
+	ctx.Put(context.URI, "host", "https://api.example.com", false)
+	ctx.Put(context.AUTH, "token", "secret", true)
+	return ctx
+}
+
+func assertGolden(t *testing.T, name string, actual string) {
+	path := fmt.Sprintf("golden/%s.golden", name)
+
+	if *update {
+		assert.NotError(t, os.WriteFile(path, []byte(actual), 0644))
+	}
+
+	expected, err := os.ReadFile(path)
+	assert.NotError(t, err)
+	assert.Equal(t, string(expected), actual)
+}
+
+func TestMarshalContext_Golden(t *testing.T) {
+	cases := map[string]*action.Request{
+		"json": makeJsonRequest(),
+		"form": makeFormRequest(),
+	}
+
+	for _, language := range snippet.Languages() {
+		for name, req := range cases {
+			t.Run(fmt.Sprintf("%s_%s", language, name), func(t *testing.T) {
+				result, err := snippet.MarshalContext(makeContext(), language, req)
+				if language == snippet.WGET && name == "form" {
+					assert.Error(t, err)
+					return
+				}
+
+				assert.NotError(t, err)
+				assertGolden(t, fmt.Sprintf("%s.%s", name, language), result)
+			})
+		}
+	}
+}
+
+func TestMarshal_InvalidInput(t *testing.T) {
+	_, err := snippet.Marshal(snippet.HTTPIE, action.NewRequest("invalid", "", "http://example.com"))
+	assert.Error(t, err)
+
+	_, err = snippet.Marshal(snippet.Language("cobol"), action.NewRequest("invalid", domain.GET, "http://example.com"))
+	assert.Error(t, err)
+}
+
+func TestLanguageFromString(t *testing.T) {
+	language, err := snippet.LanguageFromString(" PowerShell ")
+	assert.NotError(t, err)
+	assert.Equal(t, snippet.POWERSHELL, *language)
+
+	_, err = snippet.LanguageFromString("cobol")
+	assert.Error(t, err)
+}
+
+func TestMarshal_DoesNotMutateRequest(t *testing.T) {
+	req := makeJsonRequest()
+
+	_, err := snippet.Marshal(snippet.HTTPIE, req)
+	assert.NotError(t, err)
+
+	_, exists := req.Header.Find("Authorization")
+	assert.Equal(t, false, exists)
+}
@@ -0,0 +1,46 @@
+package path_test
+
+import (
+	"testing"
+
+	"github.com/Rafael24595/go-api-core/src/domain/mock"
+	"github.com/Rafael24595/go-api-core/test/support/assert"
+)
+
+func TestMatchPath_Template(t *testing.T) {
+	params, ok := mock.MatchPath("/users/{id}/orders/{order}", "/users/42/orders/7")
+
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "42", params["id"])
+	assert.Equal(t, "7", params["order"])
+}
+
+func TestMatchPath_Mismatch(t *testing.T) {
+	_, ok := mock.MatchPath("/users/{id}", "/users")
+	assert.Equal(t, false, ok)
+
+	_, ok = mock.MatchPath("/users/{id}", "/accounts/42")
+	assert.Equal(t, false, ok)
+
+	_, ok = mock.MatchPath("/users/{id}", "/users/42/orders")
+	assert.Equal(t, false, ok)
+}
+
+func TestFindPathResponse_PathArgument(t *testing.T) {
+	endPoint := &mock.EndPoint{
+		Path: "/users/{id}",
+		Responses: mock.FixResponses([]mock.Response{
+			{Status: true, Order: 1, Name: "found", Code: 200, Condition: "argument.id.$eq.<42>"},
+		}),
+	}
+
+	response, ok := mock.FindPathResponse("", "/users/42", map[string]string{}, endPoint)
+
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "found", response.Name)
+
+	response, ok = mock.FindPathResponse("", "/users/7", map[string]string{}, endPoint)
+
+	assert.Equal(t, true, ok)
+	assert.Equal(t, mock.DefaultResponse, response.Name)
+}
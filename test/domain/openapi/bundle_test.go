@@ -0,0 +1,120 @@
+package test_openapi
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	body_strategy "github.com/Rafael24595/go-api-core/src/domain/action/body/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/openapi"
+	"github.com/Rafael24595/go-api-core/test/support/assert"
+)
+
+const BUNDLE_DIR = "sources/bundle001"
+
+func makeFromBundle(t *testing.T, bundle *openapi.Bundle) []action.Request {
+	oapi, raw, err := openapi.MakeFromBundle(bundle)
+	assert.NotError(t, err)
+
+	_, _, requests, err := openapi.NewFactoryCollection(TEST_OWNER, oapi).
+		SetRaw(*raw).
+		SetBundle(bundle).
+		Make()
+	assert.NotError(t, err)
+
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].Name < requests[j].Name
+	})
+
+	return requests
+}
+
+func decodePayload(t *testing.T, request action.Request) map[string]any {
+	document, ok := request.Body.Parameters[body_strategy.DOCUMENT_PARAM][body_strategy.PAYLOAD_PARAM]
+	if !ok || len(document) == 0 {
+		t.Fatalf("Request '%s' should have a document body", request.Name)
+	}
+
+	var payload map[string]any
+	if err := json.Unmarshal([]byte(document[0].Value), &payload); err != nil {
+		t.Fatalf("Failed to parse JSON '%s': %v", document[0].Value, err)
+	}
+
+	return payload
+}
+
+func valideBundleRequests(t *testing.T, requests []action.Request) {
+	assert.Len(t, 2, requests)
+
+	team := decodePayload(t, requests[0])
+	assert.Equal[any](t, "Core", team["title"])
+
+	user := decodePayload(t, requests[1])
+	assert.Equal[any](t, "Ada", user["name"])
+	assert.Equal[any](t, "London", user["address"].(map[string]any)["city"])
+	assert.Equal[any](t, "admin", user["audit"].(map[string]any)["by"])
+
+	lead := user["team"].(map[string]any)["lead"].(map[string]any)
+	assert.Equal[any](t, "Ada", lead["name"])
+
+	_, circular := lead["team"].(map[string]any)["$Circular"]
+	assert.Equal(t, true, circular)
+}
+
+func TestBundleFromDir(t *testing.T) {
+	bundle, err := openapi.BundleFromDir(BUNDLE_DIR)
+	assert.NotError(t, err)
+
+	valideBundleRequests(t, makeFromBundle(t, bundle))
+	assert.Equal(t, "openapi.yaml", bundle.Entry)
+}
+
+func TestBundleFromZip(t *testing.T) {
+	buffer := new(bytes.Buffer)
+	writer := zip.NewWriter(buffer)
+
+	err := filepath.Walk(BUNDLE_DIR, func(file string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		relative, _ := filepath.Rel(BUNDLE_DIR, file)
+		entry, err := writer.Create(filepath.ToSlash(filepath.Join("spec", relative)))
+		if err != nil {
+			return err
+		}
+
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		_, err = entry.Write(content)
+		return err
+	})
+	assert.NotError(t, err)
+	assert.NotError(t, writer.Close())
+
+	bundle, err := openapi.BundleFromZip(buffer.Bytes())
+	assert.NotError(t, err)
+
+	valideBundleRequests(t, makeFromBundle(t, bundle))
+	assert.Equal(t, "spec/openapi.yaml", bundle.Entry)
+}
+
+func TestBundle_MissingReference(t *testing.T) {
+	bundle := openapi.NewBundle("", map[string][]byte{
+		"openapi.yaml": []byte("openapi: 3.0.0\ninfo:\n  title: Broken\n  version: 1.0.0\npaths: {}\n"),
+	})
+
+	_, _, err := openapi.MakeFromBundle(bundle)
+	assert.NotError(t, err)
+
+	var schema openapi.Schema
+	assert.Error(t, bundle.Find("schemas/missing.yaml#/Missing", &schema))
+}
@@ -0,0 +1,305 @@
+package test_openapi
+
+import (
+	"os"
+	"testing"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	"github.com/Rafael24595/go-api-core/src/domain/action/body"
+	body_strategy "github.com/Rafael24595/go-api-core/src/domain/action/body/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/action/header"
+	"github.com/Rafael24595/go-api-core/src/domain/action/param"
+	"github.com/Rafael24595/go-api-core/src/domain/action/query"
+	"github.com/Rafael24595/go-api-core/src/domain/openapi"
+	"github.com/Rafael24595/go-api-core/test/support/assert"
+)
+
+func makeContractValidator(t *testing.T) *openapi.ValidatorContract {
+	file, err := os.ReadFile("../../support/test_openapi_007.yaml")
+	assert.NotError(t, err)
+
+	oapi, raw, err := openapi.MakeFromFile(file)
+	assert.NotError(t, err)
+
+	return openapi.NewValidatorContract(oapi).SetRaw(*raw)
+}
+
+func makeContractResponse(status int16, payload string, headers map[string]string) action.Response {
+	response := action.Response{
+		Status:  status,
+		Headers: *header.NewHeaders(),
+	}
+	response.Body.Payload = payload
+	for key, value := range headers {
+		response.Headers.Add(key, value)
+	}
+	return response
+}
+
+func TestValidatorContract_FindOperation(t *testing.T) {
+	validator := makeContractValidator(t)
+
+	path, _, ok := validator.FindOperation(action.Request{Method: domain.GET, Uri: "https://api.example.com/v1/users/me?debug=true"})
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "/users/me", path)
+
+	path, _, ok = validator.FindOperation(action.Request{Method: domain.GET, Uri: "${baseUrl}/users/42"})
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "/users/{userId}", path)
+
+	_, _, ok = validator.FindOperation(action.Request{Method: domain.DELETE, Uri: "${baseUrl}/users/42"})
+	assert.Equal(t, false, ok)
+}
+
+func TestValidatorContract_ValidResponse(t *testing.T) {
+	validator := makeContractValidator(t)
+
+	request := action.Request{Method: domain.GET, Uri: "https://api.example.com/v1/users"}
+	response := makeContractResponse(200, `[{"id":1,"email":"jane@example.com","role":"admin","manager":null}]`, map[string]string{
+		"content-type": "application/json; charset=utf-8",
+		"x-total":      "1",
+	})
+
+	violations, err := validator.Validate(request, response)
+	assert.NotError(t, err)
+	assert.Len(t, 0, violations)
+}
+
+func TestValidatorContract_BodyViolations(t *testing.T) {
+	validator := makeContractValidator(t)
+
+	request := action.Request{Method: domain.GET, Uri: "${baseUrl}/users/42"}
+	response := makeContractResponse(200, `{"id":1.5,"email":"invalid","role":"owner","extra":true,"manager":{"id":2}}`, map[string]string{
+		"Content-Type": "application/json",
+	})
+
+	violations, err := validator.Validate(request, response)
+	assert.NotError(t, err)
+	assert.Len(t, 5, violations)
+
+	pointers := make(map[string]string)
+	for _, violation := range violations {
+		assert.Equal(t, openapi.LOCATION_BODY, violation.Location)
+		pointers[violation.Pointer] = violation.Rule
+	}
+
+	assert.Equal(t, "type", pointers["/id"])
+	assert.Equal(t, "format", pointers["/email"])
+	assert.Equal(t, "enum", pointers["/role"])
+	assert.Equal(t, "additionalProperties", pointers["/extra"])
+	assert.Equal(t, "required", pointers["/manager/email"])
+}
+
+func TestValidatorContract_StatusAndHeaders(t *testing.T) {
+	validator := makeContractValidator(t)
+
+	request := action.Request{Method: domain.GET, Uri: "${baseUrl}/users/42"}
+
+	violations, err := validator.Validate(request, makeContractResponse(404, `{}`, map[string]string{
+		"Content-Type": "application/json",
+	}))
+	assert.NotError(t, err)
+	assert.Len(t, 1, violations)
+	assert.Equal(t, "/message", violations[0].Pointer)
+	assert.Equal(t, "required", violations[0].Rule)
+
+	violations, err = validator.Validate(request, makeContractResponse(500, ``, nil))
+	assert.NotError(t, err)
+	assert.Len(t, 1, violations)
+	assert.Equal(t, openapi.LOCATION_STATUS, violations[0].Location)
+
+	violations, err = validator.Validate(request, makeContractResponse(200, `<user/>`, map[string]string{
+		"Content-Type": "application/xml",
+	}))
+	assert.NotError(t, err)
+	assert.Len(t, 1, violations)
+	assert.Equal(t, "content-type", violations[0].Rule)
+
+	request = action.Request{Method: domain.GET, Uri: "${baseUrl}/users"}
+	violations, err = validator.Validate(request, makeContractResponse(200, `[]`, map[string]string{
+		"Content-Type": "application/json",
+	}))
+	assert.NotError(t, err)
+	assert.Len(t, 1, violations)
+	assert.Equal(t, openapi.LOCATION_HEADER, violations[0].Location)
+	assert.Equal(t, "/X-Total", violations[0].Pointer)
+}
+
+func TestValidatorContract_ValidateRequestParameters(t *testing.T) {
+	validator := makeContractValidator(t)
+
+	request := action.Request{
+		Method: domain.GET,
+		Uri:    "https://api.example.com/v1/users",
+		Query:  *query.NewQueries().Add("role", "admin,owner"),
+		Header: *header.NewHeaders().Add("x-tenant", "acme"),
+	}
+
+	violations, err := validator.ValidateRequest(request)
+	assert.NotError(t, err)
+	assert.Len(t, 1, violations)
+	assert.Equal(t, openapi.LOCATION_QUERY, violations[0].Location)
+	assert.Equal(t, "/role", violations[0].Pointer)
+	assert.Equal(t, "enum", violations[0].Rule)
+
+	request.Header = *header.NewHeaders().AddStatus("X-Tenant", "acme", false)
+	request.Query = *query.NewQueries().Add("role", "user")
+
+	violations, err = validator.ValidateRequest(request)
+	assert.NotError(t, err)
+	assert.Len(t, 1, violations)
+	assert.Equal(t, openapi.LOCATION_HEADER, violations[0].Location)
+	assert.Equal(t, "required", violations[0].Rule)
+}
+
+func TestValidatorContract_ValidateRequestPath(t *testing.T) {
+	validator := makeContractValidator(t)
+
+	request := action.Request{
+		Method: domain.GET,
+		Uri:    "${baseUrl}/users/{userId}",
+		Param:  *param.NewParams().Add("userId", "42"),
+	}
+
+	violations, err := validator.ValidateRequest(request)
+	assert.NotError(t, err)
+	assert.Len(t, 0, violations)
+
+	request.Param = *param.NewParams().Add("userId", "abc")
+	violations, err = validator.ValidateRequest(request)
+	assert.NotError(t, err)
+	assert.Len(t, 1, violations)
+	assert.Equal(t, openapi.LOCATION_PATH, violations[0].Location)
+	assert.Equal(t, "type", violations[0].Rule)
+
+	request.Param = *param.NewParams()
+	violations, err = validator.ValidateRequest(request)
+	assert.NotError(t, err)
+	assert.Len(t, 1, violations)
+	assert.Equal(t, "required", violations[0].Rule)
+}
+
+func TestValidatorContract_ValidateRequestBody(t *testing.T) {
+	validator := makeContractValidator(t)
+
+	request := action.Request{
+		Method: domain.POST,
+		Uri:    "${baseUrl}/users",
+		Body:   *body.EmptyBody(false, domain.None),
+	}
+
+	violations, err := validator.ValidateRequest(request)
+	assert.NotError(t, err)
+	assert.Len(t, 1, violations)
+	assert.Equal(t, "required", violations[0].Rule)
+
+	request.Body = *body_strategy.DocumentBody(true, domain.Json, `{"id":1,"role":"guest"}`)
+	violations, err = validator.ValidateRequest(request)
+	assert.NotError(t, err)
+	assert.Len(t, 2, violations)
+	assert.Equal(t, "/email", violations[0].Pointer)
+	assert.Equal(t, "/role", violations[1].Pointer)
+
+	request.Body = *body_strategy.DocumentBody(true, domain.Xml, `<user/>`)
+	violations, err = validator.ValidateRequest(request)
+	assert.NotError(t, err)
+	assert.Len(t, 1, violations)
+	assert.Equal(t, "content-type", violations[0].Rule)
+}
+
+func TestValidatorContract_ValidateRequestArrayStyles(t *testing.T) {
+	file, err := os.ReadFile("../../support/test_openapi_009.yaml")
+	assert.NotError(t, err)
+
+	oapi, raw, err := openapi.MakeFromFile(file)
+	assert.NotError(t, err)
+
+	validator := openapi.NewValidatorContract(oapi).SetRaw(*raw)
+
+	request := action.Request{
+		Method: domain.GET,
+		Uri:    "/items",
+		Query: *query.NewQueries().
+			Add("ids", "1,2").
+			Add("tags", "new|sale").
+			Add("sizes", "s m").
+			Add("colors", "red").
+			Add("colors", "blue"),
+	}
+
+	violations, err := validator.ValidateRequest(request)
+	assert.NotError(t, err)
+	assert.Len(t, 0, violations)
+
+	request.Query = *query.NewQueries().
+		Add("tags", "new,sale").
+		Add("colors", "red,blue")
+
+	violations, err = validator.ValidateRequest(request)
+	assert.NotError(t, err)
+	assert.Len(t, 2, violations)
+	assert.Equal(t, "/tags", violations[0].Pointer)
+	assert.Equal(t, "/colors", violations[1].Pointer)
+}
+
+func TestContract_FileValidator(t *testing.T) {
+	file, err := os.ReadFile("../../support/test_openapi_007.yaml")
+	assert.NotError(t, err)
+
+	validator, err := openapi.NewFileContract(TEST_OWNER, "collection", file).Validator()
+	assert.NotError(t, err)
+
+	request := action.Request{
+		Method: domain.GET,
+		Uri:    "https://api.example.com/v1/users",
+		Query:  *query.NewQueries().Add("role", "user"),
+		Header: *header.NewHeaders().Add("X-Tenant", "acme"),
+	}
+
+	violations, err := validator.ValidateRequest(request)
+	assert.NotError(t, err)
+	assert.Len(t, 0, violations)
+}
+
+func TestContract_BundleLoad(t *testing.T) {
+	bundle, err := openapi.BundleFromDir(BUNDLE_DIR)
+	assert.NotError(t, err)
+
+	_, _, err = openapi.MakeFromBundle(bundle)
+	assert.NotError(t, err)
+
+	contract := openapi.NewBundleContract(TEST_OWNER, "collection", bundle)
+	assert.Equal(t, "collection", contract.PersistenceId())
+
+	oapi, _, loaded, err := contract.Load()
+	assert.NotError(t, err)
+	assert.Equal(t, bundle.Entry, loaded.Entry)
+	assert.Equal(t, 2, len(oapi.Paths))
+}
+
+func TestValidatorContract_RecursiveSchema(t *testing.T) {
+	file, err := os.ReadFile("../../support/test_openapi_012.yaml")
+	assert.NotError(t, err)
+
+	oapi, raw, err := openapi.MakeFromFile(file)
+	assert.NotError(t, err)
+
+	validator := openapi.NewValidatorContract(oapi).SetRaw(*raw)
+
+	node := map[string]any{
+		"value": float64(1),
+		"children": []any{
+			map[string]any{"value": "two"},
+			map[string]any{},
+		},
+	}
+
+	violations := validator.ValidateSchema(&openapi.Schema{Ref: "#/components/schemas/Node"}, node, "")
+	assert.Len(t, 2, violations)
+	assert.Equal(t, "/children/0/value", violations[0].Pointer)
+	assert.Equal(t, "/children/1/value", violations[1].Pointer)
+
+	violations = validator.ValidateSchema(&openapi.Schema{Ref: "#/components/schemas/Any"}, float64(1), "")
+	assert.Len(t, 0, violations)
+}
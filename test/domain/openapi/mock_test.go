@@ -0,0 +1,85 @@
+package test_openapi
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/mock"
+	"github.com/Rafael24595/go-api-core/src/domain/openapi"
+	"github.com/Rafael24595/go-api-core/test/support/assert"
+)
+
+func makeMockEndPoints(t *testing.T) []mock.EndPoint {
+	file, err := os.ReadFile("../../support/test_openapi_006.yaml")
+	assert.NotError(t, err)
+
+	oapi, raw, err := openapi.MakeFromFile(file)
+	assert.NotError(t, err)
+
+	return openapi.NewFactoryMock(TEST_OWNER, oapi).SetRaw(*raw).Make()
+}
+
+func TestFactoryMock_Make(t *testing.T) {
+	endPoints := makeMockEndPoints(t)
+	assert.Len(t, 3, endPoints)
+
+	assert.Equal(t, "List pets", endPoints[0].Name)
+	assert.Equal(t, domain.GET, endPoints[0].Method)
+	assert.Equal(t, "/pets", endPoints[0].Path)
+	assert.Equal(t, 0, endPoints[0].Order)
+
+	assert.Equal(t, "Create pet", endPoints[1].Name)
+	assert.Equal(t, domain.POST, endPoints[1].Method)
+
+	assert.Equal(t, "/pets/{petId}", endPoints[2].Path)
+	assert.Equal(t, 2, endPoints[2].Order)
+}
+
+func TestFactoryMock_RequiredConditions(t *testing.T) {
+	endPoint := makeMockEndPoints(t)[0]
+	assert.Len(t, 3, endPoint.Responses)
+
+	fallback := endPoint.Responses[0]
+	assert.Equal(t, mock.DefaultResponse, fallback.Name)
+	assert.Equal(t, 400, fallback.Code)
+
+	success := endPoint.Responses[1]
+	assert.Equal(t, 200, success.Code)
+	assert.Equal(t, "argument.limit.$ne.<>.$and.argument.x-tenant.$ne.<>", success.Condition)
+
+	response, ok := mock.FindResponse("", map[string]string{"limit": "10", "x-tenant": "acme"}, &endPoint)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, 200, response.Code)
+
+	var payload []map[string]any
+	assert.NotError(t, json.Unmarshal([]byte(response.Body.Payload), &payload))
+	assert.Equal[any](t, "Rex", payload[0]["name"])
+	assert.Equal(t, "application/json", response.Arguments[0].Value)
+
+	response, ok = mock.FindResponse("", map[string]string{"limit": "10"}, &endPoint)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, 400, response.Code)
+
+	failure := endPoint.Responses[2]
+	assert.Equal(t, 500, failure.Code)
+	assert.Equal(t, "500 Server Error", failure.Name)
+	assert.Equal(t, `{ "message": "boom" }`, failure.Body.Payload)
+}
+
+func TestFactoryMock_Examples(t *testing.T) {
+	endPoints := makeMockEndPoints(t)
+
+	create := endPoints[1]
+	assert.Equal(t, mock.DefaultResponse, create.Responses[0].Name)
+	assert.Equal(t, 201, create.Responses[0].Code)
+	assert.Equal(t, `{"id":1,"name":"Rex"}`, create.Responses[0].Body.Payload)
+	assert.Equal(t, domain.Json, create.Responses[0].Body.ContentType)
+
+	find := endPoints[2]
+	assert.Equal(t, 400, find.Responses[0].Code)
+	assert.Equal(t, "Missing required parameters: fields", find.Responses[0].Body.Payload)
+	assert.Equal(t, "Rex", find.Responses[1].Body.Payload)
+	assert.Equal(t, domain.Text, find.Responses[1].Body.ContentType)
+}
@@ -0,0 +1,94 @@
+package test_openapi
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/Rafael24595/go-api-core/src/domain/context"
+	"github.com/Rafael24595/go-api-core/src/domain/openapi"
+	"github.com/Rafael24595/go-api-core/test/support/assert"
+)
+
+func TestMakeFromOpenApi31_Model(t *testing.T) {
+	file, err := os.ReadFile("../../support/test_openapi_005.yaml")
+	assert.NotError(t, err)
+
+	oapi, _, err := openapi.MakeFromYaml(file)
+	assert.NotError(t, err)
+
+	order := oapi.Components.Schemas["Order"]
+	assert.Equal(t, "string", order.Properties["note"].Type)
+	assert.Equal(t, true, order.Properties["note"].Nullable)
+	assert.Equal(t, "integer", order.Properties["quantity"].Type)
+	assert.Equal(t, 1, len(order.Defs))
+	assert.Equal(t, 1, len(oapi.Webhooks))
+}
+
+func TestMakeFromOpenApi31_Requests(t *testing.T) {
+	file, err := os.ReadFile("../../support/test_openapi_005.yaml")
+	assert.NotError(t, err)
+
+	oapi, raw, err := openapi.MakeFromYaml(file)
+	assert.NotError(t, err)
+
+	_, ctx, requests, err := openapi.NewFactoryCollection(TEST_OWNER, oapi).SetRaw(*raw).Make()
+	assert.NotError(t, err)
+	assert.Len(t, 2, requests)
+
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].Name < requests[j].Name
+	})
+
+	order := decodePayload(t, requests[0])
+	assert.Equal(t, "Create order", requests[0].Name)
+	assert.Equal[any](t, "ord-1", order["reference"].(map[string]any)["id"])
+	assert.Equal[any](t, "note", order["note"])
+	assert.Equal[any](t, float64(3), order["quantity"])
+	assert.Equal[any](t, "USD", order["currency"])
+
+	webhook := decodePayload(t, requests[1])
+	assert.Equal(t, "Webhook / Order shipped", requests[1].Name)
+	assert.Equal(t, "${webhook}/orderShipped", requests[1].Uri)
+	assert.Equal[any](t, "order.shipped", webhook["event"])
+	assert.Equal[any](t, "ord-1", webhook["order"].(map[string]any)["id"])
+
+	assert.Equal(t, openapi.WEBHOOK_DEFAULT_URL, ctx.Apply(context.URI.String(), requests[1].Uri[:len("${webhook}")]))
+}
+
+func TestMakeFromOpenApi31_MultipleTypes(t *testing.T) {
+	file, err := os.ReadFile("../../support/test_openapi_013.yaml")
+	assert.NotError(t, err)
+
+	oapi, raw, err := openapi.MakeFromYaml(file)
+	assert.NotError(t, err)
+
+	value := oapi.Components.Schemas["Code"].Properties["value"]
+	assert.Equal(t, "string", value.Type)
+	assert.Len(t, 2, value.Types)
+	assert.Equal(t, "integer", value.Types[1])
+	assert.Equal(t, true, value.Nullable)
+
+	data, err := json.Marshal(value)
+	assert.NotError(t, err)
+
+	var decoded openapi.Schema
+	assert.NotError(t, json.Unmarshal(data, &decoded))
+	assert.Len(t, 2, decoded.Types)
+
+	validator := openapi.NewValidatorContract(oapi).SetRaw(*raw)
+	schema := &openapi.Schema{Ref: "#/components/schemas/Code"}
+	assert.Len(t, 0, validator.ValidateSchema(schema, map[string]any{"value": "A-1"}, ""))
+	assert.Len(t, 0, validator.ValidateSchema(schema, map[string]any{"value": float64(7)}, ""))
+	assert.Len(t, 0, validator.ValidateSchema(schema, map[string]any{"value": nil}, ""))
+
+	violations := validator.ValidateSchema(schema, map[string]any{"value": true}, "")
+	assert.Len(t, 1, violations)
+	assert.Equal(t, "expected string or integer but found boolean", violations[0].Message)
+
+	_, _, requests, err := openapi.NewFactoryCollection(TEST_OWNER, oapi).SetRaw(*raw).Make()
+	assert.NotError(t, err)
+	assert.Len(t, 1, requests)
+	assert.Equal[any](t, float64(42), decodePayload(t, requests[0])["value"])
+}
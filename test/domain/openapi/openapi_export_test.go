@@ -0,0 +1,181 @@
+package test_openapi
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	auth_strategy "github.com/Rafael24595/go-api-core/src/domain/action/auth/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/action/body"
+	body_strategy "github.com/Rafael24595/go-api-core/src/domain/action/body/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/action/header"
+	"github.com/Rafael24595/go-api-core/src/domain/action/query"
+	"github.com/Rafael24595/go-api-core/src/domain/collection"
+	"github.com/Rafael24595/go-api-core/src/domain/context"
+	"github.com/Rafael24595/go-api-core/src/domain/openapi"
+	"github.com/Rafael24595/go-api-core/test/support/assert"
+)
+
+func makeExportArguments() *openapi.FactoryOpenApi {
+	coll := collection.NewFreeCollection(TEST_OWNER)
+	coll.Name = "Petstore"
+
+	ctx := context.NewContext(TEST_OWNER)
+	ctx.Put(context.URI, "baseUrl", "https://api.example.com/v1", false)
+
+	find := action.NewRequest("Find pet", domain.GET, "${baseUrl}/pets/:petId")
+	find.Id = "find"
+	find.Param.Add("petId", "42")
+	find.Query.Add("fields", "name")
+	find.Query.Add("fields", "tag")
+	find.Query.PutStyle("fields", query.PIPE_DELIMITED, false)
+	find.Header.Add("X-Trace", "abc")
+	find.Header.Add("Content-Type", "application/json")
+	find.Auth.Status = true
+	find.Auth.PutAuth(*auth_strategy.BearerAuth(true, "Bearer", "${token}"))
+
+	found := &action.Response{
+		Status:  200,
+		Headers: *header.NewHeaders().Add("Content-Type", "application/json; charset=utf-8"),
+		Body:    *body.NewResponseBody(domain.Json, `{"id":42,"name":"Rex","weight":4.5,"tags":["dog"]}`),
+	}
+
+	create := action.NewRequest("Create pet", domain.POST, "${baseUrl}/pets")
+	create.Id = "create"
+	create.Body = *body_strategy.DocumentBody(true, domain.Json, `{"name":"Rex","birth":"2020-01-02"}`)
+
+	duplicated := action.NewRequest("Create pet again", domain.POST, "https://api.example.com/v1/pets")
+	duplicated.Id = "duplicated"
+
+	return openapi.NewFactoryOpenApi(coll, ctx).
+		AddRequest(*find, found).
+		AddRequest(*create, nil).
+		AddRequest(*duplicated, nil)
+}
+
+func TestFactoryOpenApi_Make(t *testing.T) {
+	oapi := makeExportArguments().Make()
+
+	assert.Equal(t, "3.0.3", oapi.OpenAPI)
+	assert.Equal(t, "Petstore", oapi.Info.Title)
+	assert.Len(t, 1, oapi.Servers)
+	assert.Equal(t, "https://api.example.com/v1", oapi.Servers[0].URL)
+	assert.Equal(t, 2, len(oapi.Paths))
+
+	find := oapi.Paths["/pets/{petId}"].Get
+	assert.NotNil(t, find)
+	assert.Equal(t, "findPet", find.OperationId)
+	assert.Len(t, 3, find.Parameters)
+
+	assert.Equal(t, "petId", find.Parameters[0].Name)
+	assert.Equal(t, "path", find.Parameters[0].In)
+	assert.Equal(t, "integer", find.Parameters[0].Schema.Type)
+
+	assert.Equal(t, "fields", find.Parameters[1].Name)
+	assert.Equal(t, "array", find.Parameters[1].Schema.Type)
+	assert.Equal(t, "pipeDelimited", find.Parameters[1].Style)
+
+	assert.Equal(t, "X-Trace", find.Parameters[2].Name)
+	assert.Equal(t, "header", find.Parameters[2].In)
+
+	response, ok := find.Responses["200"]
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "OK", response.Description)
+
+	schema := response.Content["application/json"].Schema
+	assert.Equal(t, "object", schema.Type)
+	assert.Equal(t, "integer", schema.Properties["id"].Type)
+	assert.Equal(t, "number", schema.Properties["weight"].Type)
+	assert.Equal(t, "array", schema.Properties["tags"].Type)
+
+	assert.Len(t, 1, find.Security)
+	assert.Equal(t, "bearer", oapi.Components.SecuritySchemes["bearerAuth"].Scheme)
+
+	create := oapi.Paths["/pets"].Post
+	assert.NotNil(t, create)
+	assert.Equal(t, "Create pet", create.Summary)
+	assert.Equal(t, "date", create.RequestBody.Content["application/json"].Schema.Properties["birth"].Format)
+
+	_, ok = create.Responses["default"]
+	assert.Equal(t, true, ok)
+}
+
+func TestFactoryOpenApi_RoundTrip(t *testing.T) {
+	oapi := makeExportArguments().Make()
+
+	data, err := openapi.SerializeToYaml(oapi)
+	assert.NotError(t, err)
+	assert.Equal(t, true, strings.HasPrefix(string(data), "openapi: 3.0.3"))
+
+	imported, _, err := openapi.MakeFromYaml(data)
+	assert.NotError(t, err)
+
+	_, ctx, requests, err := openapi.NewFactoryCollection(TEST_OWNER, imported).Make()
+	assert.NotError(t, err)
+	assert.NotNil(t, ctx)
+	assert.Len(t, 2, requests)
+
+	data, err = openapi.SerializeToJson(oapi)
+	assert.NotError(t, err)
+
+	var decoded map[string]any
+	assert.NotError(t, json.Unmarshal(data, &decoded))
+	assert.Equal[any](t, "3.0.3", decoded["openapi"])
+}
+
+func TestFactoryOpenApi_OperationIdRunes(t *testing.T) {
+	coll := collection.NewFreeCollection(TEST_OWNER)
+
+	request := action.NewRequest("buscar ñandú über", domain.GET, "https://api.example.com/birds")
+	request.Id = "birds"
+
+	oapi := openapi.NewFactoryOpenApi(coll, nil).
+		AddRequest(*request, nil).
+		Make()
+
+	operation := oapi.Paths["/birds"].Get
+	assert.NotNil(t, operation)
+	assert.Equal(t, "buscarÑandúÜber", operation.OperationId)
+	assert.Equal(t, true, utf8.ValidString(operation.OperationId))
+}
+
+func TestFactoryOpenApi_MergeDuplicatedOperation(t *testing.T) {
+	coll := collection.NewFreeCollection(TEST_OWNER)
+
+	create := action.NewRequest("Create pet", domain.POST, "https://api.example.com/pets")
+	create.Id = "create"
+	create.Body = *body_strategy.DocumentBody(true, domain.Json, `{"name":"Rex"}`)
+
+	again := action.NewRequest("Create pet again", domain.POST, "https://api.example.com/pets")
+	again.Id = "again"
+	again.Query.Add("dryRun", "true")
+	again.Body = *body_strategy.DocumentBody(true, domain.Json, `{"name":"Tom"}`)
+
+	list := action.NewRequest("List pets", domain.GET, "https://api.example.com/pets")
+	list.Id = "list"
+
+	oapi := openapi.NewFactoryOpenApi(coll, nil).
+		AddRequest(*create, nil).
+		AddRequest(*again, nil).
+		AddRequest(*list, nil).
+		Make()
+
+	operation := oapi.Paths["/pets"].Post
+	assert.NotNil(t, operation)
+	assert.Equal(t, "createPet", operation.OperationId)
+	assert.Equal(t, "listPets", oapi.Paths["/pets"].Get.OperationId)
+
+	assert.Len(t, 1, operation.Parameters)
+	assert.Equal(t, "dryRun", operation.Parameters[0].Name)
+	assert.Equal(t, false, operation.Parameters[0].Required)
+
+	media := operation.RequestBody.Content["application/json"]
+	assert.Equal(t, nil, media.Example)
+	assert.Equal(t, 2, len(media.Examples))
+	assert.Equal[any](t, "Rex", media.Examples["createPet"].Value.(map[string]any)["name"])
+	assert.Equal(t, "Create pet again", media.Examples["createPetAgain"].Summary)
+	assert.Equal[any](t, "Tom", media.Examples["createPetAgain"].Value.(map[string]any)["name"])
+}
@@ -13,6 +13,7 @@ import (
 	body_strategy "github.com/Rafael24595/go-api-core/src/domain/action/body/strategy"
 	"github.com/Rafael24595/go-api-core/src/domain/action/cookie"
 	"github.com/Rafael24595/go-api-core/src/domain/action/header"
+	"github.com/Rafael24595/go-api-core/src/domain/action/param"
 	"github.com/Rafael24595/go-api-core/src/domain/action/query"
 	"github.com/Rafael24595/go-api-core/src/domain/context"
 	"github.com/Rafael24595/go-api-core/src/domain/openapi"
@@ -88,7 +89,7 @@ func valideRequests(t *testing.T, requests []action.Request) {
 
 	name := "Get collection data"
 	method := domain.GET
-	uri := "${server-0}/collection/${userId}"
+	uri := "${server-0}/collection/{userId}"
 
 	var request *action.Request
 	for _, v := range requests {
@@ -130,34 +131,41 @@ func TestMakeFromParameters(t *testing.T) {
 
 	path := "/collection/{userId}"
 	parameters := oapi.Paths[path].Get.Parameters
-	fixPath, ctx, queries, headers, cookies := builder.MakeFromParameters(path, parameters, ctx)
+	fixPath, _, params, queries, headers, cookies := builder.MakeFromParameters(path, parameters, ctx)
 
 	valideParametersPath(t, fixPath)
-	valideParametersContext(t, ctx)
+	valideParametersParam(t, params)
 	valideParametersQuery(t, queries)
 	valideParametersHeader(t, headers)
 	valideParametersCookie(t, cookies)
 }
 
 func valideParametersPath(t *testing.T, path string) {
-	expected := "/collection/${userId}"
+	expected := "/collection/{userId}"
 	if path != expected {
 		t.Errorf("Found variable %s but %s expected", path, expected)
 	}
 }
 
-func valideParametersContext(t *testing.T, ctx *context.Context) {
-	uriContext, ok := ctx.Dictionary.Get(context.URI.String())
-	if !ok {
-		t.Errorf("Uri context not found.")
+func valideParametersParam(t *testing.T, params *param.Params) {
+	if len(params.Params) != 1 {
+		t.Errorf("%d params found but %d expected.", len(params.Params), 1)
 	}
 
 	key := "userId"
 
-	userId, ok := uriContext.Get(key)
+	userId, ok := params.Find(key)
+	if !ok {
+		t.Fatalf("Param '%s' not found.", key)
+	}
+
 	expected := "The ID of the user"
-	if !ok || userId.Value != expected {
-		t.Errorf("User ID not found.")
+	if userId.Description != expected {
+		t.Errorf("Found variable %s but %s expected", userId.Description, expected)
+	}
+
+	if userId.Status || userId.Value != "" {
+		t.Errorf("Param '%s' without example should be empty and disabled.", key)
 	}
 }
 
@@ -193,6 +201,61 @@ func valideParametersQuery(t *testing.T, queries *query.Queries) {
 	}
 }
 
+func TestMakeFromParametersQueryStyles(t *testing.T) {
+	oapi, raw := makeOpenApiArguments(t)
+
+	builder := openapi.NewFactoryCollection(TEST_OWNER, oapi).SetRaw(*raw)
+
+	explode := false
+	parameters := []openapi.Parameter{
+		{
+			Name:    "ids",
+			In:      "query",
+			Style:   "pipeDelimited",
+			Explode: &explode,
+			Schema: openapi.Schema{
+				Type:  "array",
+				Items: &openapi.Schema{Type: "integer"},
+			},
+		},
+		{
+			Name:  "filter",
+			In:    "query",
+			Style: "deepObject",
+			Schema: openapi.Schema{
+				Type: "object",
+				Properties: map[string]openapi.Schema{
+					"status": {Type: "string", Example: "open"},
+					"owner":  {Type: "string"},
+				},
+			},
+		},
+	}
+
+	_, _, _, queries, _, _ := builder.MakeFromParameters("/tasks", parameters, context.NewContext(TEST_OWNER))
+
+	serialization := queries.FindSerialization("ids")
+	if serialization.Style != query.PIPE_DELIMITED || serialization.Explode {
+		t.Errorf("Found serialization %#v but pipeDelimited without explode expected", serialization)
+	}
+
+	serialization = queries.FindSerialization("filter")
+	if serialization.Style != query.DEEP_OBJECT || serialization.Explode {
+		t.Errorf("Found serialization %#v but deepObject without explode expected", serialization)
+	}
+
+	filter, ok := queries.Find("filter")
+	if !ok || len(filter) != 2 {
+		t.Fatalf("Query 'filter' not found or with unexpected length: %#v", filter)
+	}
+
+	value := queries.Encode(nil)
+	expected := "filter[owner]=&filter[status]=open&ids="
+	if value != expected {
+		t.Errorf("Found variable %v but %v expected", value, expected)
+	}
+}
+
 func valideParametersHeader(t *testing.T, headers *header.Headers) {
 	key := "X-Request-ID"
 
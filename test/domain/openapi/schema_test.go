@@ -0,0 +1,92 @@
+package test_openapi
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	body_strategy "github.com/Rafael24595/go-api-core/src/domain/action/body/strategy"
+	"github.com/Rafael24595/go-api-core/src/domain/openapi"
+	"github.com/Rafael24595/go-api-core/test/support/assert"
+)
+
+func makeCompositionPayload(t *testing.T, path string) map[string]any {
+	file, err := os.ReadFile("../../support/test_openapi_004.yaml")
+	assert.NotError(t, err)
+
+	oapi, raw, err := openapi.MakeFromYaml(file)
+	assert.NotError(t, err)
+
+	builder := openapi.NewFactoryCollection(TEST_OWNER, oapi).SetRaw(*raw)
+	result := builder.MakeFromRequestBody(oapi.Paths[path].Post.RequestBody)
+
+	document, ok := result.Parameters[body_strategy.DOCUMENT_PARAM][body_strategy.PAYLOAD_PARAM]
+	if !ok || len(document) == 0 {
+		t.Fatal("Body should be a document")
+	}
+
+	var payload map[string]any
+	if err := json.Unmarshal([]byte(document[0].Value), &payload); err != nil {
+		t.Fatalf("Failed to parse JSON '%s': %v", document[0].Value, err)
+	}
+
+	return payload
+}
+
+func TestMakeFromSchema_AllOf(t *testing.T) {
+	payload := makeCompositionPayload(t, "/pets")
+
+	assert.Equal[any](t, "3fa85f64-5717-4562-b3fc-2c963f66afa6", payload["id"])
+	assert.Equal[any](t, "2024-01-01T00:00:00Z", payload["created"])
+	assert.Equal[any](t, "Rex", payload["name"])
+	assert.Equal[any](t, "available", payload["status"])
+	assert.Equal[any](t, float64(1), payload["size"])
+}
+
+func TestMakeFromSchema_OneOf(t *testing.T) {
+	payload := makeCompositionPayload(t, "/pets")
+
+	kind, ok := payload["kind"].(map[string]any)
+	if !ok {
+		t.Fatalf("Expected object kind, got %v", payload["kind"])
+	}
+
+	assert.Equal[any](t, float64(9), kind["lives"])
+	assert.Equal(t, 1, len(kind))
+}
+
+func TestMakeFromSchema_AdditionalPropertiesAndArrays(t *testing.T) {
+	payload := makeCompositionPayload(t, "/pets")
+
+	labels, ok := payload["labels"].(map[string]any)
+	if !ok {
+		t.Fatalf("Expected object labels, got %v", payload["labels"])
+	}
+	assert.Equal[any](t, "additionalProp1", labels["additionalProp1"])
+
+	tags, ok := payload["tags"].([]any)
+	if !ok {
+		t.Fatalf("Expected array tags, got %v", payload["tags"])
+	}
+	assert.Len(t, 1, tags)
+	assert.Equal[any](t, "user@example.com", tags[0])
+}
+
+func TestMakeFromSchema_AnyOf(t *testing.T) {
+	payload := makeCompositionPayload(t, "/events")
+
+	assert.Equal[any](t, "2024-01-01", payload["on"])
+
+	data, ok := payload["payload"].(map[string]any)
+	if !ok {
+		t.Fatalf("Expected object payload, got %v", payload["payload"])
+	}
+	assert.Equal[any](t, "value", data["key"])
+}
+
+func TestMakeFromSchema_MediaTypeExamples(t *testing.T) {
+	payload := makeCompositionPayload(t, "/samples")
+
+	assert.Equal[any](t, "first", payload["id"])
+	assert.Equal(t, 1, len(payload))
+}
@@ -0,0 +1,87 @@
+package test_openapi
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	"github.com/Rafael24595/go-api-core/src/domain/context"
+	"github.com/Rafael24595/go-api-core/src/domain/openapi"
+	"github.com/Rafael24595/go-api-core/test/support/assert"
+)
+
+func makeFromFile(t *testing.T, path string) (string, *context.Context, []action.Request) {
+	file, err := os.ReadFile(path)
+	assert.NotError(t, err)
+
+	oapi, raw, err := openapi.MakeFromYaml(file)
+	assert.NotError(t, err)
+
+	coll, ctx, requests, err := openapi.NewFactoryCollection(TEST_OWNER, oapi).SetRaw(*raw).Make()
+	assert.NotError(t, err)
+
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].Name < requests[j].Name
+	})
+
+	return coll.Name, ctx, requests
+}
+
+func TestMakeFromSwagger(t *testing.T) {
+	name, ctx, requests := makeFromFile(t, "../../support/test_swagger_001.yaml")
+
+	assert.Equal(t, "Inventory-1.2.0", name)
+	assert.Equal(t, "https://api.example.com/v2", ctx.Apply(context.URI.String(), "${server-0}"))
+	assert.Len(t, 3, requests)
+
+	create := requests[0]
+	assert.Equal(t, "Create item", create.Name)
+	assert.Equal(t, "${server-0}/items", create.Uri)
+	assert.Equal(t, true, create.Auth.Status)
+
+	list := requests[1]
+	assert.Equal(t, "List items", list.Name)
+	assert.Equal(t, "pipeDelimited", list.Query.FindSerialization("tags").Style.String())
+
+	upload := requests[2]
+	assert.Equal(t, "Upload image", upload.Name)
+	assert.Len(t, 1, upload.Param.Params)
+	assert.Equal(t, "itemId", upload.Param.Params[0].Key)
+}
+
+func TestMakeFromSwagger_EquivalentToOpenApi(t *testing.T) {
+	swaggerName, swaggerCtx, swaggerRequests := makeFromFile(t, "../../support/test_swagger_001.yaml")
+	openapiName, openapiCtx, openapiRequests := makeFromFile(t, "../../support/test_openapi_003.yaml")
+
+	assert.Equal(t, openapiName, swaggerName)
+	assert.Equal(t, marshalContext(t, openapiCtx), marshalContext(t, swaggerCtx))
+	assert.Len(t, len(openapiRequests), swaggerRequests)
+
+	for i := range openapiRequests {
+		assert.Equal(t, marshalRequest(t, openapiRequests[i]), marshalRequest(t, swaggerRequests[i]))
+	}
+}
+
+func marshalContext(t *testing.T, ctx *context.Context) string {
+	variables, ok := ctx.Dictionary.Get(context.URI.String())
+	if !ok {
+		return ""
+	}
+
+	data, err := json.Marshal(variables.Pairs())
+	assert.NotError(t, err)
+
+	return string(data)
+}
+
+func marshalRequest(t *testing.T, request action.Request) string {
+	request.Timestamp = 0
+	request.Modified = 0
+
+	data, err := json.Marshal(request)
+	assert.NotError(t, err)
+
+	return string(data)
+}
@@ -0,0 +1,165 @@
+package test_openapi
+
+import (
+	"os"
+	"testing"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	"github.com/Rafael24595/go-api-core/src/domain/openapi"
+	"github.com/Rafael24595/go-api-core/test/support/assert"
+)
+
+func makeSyncResult(t *testing.T, edit func(requests []action.Request)) *openapi.SyncResult {
+	current, err := os.ReadFile("../../support/test_openapi_007.yaml")
+	assert.NotError(t, err)
+
+	oapi, raw, err := openapi.MakeFromFile(current)
+	assert.NotError(t, err)
+
+	_, _, requests, err := openapi.NewFactoryCollection(TEST_OWNER, oapi).SetRaw(*raw).Make()
+	assert.NotError(t, err)
+
+	for i := range requests {
+		requests[i].Id = requests[i].Name
+	}
+
+	edit(requests)
+
+	updated, err := os.ReadFile("../../support/test_openapi_008.yaml")
+	assert.NotError(t, err)
+
+	oapi, raw, err = openapi.MakeFromFile(updated)
+	assert.NotError(t, err)
+
+	return openapi.NewFactorySync(TEST_OWNER, oapi).SetRaw(*raw).Sync(requests)
+}
+
+func findRequest(requests []action.Request, name string) *action.Request {
+	for i := range requests {
+		if requests[i].Name == name {
+			return &requests[i]
+		}
+	}
+	return nil
+}
+
+func TestFactorySync_Report(t *testing.T) {
+	result := makeSyncResult(t, func(requests []action.Request) {})
+
+	assert.Len(t, 1, result.Report.Added)
+	assert.Equal(t, "Delete member", result.Report.Added[0].Name)
+	assert.Equal(t, domain.DELETE, result.Report.Added[0].Method)
+
+	assert.Len(t, 1, result.Report.Removed)
+	assert.Equal(t, "Create user", result.Report.Removed[0].Request)
+
+	assert.Len(t, 2, result.Report.Updated)
+	assert.Equal(t, 1, result.Report.Unchanged)
+}
+
+func TestFactorySync_PreservesEdits(t *testing.T) {
+	result := makeSyncResult(t, func(requests []action.Request) {
+		list := findRequest(requests, "List users")
+		list.Header.Headers["X-Tenant"][0].Value = "acme"
+		list.Query.Queries["role"][0].Value = "admin"
+		list.Header.Add("X-Custom", "custom")
+
+		find := findRequest(requests, "Find user")
+		find.Param.Params[0].Value = "42"
+	})
+
+	list := findRequest(result.Updated, "List users")
+	assert.NotNil(t, list)
+	assert.Equal(t, "acme", list.Header.Headers["X-Tenant"][0].Value)
+	assert.Equal(t, "custom", list.Header.Headers["X-Custom"][0].Value)
+	assert.Equal(t, "admin", list.Query.Queries["role"][0].Value)
+
+	_, ok := list.Query.Find("page")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "${server-0}/users", list.Uri)
+
+	find := findRequest(result.Updated, "Find user")
+	assert.NotNil(t, find)
+	assert.Equal(t, "${server-0}/members/{memberId}", find.Uri)
+	assert.Len(t, 1, find.Param.Params)
+	assert.Equal(t, "memberId", find.Param.Params[0].Key)
+
+	var changes []string
+	for _, v := range result.Report.Updated {
+		if v.Name == "Find user" {
+			changes = v.Changes
+		}
+	}
+
+	assert.Len(t, 3, changes)
+	assert.Equal(t, "path changed from /users/{userId} to /members/{memberId}", changes[0])
+}
+
+func makePreviousSyncResult(t *testing.T, edit func(request *action.Request)) *openapi.SyncResult {
+	previous, err := os.ReadFile("../../support/test_openapi_010.yaml")
+	assert.NotError(t, err)
+
+	source, sourceRaw, err := openapi.MakeFromFile(previous)
+	assert.NotError(t, err)
+
+	_, _, requests, err := openapi.NewFactoryCollection(TEST_OWNER, source).SetRaw(*sourceRaw).Make()
+	assert.NotError(t, err)
+
+	requests[0].Id = requests[0].Name
+	edit(&requests[0])
+
+	updated, err := os.ReadFile("../../support/test_openapi_011.yaml")
+	assert.NotError(t, err)
+
+	oapi, raw, err := openapi.MakeFromFile(updated)
+	assert.NotError(t, err)
+
+	return openapi.NewFactorySync(TEST_OWNER, oapi).
+		SetRaw(*raw).
+		SetPrevious(source, *sourceRaw, nil).
+		Sync(requests)
+}
+
+func TestFactorySync_ParameterDefinitions(t *testing.T) {
+	result := makePreviousSyncResult(t, func(request *action.Request) {
+		request.Header.Add("X-Custom", "custom")
+	})
+
+	assert.Len(t, 1, result.Updated)
+	assert.Equal(t, "Full text search", result.Updated[0].Query.Queries["q"][0].Value)
+	assert.Equal(t, "custom", result.Updated[0].Header.Headers["X-Custom"][0].Value)
+
+	changes := result.Report.Updated[0].Changes
+	assert.Len(t, 2, changes)
+	assert.Equal(t, "query parameter 'q' required, description changed", changes[0])
+	assert.Equal(t, "header 'X-Legacy' removed", changes[1])
+}
+
+func TestFactorySync_ParameterDefinitionsKeepEdits(t *testing.T) {
+	result := makePreviousSyncResult(t, func(request *action.Request) {
+		request.Query.Queries["q"][0].Value = "shoes"
+	})
+
+	assert.Len(t, 1, result.Updated)
+	assert.Equal(t, "shoes", result.Updated[0].Query.Queries["q"][0].Value)
+
+	changes := result.Report.Updated[0].Changes
+	assert.Len(t, 2, changes)
+	assert.Equal(t, "query parameter 'q' required, description changed, the edited values are kept", changes[0])
+}
+
+func TestFactorySync_ParametersNotInSpecification(t *testing.T) {
+	result := makeSyncResult(t, func(requests []action.Request) {
+		findRequest(requests, "List users").Header.Add("X-Custom", "custom")
+	})
+
+	var changes []string
+	for _, v := range result.Report.Updated {
+		if v.Name == "List users" {
+			changes = v.Changes
+		}
+	}
+
+	assert.Equal(t, "header 'X-Custom' not in the specification", changes[len(changes)-1])
+}
@@ -0,0 +1,165 @@
+package search_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/src/domain/action"
+	"github.com/Rafael24595/go-api-core/src/domain/action/body"
+	"github.com/Rafael24595/go-api-core/src/domain/action/header"
+	"github.com/Rafael24595/go-api-core/src/domain/action/query"
+	"github.com/Rafael24595/go-api-core/src/domain/collection"
+	"github.com/Rafael24595/go-api-core/src/domain/search"
+	"github.com/Rafael24595/go-api-core/test/support/assert"
+)
+
+func makeRequest(id, owner, name string, method domain.HttpMethod, uri string, headers map[string]string) action.Request {
+	request := action.Request{
+		Id:     id,
+		Owner:  owner,
+		Name:   name,
+		Method: method,
+		Uri:    uri,
+		Query:  *query.NewQueries(),
+		Header: *header.NewHeaders(),
+		Body:   *body.EmptyBody(false, domain.None),
+	}
+	for k, v := range headers {
+		request.Header.Add(k, v)
+	}
+	return request
+}
+
+func makeIndex(bodies bool) *search.Index {
+	index := search.NewIndex(bodies)
+	index.PutRequest(
+		makeRequest("req-1", "john", "List invoices", domain.GET, "https://api.local/invoices", map[string]string{"X-Tenant-Id": "acme"}),
+		makeRequest("req-2", "john", "Create invoice", domain.POST, "https://api.local/invoices", nil),
+		makeRequest("req-3", "john", "List users", domain.GET, "https://api.local/users", map[string]string{"Authorization": "Bearer secret"}),
+		makeRequest("req-4", "jane", "List invoices", domain.GET, "https://api.local/invoices", map[string]string{"X-Tenant-Id": "acme"}),
+	)
+	index.PutResponse(
+		action.Response{Id: "rsp-1", Request: "req-1", Owner: "john", Status: 200, Body: *body.NewResponseBody(domain.Json, `{"total":"overdue"}`)},
+		action.Response{Id: "rsp-2", Request: "req-2", Owner: "john", Status: 400},
+	)
+	index.PutCollection(collection.Collection{
+		Id:    "col-1",
+		Owner: "john",
+		Name:  "Billing",
+		Nodes: []domain.NodeReference{{Order: 0, Item: "req-1"}, {Order: 1, Item: "req-2"}},
+	})
+	return index
+}
+
+func hitIds(hits []search.Hit) string {
+	ids := make([]string, len(hits))
+	for i, v := range hits {
+		ids[i] = v.Id
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestSearch_Text(t *testing.T) {
+	index := makeIndex(false)
+
+	hits := index.Search("john", search.Query{Text: "invoices tenant", Kinds: []search.Kind{search.REQUEST}})
+	assert.Equal(t, "req-1", hitIds(hits))
+	assert.Equal(t, "header,name,uri", string(hits[0].Fields[0])+","+string(hits[0].Fields[1])+","+string(hits[0].Fields[2]))
+
+	hits = index.Search("john", search.Query{Text: "invo", Kinds: []search.Kind{search.REQUEST}})
+	assert.Len(t, 2, hits)
+
+	hits = index.Search("john", search.Query{Text: "bill"})
+	assert.Equal(t, "col-1", hitIds(hits))
+}
+
+func TestSearch_Owner(t *testing.T) {
+	index := makeIndex(false)
+
+	hits := index.Search("jane", search.Query{Text: "invoices"})
+	assert.Equal(t, "req-4", hitIds(hits))
+
+	assert.Len(t, 0, index.Search("nobody", search.Query{}))
+}
+
+func TestSearch_SensitiveHeaders(t *testing.T) {
+	index := makeIndex(false)
+
+	assert.Len(t, 0, index.Search("john", search.Query{Text: "secret"}))
+	assert.Equal(t, "req-3", hitIds(index.Search("john", search.Query{Text: "authorization"})))
+}
+
+func TestSearch_Filters(t *testing.T) {
+	index := makeIndex(false)
+
+	hits := index.Search("john", search.Query{Methods: []string{"post"}, Kinds: []search.Kind{search.REQUEST}})
+	assert.Equal(t, "req-2", hitIds(hits))
+
+	hits = index.Search("john", search.Query{Statuses: []int16{200}})
+	assert.Equal(t, "req-1,rsp-1", hitIds(hits))
+
+	hits = index.Search("john", search.Query{Collection: "col-1", Kinds: []search.Kind{search.REQUEST}})
+	assert.Equal(t, "req-2,req-1", hitIds(hits))
+
+	hits = index.Search("john", search.Query{Text: "users", Collection: "col-1"})
+	assert.Len(t, 0, hits)
+}
+
+func TestSearch_ResponseBodies(t *testing.T) {
+	assert.Len(t, 0, makeIndex(false).Search("john", search.Query{Text: "overdue"}))
+
+	hits := makeIndex(true).Search("john", search.Query{Text: "overdue"})
+	assert.Equal(t, "rsp-1", hitIds(hits))
+	assert.Equal(t, "req-1", hits[0].Request)
+	assert.Equal(t, "List invoices", hits[0].Name)
+}
+
+func TestSearch_Incremental(t *testing.T) {
+	index := makeIndex(false)
+
+	index.RemoveRequest("req-1")
+	assert.Len(t, 0, index.Search("john", search.Query{Text: "tenant"}))
+
+	index.PutCollection(collection.Collection{Id: "col-1", Owner: "john", Name: "Billing"})
+	hits := index.Search("john", search.Query{Collection: "col-1", Kinds: []search.Kind{search.REQUEST}})
+	assert.Len(t, 0, hits)
+
+	updated := makeRequest("req-2", "john", "Create payment", domain.POST, "https://api.local/payments", nil)
+	index.PutRequest(updated)
+	assert.Len(t, 0, index.Search("john", search.Query{Text: "invoice", Kinds: []search.Kind{search.REQUEST}}))
+	assert.Equal(t, "req-2", hitIds(index.Search("john", search.Query{Text: "payments"})))
+}
+
+func TestSearch_Reload(t *testing.T) {
+	index := makeIndex(false)
+
+	index.ReloadRequest(
+		makeRequest("req-2", "john", "Create invoice", domain.POST, "https://api.local/invoices", nil),
+		makeRequest("req-5", "john", "List payments", domain.GET, "https://api.local/payments", nil),
+	)
+
+	hits := index.Search("john", search.Query{Kinds: []search.Kind{search.REQUEST}})
+	assert.Equal(t, "req-2,req-5", hitIds(hits))
+	assert.Len(t, 0, index.Search("jane", search.Query{Kinds: []search.Kind{search.REQUEST}}))
+	assert.Len(t, 2, index.Search("john", search.Query{Kinds: []search.Kind{search.RESPONSE}}))
+
+	index.ReloadCollection()
+	assert.Len(t, 0, index.Search("john", search.Query{Kinds: []search.Kind{search.COLLECTION}}))
+}
+
+func TestSearch_Tags(t *testing.T) {
+	index := makeIndex(false)
+
+	tagged := makeRequest("req-1", "john", "List invoices", domain.GET, "https://api.local/invoices", nil)
+	tagged.Tags = []string{"Smoke", "billing"}
+	index.PutRequest(tagged)
+
+	hits := index.Search("john", search.Query{Tags: []string{"smoke"}})
+	assert.Equal(t, "req-1,rsp-1", hitIds(hits))
+
+	hits = index.Search("john", search.Query{Text: "smoke"})
+	assert.Equal(t, "req-1", hitIds(hits))
+
+	assert.Len(t, 0, index.Search("john", search.Query{Tags: []string{"deprecated"}}))
+}
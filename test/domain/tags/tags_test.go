@@ -0,0 +1,55 @@
+package tags_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Rafael24595/go-api-core/src/domain"
+	"github.com/Rafael24595/go-api-core/test/support/assert"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tags := domain.NormalizeTags("Smoke", " billing ", "smoke", "", "Smoke Test")
+	assert.Equal(t, "billing,smoke,smoke-test", strings.Join(tags, ","))
+}
+
+func TestMatchTags(t *testing.T) {
+	tags := []string{"billing", "smoke"}
+
+	assert.Equal(t, true, domain.MatchTags(tags))
+	assert.Equal(t, true, domain.MatchTags(tags, "SMOKE"))
+	assert.Equal(t, true, domain.MatchTags(tags, "deprecated", "billing"))
+	assert.Equal(t, false, domain.MatchTags(tags, "deprecated"))
+	assert.Equal(t, false, domain.MatchTags(nil, "smoke"))
+}
+
+func TestAddTags(t *testing.T) {
+	tags, ok := domain.AddTags([]string{"smoke"}, "Billing")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "billing,smoke", strings.Join(tags, ","))
+
+	_, ok = domain.AddTags(tags, "smoke")
+	assert.Equal(t, false, ok)
+}
+
+func TestRemoveTags(t *testing.T) {
+	tags, ok := domain.RemoveTags([]string{"billing", "smoke"}, "Smoke")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "billing", strings.Join(tags, ","))
+
+	_, ok = domain.RemoveTags(tags, "smoke")
+	assert.Equal(t, false, ok)
+}
+
+func TestRenameTag(t *testing.T) {
+	tags, ok := domain.RenameTag([]string{"billing", "smoke"}, "smoke", "sanity")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "billing,sanity", strings.Join(tags, ","))
+
+	tags, ok = domain.RenameTag([]string{"billing", "smoke"}, "smoke", "billing")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "billing", strings.Join(tags, ","))
+
+	_, ok = domain.RenameTag([]string{"billing"}, "smoke", "sanity")
+	assert.Equal(t, false, ok)
+}